@@ -0,0 +1,135 @@
+package fetch
+
+/*
+#include "../../csrc/fetch/cc.h"
+*/
+import "C"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+)
+
+// CcAlgo identifies a congestion control algorithm.
+type CcAlgo string
+
+// CcAlgo values.
+const (
+	CcCubic CcAlgo = "CUBIC"
+	CcAimd  CcAlgo = "AIMD"
+	CcBbr   CcAlgo = "BBR"
+	CcFixed CcAlgo = "FIXED"
+)
+
+var ccAlgoC = map[CcAlgo]C.FetchCcAlgo{
+	CcCubic: C.FetchCcCubic,
+	CcAimd:  C.FetchCcAimd,
+	CcBbr:   C.FetchCcBbr,
+	CcFixed: C.FetchCcFixed,
+}
+
+// Default congestion control parameters.
+const (
+	DefaultAimdDecrease = 0.5
+	DefaultFixedCwnd    = 64
+)
+
+// CcConfig contains congestion control configuration.
+type CcConfig struct {
+	// Algo selects the congestion control algorithm.
+	//  - CUBIC: TCP CUBIC, the default.
+	//  - AIMD: classic additive increase multiplicative decrease with slow start.
+	//  - BBR: BBR-like rate-based controller, which sets congestion window from estimated
+	//    bandwidth-delay product.
+	//  - FIXED: fixed congestion window, as a baseline for benchmarks.
+	Algo CcAlgo `json:"algo,omitempty" gqldesc:"Congestion control algorithm."`
+
+	// AimdDecrease is the factor multiplied to the congestion window upon a congestion mark or timeout.
+	// This is only relevant to AIMD algorithm.
+	// It must be between 0 and 1; default is 0.5.
+	AimdDecrease float64 `json:"aimdDecrease,omitempty" gqldesc:"AIMD window decrease factor."`
+
+	// FixedCwnd is the congestion window of FIXED algorithm.
+	// Default is 64.
+	FixedCwnd int `json:"fixedCwnd,omitempty" gqldesc:"FIXED congestion window."`
+}
+
+func (cfg *CcConfig) applyDefaults() {
+	if cfg.Algo == "" {
+		cfg.Algo = CcCubic
+	}
+	if cfg.AimdDecrease == 0 {
+		cfg.AimdDecrease = DefaultAimdDecrease
+	}
+	if cfg.FixedCwnd == 0 {
+		cfg.FixedCwnd = DefaultFixedCwnd
+	}
+}
+
+// Validate applies defaults and checks whether configuration is acceptable.
+func (cfg *CcConfig) Validate() error {
+	cfg.applyDefaults()
+	if _, ok := ccAlgoC[cfg.Algo]; !ok {
+		return fmt.Errorf("unknown congestion control algorithm %s", cfg.Algo)
+	}
+	if !(cfg.AimdDecrease > 0 && cfg.AimdDecrease < 1) {
+		return errors.New("AimdDecrease must be between 0 and 1")
+	}
+	if cfg.FixedCwnd < 1 || cfg.FixedCwnd > math.MaxUint32 {
+		return errors.New("FixedCwnd out of range")
+	}
+	return nil
+}
+
+func (cfg CcConfig) copyToC(c *C.FetchCcConfig) {
+	*c = C.FetchCcConfig{
+		algo:      ccAlgoC[cfg.Algo],
+		aimdBeta:  C.double(cfg.AimdDecrease),
+		fixedCwnd: C.uint32_t(cfg.FixedCwnd),
+	}
+}
+
+// CcState contains congestion control algorithm state.
+// Fields that are irrelevant to the algorithm are omitted.
+type CcState struct {
+	Ssthresh *float64      `json:"ssthresh,omitempty"` // CUBIC and AIMD: slow start threshold, omitted before first decrease
+	WMax     *float64      `json:"wMax,omitempty"`     // CUBIC: window size before last decrease
+	Phase    string        `json:"phase,omitempty"`    // BBR: state machine phase
+	BtlBw    *float64      `json:"btlBw,omitempty"`    // BBR: estimated bottleneck bandwidth, in Data per second
+	MinRtt   time.Duration `json:"minRtt,omitempty"`   // BBR: minimum RTT
+}
+
+var bbrPhaseStrings = map[C.BbrPhase]string{
+	C.BbrStartup: "startup",
+	C.BbrDrain:   "drain",
+	C.BbrProbeBw: "probeBw",
+}
+
+func readCcState(cc *C.FetchCc) (algo CcAlgo, st CcState) {
+	optional := func(v C.double) *float64 {
+		if f := float64(v); !math.IsNaN(f) && f < math.MaxFloat64 {
+			return &f
+		}
+		return nil
+	}
+
+	switch cc.algo {
+	case C.FetchCcCubic:
+		algo = CcCubic
+		st.Ssthresh, st.WMax = optional(cc.cubic.ssthresh), optional(cc.cubic.wMax)
+	case C.FetchCcAimd:
+		algo = CcAimd
+		st.Ssthresh = optional(cc.aimd.ssthresh)
+	case C.FetchCcBbr:
+		algo = CcBbr
+		st.Phase = bbrPhaseStrings[cc.bbr.phase]
+		st.BtlBw = optional(cc.bbr.btlBw * C.double(eal.TscHz))
+		st.MinRtt = eal.FromTscDuration(int64(cc.bbr.minRtt))
+	case C.FetchCcFixed:
+		algo = CcFixed
+	}
+	return
+}
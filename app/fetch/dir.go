@@ -0,0 +1,426 @@
+package fetch
+
+/*
+#include "../../csrc/fetch/fetcher.h"
+*/
+import "C"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/dpdk/pktmbuf"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
+	"github.com/usnistgov/ndn-dpdk/ndn/endpoint"
+	"github.com/usnistgov/ndn-dpdk/ndn/rdr"
+	"github.com/usnistgov/ndn-dpdk/ndn/rdr/ndn6file"
+	"github.com/usnistgov/ndn-dpdk/ndn/segmented"
+	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
+	"github.com/usnistgov/ndn-dpdk/ndni"
+	"go.uber.org/zap"
+	"go4.org/must"
+	"golang.org/x/sys/unix"
+)
+
+// dirRetxLimit is the retransmission limit of each Interest for metadata and directory listing.
+const dirRetxLimit = 3
+
+// DirProgress contains progress of a directory task.
+type DirProgress struct {
+	Listed     bool   `json:"listed" gqldesc:"Whether directory listing has been retrieved completely."`
+	NFiles     int    `json:"nFiles" gqldesc:"Number of files discovered."`
+	NFilesDone int    `json:"nFilesDone" gqldesc:"Number of files fetched."`
+	NBytes     int64  `json:"nBytes" gqldesc:"Total size of files discovered."`
+	NBytesDone int64  `json:"nBytesDone" gqldesc:"Total size of files fetched."`
+	Current    string `json:"current,omitempty" gqldesc:"Relative path of the earliest file being fetched."`
+	Error      string `json:"error,omitempty" gqldesc:"Error message, if the task has failed."`
+}
+
+func (d TaskDef) validateDirectory() error {
+	if d.SegmentBegin != 0 || d.SegmentEnd != 0 || d.Filename != "" || d.FileSize != nil || d.SegmentLen != 0 {
+		return errors.New("SegmentRange, Filename, FileSize, SegmentLen must be omitted in directory task")
+	}
+	if len(d.Prefix) == 0 {
+		return errors.New("Prefix must not be empty in directory task")
+	}
+	if e := os.MkdirAll(d.Directory, 0o777); e != nil {
+		return fmt.Errorf("os.MkdirAll(%s): %w", d.Directory, e)
+	}
+	return nil
+}
+
+type dirFile struct {
+	rel string
+	m   ndn6file.Metadata
+	fd  int
+}
+
+// dirTask retrieves a directory from ndn6-file-server.
+//
+// Its task slot is added to the worker for the whole duration, in multi-file mode (C.FetchFiles).
+// Metadata and directory listing are retrieved in Go via the RX queue of the task slot.
+// Files are then fetched by the worker, up to C.FetchMaxFiles files at a time.
+// Each file occupies a range of logical segment numbers, so that the congestion window is shared
+// and does not drain at file boundaries.
+// The worker signals an eventfd when the RX queue has packets or when files are completed.
+type dirTask struct {
+	task   *TaskContext
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+	logger *zap.Logger
+	files  []dirFile
+
+	ff     *C.FetchFiles
+	event  *os.File
+	notify chan struct{}
+	added  []dirFile // files in ff that are not yet closed
+	nAdded uint32
+
+	mutex    sync.Mutex
+	progress DirProgress
+	finished bool
+}
+
+// Progress returns current progress.
+func (dt *dirTask) Progress() DirProgress {
+	dt.mutex.Lock()
+	defer dt.mutex.Unlock()
+	return dt.progress
+}
+
+// Finished determines whether the directory task has completed or failed.
+func (dt *dirTask) Finished() bool {
+	dt.mutex.Lock()
+	defer dt.mutex.Unlock()
+	return dt.finished
+}
+
+func (dt *dirTask) update(f func(progress *DirProgress)) {
+	dt.mutex.Lock()
+	defer dt.mutex.Unlock()
+	f(&dt.progress)
+}
+
+func (dt *dirTask) run() {
+	defer close(dt.done)
+	dt.logger.Info("directory task start")
+
+	e := dt.execute()
+
+	dt.mutex.Lock()
+	defer dt.mutex.Unlock()
+	dt.progress.Current = ""
+	if e != nil {
+		dt.progress.Error = e.Error()
+		dt.logger.Warn("directory task failed", zap.Error(e))
+	} else {
+		dt.logger.Info("directory task finished",
+			zap.Int("files", dt.progress.NFilesDone),
+			zap.Int64("bytes", dt.progress.NBytesDone),
+		)
+	}
+	dt.finished = true
+}
+
+func (dt *dirTask) execute() error {
+	if e := dt.list(dt.task.d.Prefix, "."); e != nil {
+		return e
+	}
+	dt.update(func(progress *DirProgress) { progress.Listed = true })
+	return dt.fetchFiles()
+}
+
+// readEvents converts eventfd signals into dt.notify.
+// It returns when dt.event is closed.
+func (dt *dirTask) readEvents() {
+	var buf [8]byte
+	for {
+		if _, e := dt.event.Read(buf[:]); e != nil {
+			return
+		}
+		select {
+		case dt.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// list retrieves directory listing recursively, and appends discovered files to dt.files.
+func (dt *dirTask) list(prefix ndn.Name, rel string) error {
+	var m ndn6file.Metadata
+	if e := dt.retrieveMetadata(prefix, &m); e != nil {
+		return fmt.Errorf("retrieve metadata of %s: %w", prefix, e)
+	}
+	if !m.IsDir() {
+		return fmt.Errorf("%s mode %o is not a directory", prefix, m.Mode)
+	}
+
+	payload, e := dt.retrieveSegmented(m.Name, m.SegmentEnd())
+	if e != nil {
+		return fmt.Errorf("retrieve directory listing %s: %w", m.Name, e)
+	}
+	var ls ndn6file.DirectoryListing
+	if e := ls.UnmarshalBinary(payload); e != nil {
+		return fmt.Errorf("decode directory listing %s: %w", m.Name, e)
+	}
+
+	if e := os.MkdirAll(filepath.Join(dt.task.d.Directory, filepath.FromSlash(rel)), 0o777); e != nil {
+		return e
+	}
+
+	for _, entry := range ls {
+		name := entry.Name()
+		if name == "." || name == ".." || strings.ContainsAny(name, "/\\") {
+			dt.logger.Warn("skipping unsafe directory entry", zap.String("dir", rel), zap.String("name", name))
+			continue
+		}
+		childPrefix := prefix.Append(ndn.MakeNameComponent(an.TtGenericNameComponent, []byte(name)))
+		childRel := path.Join(rel, name)
+
+		if entry.IsDir() {
+			if e := dt.list(childPrefix, childRel); e != nil {
+				return e
+			}
+			continue
+		}
+
+		var fm ndn6file.Metadata
+		if e := dt.retrieveMetadata(childPrefix, &fm); e != nil {
+			return fmt.Errorf("retrieve metadata of %s: %w", childPrefix, e)
+		}
+		if !fm.IsFile() {
+			dt.logger.Warn("skipping non-regular file", zap.String("rel", childRel), zap.Uint16("mode", fm.Mode))
+			continue
+		}
+		dt.files = append(dt.files, dirFile{rel: childRel, m: fm})
+		dt.update(func(progress *DirProgress) {
+			progress.NFiles++
+			progress.NBytes += fm.Size
+		})
+	}
+	return nil
+}
+
+// fetchFiles fetches discovered files via the worker.
+func (dt *dirTask) fetchFiles() error {
+	pending := dt.files
+	var segEnd uint64
+	for {
+		for nDone := uint32(C.FetchFiles_GetDone(dt.ff)); dt.nAdded-uint32(len(dt.added)) != nDone; {
+			f := dt.added[0]
+			dt.added = dt.added[1:]
+			closeOutput(f.fd, &f.m.Size, dt.logger)
+			dt.fileDone(f)
+		}
+
+		nAdded := dt.nAdded
+		for len(pending) > 0 && len(dt.added) < C.FetchMaxFiles {
+			f := pending[0]
+			pending = pending[1:]
+			if f.m.SegmentEnd() == 0 {
+				if e := os.WriteFile(dt.filename(f), nil, 0o666); e != nil {
+					return fmt.Errorf("fetch %s: %w", f.rel, e)
+				}
+				dt.fileDone(f)
+				continue
+			}
+
+			var e error
+			if segEnd, e = dt.addFile(f, segEnd); e != nil {
+				return fmt.Errorf("fetch %s: %w", f.rel, e)
+			}
+		}
+		if dt.nAdded != nAdded {
+			C.FetchFiles_SetAdded(dt.ff, C.uint32_t(dt.nAdded))
+		}
+
+		if len(dt.added) == 0 {
+			return nil
+		}
+		dt.update(func(progress *DirProgress) { progress.Current = dt.added[0].rel })
+
+		select {
+		case <-dt.ctx.Done():
+			return dt.ctx.Err()
+		case <-dt.notify:
+		}
+	}
+}
+
+// addFile opens an output file and fills its record in dt.ff.
+// The file occupies logical segment numbers starting from segBegin.
+// The caller must publish the record via C.FetchFiles_SetAdded.
+func (dt *dirTask) addFile(f dirFile, segBegin uint64) (segEnd uint64, e error) {
+	d := TaskDef{
+		InterestTemplateConfig: dt.task.d.InterestTemplateConfig,
+		SegmentRange:           segmented.SegmentRange{SegmentEnd: f.m.SegmentEnd()},
+		Filename:               dt.filename(f),
+		SegmentLen:             f.m.SegmentSize,
+	}
+	d.Prefix = f.m.Name
+
+	file := &dt.ff.file[dt.nAdded%C.FetchMaxFiles]
+	if e = applyTemplate(&file.tpl, d.InterestTemplateConfig); e != nil {
+		return 0, e
+	}
+	if f.fd, e = openOutput(d, dt.logger); e != nil {
+		return 0, e
+	}
+
+	segEnd = segBegin + d.SegmentEnd
+	file.segBegin, file.segEnd = C.uint64_t(segBegin), C.uint64_t(segEnd)
+	file.segmentLen, file.fd = C.uint32_t(d.SegmentLen), C.int(f.fd)
+	dt.added = append(dt.added, f)
+	dt.nAdded++
+	return segEnd, nil
+}
+
+func (dt *dirTask) filename(f dirFile) string {
+	return filepath.Join(dt.task.d.Directory, filepath.FromSlash(f.rel))
+}
+
+func (dt *dirTask) fileDone(f dirFile) {
+	dt.update(func(progress *DirProgress) {
+		progress.NFilesDone++
+		progress.NBytesDone += f.m.Size
+	})
+}
+
+func (dt *dirTask) retrieveMetadata(name ndn.Name, m *ndn6file.Metadata) error {
+	data, e := dt.consume(rdr.MakeDiscoveryInterest(name))
+	if e != nil {
+		return e
+	}
+	if data.ContentType != an.ContentBlob {
+		return ndn.ErrContentType
+	}
+	return m.UnmarshalBinary(data.Content)
+}
+
+// retrieveSegmented retrieves a (small) segmented object one segment at a time.
+// If segmentEnd is zero, it continues until the Data carrying FinalBlock.
+func (dt *dirTask) retrieveSegmented(name ndn.Name, segmentEnd uint64) (payload []byte, e error) {
+	for seg := uint64(0); segmentEnd == 0 || seg < segmentEnd; seg++ {
+		data, e := dt.consume(ndn.Interest{
+			Name: name.Append(ndn.NameComponentFrom(an.TtSegmentNameComponent, tlv.NNI(seg))),
+		})
+		if e != nil {
+			return nil, e
+		}
+		payload = append(payload, data.Content...)
+		if data.IsFinalBlock() {
+			break
+		}
+	}
+	return payload, nil
+}
+
+// consume sends an Interest through the face and waits for Data in the RX queue of the task slot.
+// This must be called before any file is added, when the worker does not dequeue from the RX queue.
+func (dt *dirTask) consume(interest ndn.Interest) (data ndn.Data, e error) {
+	ts := dt.task.ts
+	cfg := dt.task.d.InterestTemplateConfig
+	cfg.Prefix, cfg.CanBePrefix, cfg.MustBeFresh = interest.Name, interest.CanBePrefix, interest.MustBeFresh
+	lifetime := cfg.InterestLifetime.Duration()
+	if lifetime == 0 {
+		lifetime = ndn.DefaultInterestLifetime
+	}
+
+	var tpl ndni.InterestTemplate
+	cfg.Apply(&tpl)
+	mp := ndni.InterestMempool.Get(dt.task.w.NumaSocket())
+	face := dt.task.fetcher.Face()
+	vec := make(pktmbuf.Vector, iface.MaxBurstSize)
+
+	for range dirRetxLimit + 1 {
+		interestVec, e := mp.Alloc(1)
+		if e != nil {
+			return data, e
+		}
+		pkt := tpl.Encode(interestVec[0], nil, rand.Uint32())
+		pkt.SetPitToken([]byte{byte(ts.index)})
+		iface.TxBurst(face.ID(), []*ndni.Packet{pkt})
+
+		if found, e := dt.waitData(interest, vec, time.After(lifetime), &data); found || e != nil {
+			return data, e
+		}
+	}
+	return data, endpoint.ErrExpire
+}
+
+// waitData waits for Data satisfying an Interest in the RX queue of the task slot.
+// The worker signals dt.event when the RX queue has packets, after C.FetchFiles_ArmRx.
+func (dt *dirTask) waitData(interest ndn.Interest, vec pktmbuf.Vector, expire <-chan time.Time, data *ndn.Data) (found bool, e error) {
+	for {
+		count, _ := dt.task.ts.RxQueueD().Pop(vec, eal.TscNow())
+		for _, m := range vec[:count] {
+			npkt := ndni.PacketFromPtr(m.Ptr()).ToNPacket()
+			if !found && npkt.Data != nil && npkt.Data.CanSatisfy(interest) {
+				*data, found = *npkt.Data, true
+			}
+		}
+		vec[:count].Close()
+		if found {
+			return true, nil
+		}
+
+		C.FetchFiles_ArmRx(dt.ff)
+		select {
+		case <-dt.ctx.Done():
+			return false, dt.ctx.Err()
+		case <-expire:
+			return false, nil
+		case <-dt.notify:
+		}
+	}
+}
+
+// close releases resources.
+// This must be called after the task slot has been removed from the worker.
+func (dt *dirTask) close() {
+	for _, f := range dt.added {
+		closeOutput(f.fd, nil, dt.logger)
+	}
+	dt.added = nil
+	dt.task.ts.files = nil
+	must.Close(dt.event)
+	eal.Free(dt.ff)
+}
+
+// newDirTask creates a directory task and enables multi-file mode in its task slot.
+// The task slot should be added to the worker afterwards.
+func newDirTask(task *TaskContext) (dt *dirTask, e error) {
+	efd, e := unix.Eventfd(0, unix.EFD_CLOEXEC|unix.EFD_NONBLOCK)
+	if e != nil {
+		return nil, fmt.Errorf("unix.Eventfd: %w", e)
+	}
+
+	dt = &dirTask{
+		task: task,
+		done: make(chan struct{}),
+		logger: logger.With(
+			zap.Int("slot-index", int(task.ts.index)),
+			zap.Stringer("prefix", task.d.Prefix),
+			zap.String("directory", task.d.Directory),
+		),
+		ff:     eal.Zmalloc[C.FetchFiles]("FetchFiles", C.sizeof_FetchFiles, task.w.NumaSocket()),
+		event:  os.NewFile(uintptr(efd), "FetchFiles.eventFd"),
+		notify: make(chan struct{}, 1),
+	}
+	dt.ff.eventFd = C.int(efd)
+	dt.ctx, dt.cancel = context.WithCancel(context.Background())
+	task.ts.files = dt.ff
+	go dt.readEvents()
+	return dt, nil
+}
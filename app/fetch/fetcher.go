@@ -102,10 +102,6 @@ func (fetcher *Fetcher) Fetch(d TaskDef) (task *TaskContext, e error) {
 			task, e = nil, errors.New("too many running tasks")
 			return
 		}
-		if e = task.ts.Init(d); e != nil {
-			task = nil
-			return
-		}
 
 		for _, w := range fetcher.workers {
 			if task.w.nTasks > w.nTasks {
@@ -113,7 +109,31 @@ func (fetcher *Fetcher) Fetch(d TaskDef) (task *TaskContext, e error) {
 			}
 		}
 
+		if d.Directory == "" {
+			if e = task.ts.Init(d); e != nil {
+				task = nil
+				return
+			}
+		} else {
+			if e = d.validateDirectory(); e != nil {
+				task = nil
+				return
+			}
+			// reset congestion control, all files will share this state
+			if e = task.ts.Init(TaskDef{
+				InterestTemplateConfig: d.InterestTemplateConfig,
+				CongestionControl:      d.CongestionControl,
+			}); e != nil {
+				task = nil
+				return
+			}
+			if task.dir, e = newDirTask(task); e != nil {
+				task = nil
+				return
+			}
+		}
 		task.w.AddTask(eal.MainReadSide, task.ts)
+
 		e = nil
 		taskContextLock.Lock()
 		defer taskContextLock.Unlock()
@@ -121,6 +141,10 @@ func (fetcher *Fetcher) Fetch(d TaskDef) (task *TaskContext, e error) {
 		task.id = lastTaskContextID
 		taskContextByID[task.id] = task
 	})
+
+	if task != nil && task.dir != nil {
+		go task.dir.run()
+	}
 	return
 }
 
@@ -137,6 +161,13 @@ func (fetcher *Fetcher) Stop() error {
 // Reset aborts all tasks and stops all worker threads.
 func (fetcher *Fetcher) Reset() {
 	fetcher.Stop()
+	for _, task := range fetcher.Tasks() {
+		if task.dir != nil {
+			task.dir.cancel()
+			<-task.dir.done
+			task.dir.close()
+		}
+	}
 	for _, w := range fetcher.workers {
 		w.ClearTasks()
 	}
@@ -0,0 +1,48 @@
+package fetchtest
+
+/*
+#include "../../../csrc/fetch/aimd.h"
+*/
+import "C"
+import (
+	"testing"
+)
+
+func ctestAimd(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	ca := &C.Aimd{}
+	C.Aimd_Init(ca, 0.5)
+
+	cwnd := func() int { return int(C.Aimd_GetCwnd(ca)) }
+	increase := func() { C.Aimd_Increase(ca) }
+	decrease := func() { C.Aimd_Decrease(ca) }
+
+	assert.Equal(2, cwnd())
+
+	// slow start
+	for range 98 {
+		increase()
+	}
+	assert.Equal(100, cwnd())
+
+	// enter congestion avoidance
+	decrease()
+	assert.Equal(50, cwnd())
+
+	// additive increase: one per window
+	for range 50 {
+		increase()
+	}
+	assert.Equal(50, cwnd())
+	for range 60 {
+		increase()
+	}
+	assert.Equal(52, cwnd())
+
+	// multiplicative decrease, not below 1
+	for range 10 {
+		decrease()
+	}
+	assert.Equal(1, cwnd())
+}
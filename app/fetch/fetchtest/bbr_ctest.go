@@ -0,0 +1,45 @@
+package fetchtest
+
+/*
+#include "../../../csrc/fetch/bbr.h"
+*/
+import "C"
+import (
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+)
+
+func ctestBbr(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	ca := &C.Bbr{}
+	C.Bbr_Init(ca)
+
+	now := eal.TscNow()
+	const rtt = 10 * time.Millisecond
+	rttTsc := eal.ToTscDuration(rtt)
+
+	cwnd := func() int { return int(C.Bbr_GetCwnd(ca)) }
+	// simulate a bottleneck of 10 Data per millisecond, i.e. BDP is 100
+	run := func(d time.Duration) {
+		for end := now.Add(d); now < end; now = now.Add(100 * time.Microsecond) {
+			C.Bbr_Increase(ca, C.TscTime(now), C.TscDuration(rttTsc), C.double(rttTsc))
+		}
+	}
+
+	assert.Equal(2, cwnd())
+	assert.EqualValues(C.BbrStartup, ca.phase)
+
+	run(500 * time.Millisecond)
+	assert.EqualValues(C.BbrProbeBw, ca.phase)
+	assert.InDelta(10000, float64(ca.btlBw)*float64(eal.TscHz), 1000)
+	assert.EqualValues(rttTsc, ca.minRtt)
+	assert.InDelta(100, cwnd(), 30)
+
+	// congestion mark reduces cwnd to no more than BDP
+	C.Bbr_Decrease(ca)
+	assert.LessOrEqual(cwnd(), 110)
+	assert.GreaterOrEqual(cwnd(), 4)
+}
@@ -0,0 +1,193 @@
+package fetchtest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/app/fetch"
+	"github.com/usnistgov/ndn-dpdk/app/tg/tgtestenv"
+	"github.com/usnistgov/ndn-dpdk/iface/intface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
+	"github.com/usnistgov/ndn-dpdk/ndn/rdr"
+	"github.com/usnistgov/ndn-dpdk/ndn/rdr/ndn6file"
+	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
+)
+
+const testDirSegmentLen = 1000
+
+type testDirObject struct {
+	m       ndn6file.Metadata
+	payload []byte
+}
+
+// testDirServer is a minimal ndn6-file-server.
+type testDirServer map[string]*testDirObject
+
+func (srv testDirServer) Add(prefix string, isDir bool, payload []byte) {
+	name := ndn.ParseName(prefix)
+	if isDir {
+		name = name.Append(ndn6file.KeywordLs)
+	}
+	name = name.Append(ndn.NameComponentFrom(an.TtVersionNameComponent, tlv.NNI(1)))
+
+	obj := &testDirObject{payload: payload}
+	obj.m.Name = name
+	obj.m.SegmentSize = testDirSegmentLen
+	obj.m.Size = int64(len(payload))
+	if isDir {
+		obj.m.Mode = syscall.S_IFDIR | 0o755
+	} else {
+		obj.m.Mode = syscall.S_IFREG | 0o644
+	}
+	if nSegs := (len(payload) + testDirSegmentLen - 1) / testDirSegmentLen; nSegs > 0 {
+		obj.m.FinalBlock = ndn.NameComponentFrom(an.TtSegmentNameComponent, tlv.NNI(nSegs-1))
+	}
+
+	srv[ndn.ParseName(prefix).String()] = obj
+	srv[name.String()] = obj
+}
+
+func (srv testDirServer) Serve(interest ndn.Interest) *ndn.Data {
+	if rdr.IsDiscoveryInterest(interest) {
+		obj := srv[interest.Name.GetPrefix(-1).String()]
+		if obj == nil {
+			return nil
+		}
+		content, _ := obj.m.MarshalBinary()
+		data := ndn.MakeData(interest, interest.Name.Append(
+			ndn.NameComponentFrom(an.TtVersionNameComponent, tlv.NNI(2)),
+			ndn.NameComponentFrom(an.TtSegmentNameComponent, tlv.NNI(0)),
+		), ndn.FinalBlockFlag, time.Millisecond, content)
+		return &data
+	}
+
+	obj := srv[interest.Name.GetPrefix(-1).String()]
+	lastComp := interest.Name.Get(-1)
+	var segNum tlv.NNI
+	if obj == nil || lastComp.Type != an.TtSegmentNameComponent || segNum.UnmarshalBinary(lastComp.Value) != nil {
+		return nil
+	}
+	offset := int(segNum) * testDirSegmentLen
+	if offset >= len(obj.payload) && offset > 0 {
+		return nil
+	}
+	data := ndn.MakeData(interest, obj.payload[offset:min(offset+testDirSegmentLen, len(obj.payload))])
+	if obj.m.FinalBlock.Equal(lastComp) {
+		data.FinalBlock = lastComp
+	}
+	return &data
+}
+
+func TestFetcherDirectory(t *testing.T) {
+	assert, require := makeAR(t)
+
+	intFace := intface.MustNew()
+	t.Cleanup(func() { intFace.D.Close() })
+
+	var cfg fetch.Config
+	cfg.NThreads = 1
+	cfg.NTasks = 2
+	cfg.WindowCapacity = testFetcherWindowCapacity
+
+	fetcher, e := fetch.New(intFace.D, cfg)
+	require.NoError(e)
+	tgtestenv.Open(t, fetcher)
+	t.Cleanup(func() { fetcher.Close() })
+	fetcher.Launch()
+
+	payloadA, payloadB := make([]byte, 3000), make([]byte, 2500)
+	randBytes(payloadA)
+	randBytes(payloadB)
+	srv := testDirServer{}
+	srv.Add("/D", true, []byte("a.bin\x00sub/\x00empty\x00many/\x00"))
+	srv.Add("/D/a.bin", false, payloadA)
+	srv.Add("/D/empty", false, nil)
+	srv.Add("/D/sub", true, []byte("b.bin\x00"))
+	srv.Add("/D/sub/b.bin", false, payloadB)
+
+	// more files than a task slot can fetch concurrently
+	expectedFiles := map[string][]byte{
+		"a.bin":     payloadA,
+		"empty":     {},
+		"sub/b.bin": payloadB,
+	}
+	nExpectedSegs, nExpectedBytes := 6, len(payloadA)+len(payloadB)
+	var manyListing []byte
+	for i := range 40 {
+		filename := fmt.Sprintf("%02d.bin", i)
+		payload := make([]byte, 1+i*97)
+		randBytes(payload)
+		srv.Add("/D/many/"+filename, false, payload)
+		manyListing = append(manyListing, filename+"\x00"...)
+		expectedFiles["many/"+filename] = payload
+		nExpectedSegs += (len(payload) + testDirSegmentLen - 1) / testDirSegmentLen
+		nExpectedBytes += len(payload)
+	}
+	srv.Add("/D/many", true, manyListing)
+
+	go func() {
+		for packet := range intFace.Rx {
+			if packet.Interest == nil {
+				continue
+			}
+			if data := srv.Serve(*packet.Interest); data != nil {
+				intFace.Tx <- *data
+			}
+		}
+	}()
+
+	outDir := filepath.Join(t.TempDir(), "out")
+	var d fetch.TaskDef
+	d.Prefix = ndn.ParseName("/D")
+	d.Directory = outDir
+	task, e := fetcher.Fetch(d)
+	require.NoError(e)
+
+	ticker := time.NewTicker(time.Millisecond)
+	defer ticker.Stop()
+	for range ticker.C {
+		if task.Finished() {
+			break
+		}
+	}
+	cnt := task.Counters()
+	task.Stop()
+
+	t.Logf("Counters %v", cnt)
+	require.NotNil(cnt.Dir)
+	assert.Empty(cnt.Dir.Error)
+	assert.True(cnt.Dir.Listed)
+	assert.Equal(len(expectedFiles), cnt.Dir.NFiles)
+	assert.Equal(len(expectedFiles), cnt.Dir.NFilesDone)
+	assert.EqualValues(nExpectedBytes, cnt.Dir.NBytesDone)
+	assert.NotNil(cnt.Finished)
+	assert.EqualValues(nExpectedSegs, cnt.NRxData)
+
+	for rel, payload := range expectedFiles {
+		written, e := os.ReadFile(filepath.Join(outDir, rel))
+		if assert.NoError(e, rel) {
+			assert.Equal(payload, written, rel)
+		}
+	}
+
+	d.Prefix = ndn.ParseName("/D/a.bin")
+	task, e = fetcher.Fetch(d)
+	require.NoError(e)
+	for range ticker.C {
+		if task.Finished() {
+			break
+		}
+	}
+	cnt = task.Counters()
+	task.Stop()
+	assert.Contains(cnt.Dir.Error, "not a directory")
+
+	d.Filename = "x.bin"
+	_, e = fetcher.Fetch(d)
+	assert.Error(e)
+}
@@ -176,6 +176,30 @@ func TestFetcher(t *testing.T) {
 		assert.InDelta(float64(ft.NInterests), float64(cnt.NTxRetx+cnt.NRxData), testFetcherWindowCapacity)
 	})
 
+	for i, cc := range []fetch.CcConfig{
+		{Algo: fetch.CcAimd, AimdDecrease: 0.7},
+		{Algo: fetch.CcBbr},
+		{Algo: fetch.CcFixed, FixedCwnd: 32},
+	} {
+		name := rune('J' + i)
+		t.Run(string(name), func(t *testing.T) {
+			assert, _ := makeAR(t)
+
+			ft := newTestFetcherTask(name)
+			ft.SegmentBegin, ft.SegmentEnd = 0, 2000
+			ft.CongestionControl = &cc
+			ftByName[name] = ft
+			// bounded by SegmentRange, alternative congestion control
+
+			cnt := ft.Run(t, fetcher)
+			assert.EqualValues(ft.SegmentEnd-ft.SegmentBegin, cnt.NRxData)
+			assert.Equal(cc.Algo, cnt.Cc)
+			if cc.Algo == fetch.CcFixed {
+				assert.Equal(cc.FixedCwnd, cnt.Cwnd)
+			}
+		})
+	}
+
 	go func() {
 		for packet := range intFace.Rx {
 			if !assert.NotNil(packet.Interest) || !assert.Len(packet.Interest.Name, 2) {
@@ -29,7 +29,7 @@ func ctestLogic(t *testing.T) {
 
 	fl.Reset(segmented.SegmentRange{
 		SegmentEnd: finalSeg + 1,
-	})
+	}, fetch.CcConfig{Algo: fetch.CcCubic, AimdDecrease: fetch.DefaultAimdDecrease, FixedCwnd: fetch.DefaultFixedCwnd})
 
 	rxData := make(chan uint64)
 	txCounts := map[uint64]int{}
@@ -2,6 +2,7 @@ package fetch
 
 import (
 	"errors"
+	"maps"
 	"reflect"
 
 	"github.com/graphql-go/graphql"
@@ -20,6 +21,9 @@ var GqlRetrieveByFaceID func(id iface.ID) *Fetcher
 // GraphQL types.
 var (
 	GqlConfigInput     *graphql.InputObject
+	GqlCcAlgoEnum      *graphql.Enum
+	GqlCcConfigInput   *graphql.InputObject
+	GqlCcConfigType    *graphql.Object
 	GqlTaskDefInput    *graphql.InputObject
 	GqlTaskDefType     *graphql.Object
 	GqlTaskContextType *gqlserver.NodeType[*TaskContext]
@@ -35,15 +39,34 @@ func init() {
 		}),
 	})
 
+	GqlCcAlgoEnum = gqlserver.NewStringEnum("FetchCcAlgo", "Fetcher congestion control algorithm.", CcCubic, CcAimd, CcBbr, CcFixed)
+	ccFieldTypes := gqlserver.FieldTypes{
+		reflect.TypeFor[CcAlgo](): GqlCcAlgoEnum,
+	}
+	GqlCcConfigInput = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "FetchCcConfigInput",
+		Description: "Fetcher congestion control config.",
+		Fields:      gqlserver.BindInputFields[CcConfig](ccFieldTypes),
+	})
+	GqlCcConfigType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "FetchCcConfig",
+		Description: "Fetcher congestion control config.",
+		Fields:      gqlserver.BindFields[CcConfig](ccFieldTypes),
+	})
+
+	taskDefInputFieldTypes := maps.Clone(ndni.GqlInterestTemplateFieldTypes)
+	taskDefInputFieldTypes[reflect.TypeFor[CcConfig]()] = GqlCcConfigInput
 	GqlTaskDefInput = graphql.NewInputObject(graphql.InputObjectConfig{
 		Name:        "FetchTaskDefInput",
 		Description: "Fetch task definition.",
-		Fields:      gqlserver.BindInputFields[TaskDef](ndni.GqlInterestTemplateFieldTypes),
+		Fields:      gqlserver.BindInputFields[TaskDef](taskDefInputFieldTypes),
 	})
+	taskDefFieldTypes := maps.Clone(ndni.GqlInterestTemplateFieldTypes)
+	taskDefFieldTypes[reflect.TypeFor[CcConfig]()] = GqlCcConfigType
 	GqlTaskDefType = graphql.NewObject(graphql.ObjectConfig{
 		Name:        "FetchTaskDef",
 		Description: "Fetch task definition.",
-		Fields:      gqlserver.BindFields[TaskDef](ndni.GqlInterestTemplateFieldTypes),
+		Fields:      gqlserver.BindFields[TaskDef](taskDefFieldTypes),
 	})
 
 	GqlTaskContextType = gqlserver.NewNodeType(graphql.ObjectConfig{
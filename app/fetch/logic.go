@@ -25,9 +25,12 @@ func (fl *Logic) Init(winCapacity int, socket eal.NumaSocket) {
 }
 
 // Reset resets this to initial state.
-func (fl *Logic) Reset(r segmented.SegmentRange) {
+// cc must have been validated.
+func (fl *Logic) Reset(r segmented.SegmentRange, cc CcConfig) {
 	r.SegmentRangeApplyDefaults()
-	C.FetchLogic_Reset(fl.ptr(), C.uint64_t(r.SegmentBegin), C.uint64_t(r.SegmentEnd))
+	var ccC C.FetchCcConfig
+	cc.copyToC(&ccC)
+	C.FetchLogic_Reset(fl.ptr(), C.uint64_t(r.SegmentBegin), C.uint64_t(r.SegmentEnd), &ccC)
 }
 
 // Close deallocates data structures.
@@ -51,7 +54,8 @@ func (fl *Logic) Counters() (cnt Counters) {
 	cnt.LastRtt = eal.FromTscDuration(int64(fl.rtte.last))
 	cnt.SRtt = eal.FromTscDuration(int64(fl.rtte.rttv.sRtt))
 	cnt.Rto = eal.FromTscDuration(int64(fl.rtte.rto))
-	cnt.Cwnd = int(C.TcpCubic_GetCwnd(&fl.ca))
+	cnt.Cwnd = int(C.FetchCc_GetCwnd(&fl.cc))
+	cnt.Cc, cnt.CcState = readCcState(&fl.cc)
 	cnt.NInFlight = uint32(fl.nInFlight)
 	cnt.NTxRetx = uint64(fl.nTxRetx)
 	cnt.NRxData = uint64(fl.nRxData)
@@ -66,13 +70,15 @@ type Counters struct {
 	SRtt      time.Duration  `json:"sRtt" gqldesc:"Smoothed RTT."`
 	Rto       time.Duration  `json:"rto" gqldesc:"RTO."`
 	Cwnd      int            `json:"cwnd" gqldesc:"Congestion window."`
+	Cc        CcAlgo         `json:"cc" gqldesc:"Congestion control algorithm."`
+	CcState   CcState        `json:"ccState" gqldesc:"Congestion control algorithm state."`
 	NInFlight uint32         `json:"nInFlight" gqldesc:"Currently in-flight Interests."`
 	NTxRetx   uint64         `json:"nTxRetx" gqldesc:"Retransmitted Interests."`
 	NRxData   uint64         `json:"nRxData" gqldesc:"Data satisfying pending Interests."`
 }
 
 func (cnt Counters) String() string {
-	return fmt.Sprintf("rtt=%dms srtt=%dms rto=%dms cwnd=%d %dP %dR %dD",
+	return fmt.Sprintf("rtt=%dms srtt=%dms rto=%dms cc=%s cwnd=%d %dP %dR %dD",
 		cnt.LastRtt.Milliseconds(), cnt.SRtt.Milliseconds(), cnt.Rto.Milliseconds(),
-		cnt.Cwnd, cnt.NInFlight, cnt.NTxRetx, cnt.NRxData)
+		cnt.Cc, cnt.Cwnd, cnt.NInFlight, cnt.NTxRetx, cnt.NRxData)
 }
@@ -34,20 +34,40 @@ type TaskContext struct {
 	fetcher  *Fetcher
 	w        *worker
 	ts       *taskSlot
+	dir      *dirTask
 	stopping chan struct{}
 }
 
 // Counters returns congestion control and scheduling counters.
 func (task *TaskContext) Counters() Counters {
-	return task.ts.Logic().Counters()
+	cnt := task.ts.Logic().Counters()
+	if task.dir != nil {
+		// Logic finishes whenever added files are completed; report finished only when the directory task has ended
+		progress := task.dir.Progress()
+		cnt.Dir = &progress
+		if !task.dir.Finished() {
+			cnt.Finished = nil
+		} else if cnt.Finished == nil {
+			cnt.Finished = &cnt.Elapsed
+		}
+	}
+	return cnt
 }
 
 // Stop aborts/stops the fetch task.
 // This should be called even if the fetch task has succeeded.
+// It may be called on any thread, because the goroutine of a directory task does not depend on the main thread.
 func (task *TaskContext) Stop() {
+	if task.dir != nil {
+		task.dir.cancel()
+		<-task.dir.done
+	}
 	eal.CallMain(func() {
 		task.w.RemoveTask(eal.MainReadSide, task.ts)
 		task.ts.closeFd(task.d.FileSize)
+		if task.dir != nil {
+			task.dir.close()
+		}
 		close(task.stopping)
 		taskContextLock.Lock()
 		defer taskContextLock.Unlock()
@@ -56,7 +76,11 @@ func (task *TaskContext) Stop() {
 }
 
 // Finished determines if all segments have been fetched.
+// For a directory task, this is true when all files have been fetched or an error has occurred.
 func (task *TaskContext) Finished() bool {
+	if task.dir != nil {
+		return task.dir.Finished()
+	}
 	return task.ts.Logic().Finished()
 }
 
@@ -87,6 +111,18 @@ type TaskDef struct {
 	// This is only needed when writing to a file.
 	// If any segment has incorrect Content TLV-LENGTH, the output file would not contain correct payload.
 	SegmentLen int `json:"segmentLen,omitempty"`
+
+	// CongestionControl selects congestion control algorithm.
+	// Default is TCP CUBIC.
+	CongestionControl *CcConfig `json:"congestionControl,omitempty"`
+
+	// Directory is the output directory of a directory task.
+	//
+	// If set, Prefix refers to a directory on ndn6-file-server.
+	// The fetcher retrieves its directory listing recursively, and then fetches every file into
+	// this local directory, several files at a time, sharing one congestion window.
+	// SegmentRange, Filename, FileSize, and SegmentLen must be omitted.
+	Directory string `json:"directory,omitempty"`
 }
 
 // TaskSlotConfig contains task slot configuration.
@@ -109,17 +145,19 @@ type taskSlot C.FetchTask
 // Init (re-)initializes the task slot to perform a fetch task.
 // This should only be called on an inactive task slot.
 func (ts *taskSlot) Init(d TaskDef) error {
-	fl := ts.Logic()
-	fl.Reset(d.SegmentRange)
+	var cc CcConfig
+	if d.CongestionControl != nil {
+		cc = *d.CongestionControl
+	}
+	if e := cc.Validate(); e != nil {
+		return e
+	}
 
-	tpl := ndni.InterestTemplateFromPtr(unsafe.Pointer(&ts.tpl))
-	d.InterestTemplateConfig.Apply(tpl)
+	ts.Logic().Reset(d.SegmentRange, cc)
 
-	// FetchTask_DecodeData expects SegmentNameComponent TLV-TYPE at prefixV[prefixL]
-	if uintptr(ts.tpl.prefixL+1) >= unsafe.Sizeof(ts.tpl.prefixV) {
-		return errors.New("name too long")
+	if e := applyTemplate(&ts.tpl, d.InterestTemplateConfig); e != nil {
+		return e
 	}
-	ts.tpl.prefixV[ts.tpl.prefixL] = an.TtSegmentNameComponent
 
 	logEntry := logger.With(
 		zap.Int("slot-index", int(ts.index)),
@@ -127,16 +165,9 @@ func (ts *taskSlot) Init(d TaskDef) error {
 	)
 
 	if d.Filename != "" {
-		if d.SegmentLen <= 0 || d.SegmentLen > math.MaxUint32 {
-			return errors.New("bad SegmentLen")
-		}
-		if d.SegmentEnd <= d.SegmentBegin || d.SegmentEnd > math.MaxUint32 {
-			return errors.New("bad SegmentEnd")
-		}
-
-		fd, e := unix.Open(d.Filename, unix.O_WRONLY|unix.O_CREAT, 0o666)
+		fd, e := openOutput(d, logEntry)
 		if e != nil {
-			return fmt.Errorf("unix.Open(%s): %w", d.Filename, e)
+			return e
 		}
 
 		logEntry = logEntry.With(
@@ -144,22 +175,12 @@ func (ts *taskSlot) Init(d TaskDef) error {
 			zap.Int("fd", fd),
 			zap.Int("segment-len", d.SegmentLen),
 		)
-
-		offsetBegin := int64(d.SegmentBegin) * int64(d.SegmentLen)
-		offsetEnd := int64(d.SegmentEnd) * int64(d.SegmentLen)
-		if e := unix.Fallocate(fd, 0, offsetBegin, offsetEnd-offsetBegin); e != nil {
-			logEntry.Warn("unix.Fallocate error, this may affect write performance",
-				zap.Int64("offset-begin", offsetBegin),
-				zap.Int64("offset-end", offsetEnd),
-				zap.Error(e),
-			)
-		}
-
 		ts.fd, ts.segmentLen = C.int(fd), C.uint32_t(d.SegmentLen)
 	}
 
 	logEntry.Info("task init",
 		zap.Uint64s("segment-range", []uint64{d.SegmentBegin, d.SegmentEnd}),
+		zap.String("cc", string(cc.Algo)),
 	)
 	return nil
 }
@@ -179,9 +200,52 @@ func (ts *taskSlot) closeFd(fileSize *int64) {
 	if fd < 0 {
 		return
 	}
+	closeOutput(fd, fileSize, logger.With(zap.Int("slot-index", int(ts.index))))
+	ts.fd = -1
+}
+
+// applyTemplate prepares the Interest template of a task slot or a file in a directory task.
+func applyTemplate(tpl *C.InterestTemplate, cfg ndni.InterestTemplateConfig) error {
+	cfg.Apply(ndni.InterestTemplateFromPtr(unsafe.Pointer(tpl)))
+
+	// FetchTask_DecodeData expects SegmentNameComponent TLV-TYPE at prefixV[prefixL]
+	if uintptr(tpl.prefixL+1) >= unsafe.Sizeof(tpl.prefixV) {
+		return errors.New("name too long")
+	}
+	tpl.prefixV[tpl.prefixL] = an.TtSegmentNameComponent
+	return nil
+}
 
-	logEntry := logger.With(
-		zap.Int("slot-index", int(ts.index)),
+// openOutput opens the output file of d.Filename and preallocates its space.
+func openOutput(d TaskDef, logEntry *zap.Logger) (fd int, e error) {
+	if d.SegmentLen <= 0 || d.SegmentLen > math.MaxUint32 {
+		return -1, errors.New("bad SegmentLen")
+	}
+	if d.SegmentEnd <= d.SegmentBegin || d.SegmentEnd > math.MaxUint32 {
+		return -1, errors.New("bad SegmentEnd")
+	}
+
+	if fd, e = unix.Open(d.Filename, unix.O_WRONLY|unix.O_CREAT, 0o666); e != nil {
+		return -1, fmt.Errorf("unix.Open(%s): %w", d.Filename, e)
+	}
+
+	offsetBegin := int64(d.SegmentBegin) * int64(d.SegmentLen)
+	offsetEnd := int64(d.SegmentEnd) * int64(d.SegmentLen)
+	if e := unix.Fallocate(fd, 0, offsetBegin, offsetEnd-offsetBegin); e != nil {
+		logEntry.Warn("unix.Fallocate error, this may affect write performance",
+			zap.String("filename", d.Filename),
+			zap.Int("fd", fd),
+			zap.Int64("offset-begin", offsetBegin),
+			zap.Int64("offset-end", offsetEnd),
+			zap.Error(e),
+		)
+	}
+	return fd, nil
+}
+
+// closeOutput truncates the output file to fileSize, if not nil, and closes it.
+func closeOutput(fd int, fileSize *int64, logEntry *zap.Logger) {
+	logEntry = logEntry.With(
 		zap.Int("fd", fd),
 		zap.Int64p("file-size", fileSize),
 	)
@@ -201,7 +265,6 @@ func (ts *taskSlot) closeFd(fileSize *int64) {
 	}
 
 	logEntry.Info("task output file closed")
-	ts.fd = -1
 }
 
 func newTaskSlot(index int, cfg TaskSlotConfig, socket eal.NumaSocket) (ts *taskSlot) {
@@ -34,10 +34,11 @@ const (
 type Config struct {
 	LCoreAlloc ealthread.Config `json:"-"`
 
-	Ndt      ndt.Config         `json:"ndt,omitempty"`
-	Fib      fibdef.Config      `json:"fib,omitempty"`
-	Pcct     pcct.Config        `json:"pcct,omitempty"`
-	Suppress pit.SuppressConfig `json:"suppress,omitempty"`
+	Ndt        ndt.Config         `json:"ndt,omitempty"`
+	NdtBalance ndt.BalanceConfig  `json:"ndtBalance,omitempty"`
+	Fib        fibdef.Config      `json:"fib,omitempty"`
+	Pcct       pcct.Config        `json:"pcct,omitempty"`
+	Suppress   pit.SuppressConfig `json:"suppress,omitempty"`
 
 	Crypto                CryptoConfig         `json:"crypto,omitempty"`
 	Disk                  DiskConfig           `json:"disk,omitempty"`
@@ -119,6 +120,7 @@ func DefaultAlloc() (m map[string]eal.LCores, e error) {
 // DataPlane represents the forwarder data plane.
 type DataPlane struct {
 	ndt      *ndt.Ndt
+	ndtb     *ndt.Balancer
 	fib      *fib.Fib
 	dispatch []DispatchThread
 	fwis     []*Input
@@ -133,6 +135,11 @@ func (dp *DataPlane) Ndt() *ndt.Ndt {
 	return dp.ndt
 }
 
+// NdtBalancer returns the NDT balancer, or nil if automatic NDT load balancing is disabled.
+func (dp *DataPlane) NdtBalancer() *ndt.Balancer {
+	return dp.ndtb
+}
+
 // Fib returns the FIB.
 func (dp *DataPlane) Fib() *fib.Fib {
 	return dp.fib
@@ -160,6 +167,9 @@ func (dp *DataPlane) Close() error {
 		lcores = append(lcores, txl.LCore())
 	}
 
+	if dp.ndtb != nil {
+		errs = append(errs, dp.ndtb.Close())
+	}
 	errs = append(errs, iface.CloseAll())
 	if dp.ndt != nil {
 		errs = append(errs, dp.ndt.Close())
@@ -314,6 +324,10 @@ func New(cfg Config) (dp *DataPlane, e error) {
 		ealthread.Launch(fwi.rxl)
 	}
 
+	if cfg.NdtBalance.Enabled() {
+		dp.ndtb = ndt.NewBalancer(dp.ndt, len(dp.fwds), cfg.NdtBalance)
+	}
+
 	iface.RxParseFor = ndni.ParseForFw
 	return dp, nil
 }
@@ -33,6 +33,8 @@ type Fwd struct {
 	queueI *iface.PktQueue
 	queueD *iface.PktQueue
 	queueN *iface.PktQueue
+
+	cancelFaceClosed func()
 }
 
 var (
@@ -43,6 +45,11 @@ var (
 // Close stops and releases the thread.
 func (fwd *Fwd) Close() error {
 	defer eal.Free(fwd.c)
+	defer eal.Free(fwd.c.faceStat)
+	defer eal.Free(fwd.c.faceGen)
+	if fwd.cancelFaceClosed != nil {
+		fwd.cancelFaceClosed()
+	}
 	return errors.Join(
 		fwd.Stop(),
 		fwd.queueI.Close(),
@@ -111,6 +118,15 @@ func (Fwd) ThreadRole() string {
 	return RoleFwd
 }
 
+// clearFaceStat resets per-face status of a closed face, so that it is not inherited by a new face with same ID.
+// The record is reset by the forwarding thread, because it may be updating the record concurrently.
+func (fwd *Fwd) clearFaceStat(id iface.ID) {
+	if !id.Valid() {
+		return
+	}
+	C.FwFwd_ResetFaceStat(fwd.c, C.FaceID(id))
+}
+
 // newFwd creates a forwarding thread.
 // FIB must be assigned before starting the thread.
 func newFwd(id int, lc eal.LCore, pcctCfg pcct.Config, qcfgI, qcfgD, qcfgN iface.PktQueueConfig,
@@ -122,6 +138,9 @@ func newFwd(id int, lc eal.LCore, pcctCfg pcct.Config, qcfgI, qcfgD, qcfgN iface
 	}
 
 	fwd.c.id = C.uint8_t(fwd.id)
+	fwd.c.faceStat = eal.Zmalloc[C.FwFaceStat]("FwFaceStat", C.sizeof_FwFaceStat*(iface.MaxID-iface.MinID+1), socket)
+	fwd.c.faceGen = eal.Zmalloc[C.uint32_t]("FwFaceGen", C.sizeof_uint32_t*(iface.MaxID-iface.MinID+1), socket)
+	fwd.cancelFaceClosed = iface.OnFaceClosed(fwd.clearFaceStat)
 	fwd.ThreadWithCtrl = ealthread.NewThreadWithCtrl(
 		cptr.Func0.C(C.FwFwd_Run, fwd.c),
 		unsafe.Pointer(&fwd.c.ctrl),
@@ -601,3 +601,49 @@ func TestCongMark(t *testing.T) {
 		}
 	}
 }
+
+func TestCongMarkAggregate(t *testing.T) {
+	assert, _ := makeAR(t)
+	fixture := NewFixture(t)
+
+	face1, face2, face3 := intface.MustNew(), intface.MustNew(), intface.MustNew()
+	collect1, collect2, collect3 := intface.Collect(face1), intface.Collect(face2), intface.Collect(face3)
+	fixture.SetFibEntry("/A", "multicast", face1.ID)
+	name1, name2 := ndn.ParseName("/A/1"), ndn.ParseName("/A/2")
+
+	// face2 Interests carry congestion mark, aggregated with face3 Interests
+	face2.Tx <- ndn.MakeInterest(name1, ndn.LpL3{CongMark: 1})
+	face2.Tx <- ndn.MakeInterest(name2, ndn.LpL3{CongMark: 1})
+	face3.Tx <- ndn.MakeInterest(name1)
+	face3.Tx <- ndn.MakeInterest(name2)
+	fixture.StepDelay()
+
+	// forwarded Interests carry congestion mark of the downstream that triggered forwarding
+	if received := collect1.Clear(); assert.Len(received, 2) {
+		for _, pkt := range received {
+			assert.EqualValues(1, pkt.Lp.CongMark)
+			data := ndn.MakeData(pkt.Interest).ToPacket()
+			if pkt.Interest.Name.Equal(name2) {
+				data.Lp.CongMark = 1
+			}
+			face1.Tx <- data
+		}
+	}
+	fixture.StepDelay()
+
+	// marked Data carries the mark to every downstream, unmarked Data carries downstream's own mark
+	if received := collect2.Clear(); assert.Len(received, 2) {
+		for _, pkt := range received {
+			assert.EqualValues(1, pkt.Lp.CongMark)
+		}
+	}
+	if received := collect3.Clear(); assert.Len(received, 2) {
+		for _, pkt := range received {
+			if pkt.Data.Name.Equal(name2) {
+				assert.EqualValues(1, pkt.Lp.CongMark)
+			} else {
+				assert.EqualValues(0, pkt.Lp.CongMark)
+			}
+		}
+	}
+}
@@ -102,3 +102,28 @@ func TestReturnNacks(t *testing.T) {
 	assert.Equal(1, collect1.Count())
 	assert.NotNil(collect1.Get(-1).Nack)
 }
+
+func TestNackCongMark(t *testing.T) {
+	assert, _ := makeAR(t)
+	fixture := NewFixture(t)
+
+	face1, face2 := intface.MustNew(), intface.MustNew()
+	collect1, collect2 := intface.Collect(face1), intface.Collect(face2)
+	fixture.SetFibEntry("/A", "multicast", face2.ID)
+
+	token := makeToken()
+	face1.Tx <- ndn.MakeInterest("/A/1", token.LpL3())
+	fixture.StepDelay()
+	assert.Equal(1, collect2.Count())
+
+	nack := ndn.MakeNack(collect2.Get(-1).Interest, an.NackCongestion).ToPacket()
+	nack.Lp.CongMark = 1
+	face2.Tx <- nack
+	fixture.StepDelay()
+	assert.Equal(1, collect1.Count())
+
+	if packet := collect1.Get(-1); assert.NotNil(packet.Nack) {
+		assert.EqualValues(1, packet.Lp.CongMark)
+		assert.EqualValues(token, packet.Lp.PitToken)
+	}
+}
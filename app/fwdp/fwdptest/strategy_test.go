@@ -88,6 +88,45 @@ func TestFastroute(t *testing.T) {
 	assert.Equal(3, collect3.Count()) // no Interest to face3 because it's DOWN
 }
 
+func TestFastrouteCongested(t *testing.T) {
+	assert, _ := makeAR(t)
+	fixture := NewFixture(t)
+
+	face1, face2, face3, face4 := intface.MustNew(), intface.MustNew(), intface.MustNew(), intface.MustNew()
+	collect1, collect2, collect3 := intface.Collect(face1), intface.Collect(face2), intface.Collect(face3)
+	fixture.SetFibEntry("/C/D", "fastroute", face1.ID, face2.ID, face3.ID)
+
+	// multicast first Interest, face3 replies Data
+	face4.Tx <- ndn.MakeInterest("/C/D/0")
+	fixture.StepDelay()
+	face3.Tx <- ndn.MakeData(collect3.Get(-1).Interest)
+	fixture.StepDelay()
+
+	sendBurst := func(seq int) {
+		for i := range 80 {
+			face4.Tx <- ndn.MakeInterest(fmt.Sprintf("/C/D/%d/%d", seq, i))
+		}
+		fixture.StepDelay()
+	}
+
+	// without congestion, probing happens every 1024 Interests
+	sendBurst(1)
+	assert.Equal(1, collect1.Count())
+	assert.Equal(1, collect2.Count())
+	assert.Equal(81, collect3.Count())
+
+	// face3 replies Data with congestion mark
+	pkt := ndn.MakeData(collect3.Get(-1).Interest).ToPacket()
+	pkt.Lp.CongMark = 1
+	face3.Tx <- pkt
+	time.Sleep(10 * time.Millisecond) // congestion mark is considered recent for 100ms
+
+	// while face3 is congested, probing happens every 64 Interests
+	sendBurst(2)
+	assert.Equal(4, collect1.Count()+collect2.Count())
+	assert.Equal(161, collect3.Count())
+}
+
 func TestFastrouteProbe(t *testing.T) {
 	assert, _ := makeAR(t)
 	fixture := NewFixture(t)
@@ -317,3 +356,102 @@ func TestWeighted(t *testing.T) {
 	assert.InDelta(w1/(w1+w2+w3), float64(n41)/float64(n41+n42+n43), 0.1)
 	assert.InDelta(w2/(w1+w2+w3), float64(n42)/float64(n41+n42+n43), 0.1)
 }
+
+func TestAsf(t *testing.T) {
+	assert, _ := makeAR(t)
+	fixture := NewFixture(t)
+
+	face1, face2, face3, face4 := intface.MustNew(), intface.MustNew(), intface.MustNew(), intface.MustNew()
+	fixture.SetFibEntryParams("/F", "asf", map[string]any{"probeInterval": 100, "timeout": 100, "maxPenalty": 3},
+		face1.ID, face2.ID, face3.ID)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	var nData int
+	startConsumer := func() { // 500 Interests per second
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tick := time.NewTicker(2 * time.Millisecond)
+			defer tick.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case t := <-tick.C:
+					face4.Tx <- ndn.MakeInterest(fmt.Sprintf("/F/F/%d", t.UnixNano()))
+				case pkt := <-face4.Rx:
+					if pkt.Data != nil {
+						nData++
+					}
+				}
+			}
+		}()
+	}
+	startProducer := func(face *intface.IntFace) (cnt *int, delay *time.Duration, drop *bool) {
+		type DelayedData struct {
+			Timer <-chan time.Time
+			Data  ndn.Data
+		}
+		cnt, delay, drop = new(int), new(time.Duration), new(bool)
+		queue := make(chan DelayedData, 65536)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case record := <-queue:
+					<-record.Timer
+					face.Tx <- record.Data
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case pkt := <-face.Rx:
+					if pkt.Interest != nil {
+						*cnt++
+						if !*drop {
+							queue <- DelayedData{
+								Timer: time.After(*delay),
+								Data:  ndn.MakeData(pkt.Interest),
+							}
+						}
+					}
+				}
+			}
+		}()
+		return
+	}
+	cnt1, delay1, _ := startProducer(face1)
+	cnt2, delay2, drop2 := startProducer(face2)
+	cnt3, delay3, _ := startProducer(face3)
+
+	// face2 is fastest, strategy should converge to face2
+	*delay1, *delay2, *delay3 = 20*time.Millisecond, 1*time.Millisecond, 30*time.Millisecond
+	startConsumer()
+	time.Sleep(1 * time.Second)
+	*cnt1, *cnt2, *cnt3 = 0, 0, 0
+	time.Sleep(2 * time.Second)
+	assert.Greater(*cnt2/4, *cnt1)
+	assert.Greater(*cnt2/4, *cnt3)
+
+	// face2 stops responding, strategy should fail over to face1 after timeouts
+	*drop2 = true
+	time.Sleep(1 * time.Second)
+	*cnt1, *cnt2, *cnt3, nData = 0, 0, 0, 0
+	time.Sleep(2 * time.Second)
+	assert.Greater(*cnt1/4, *cnt2)
+	assert.Greater(*cnt1/4, *cnt3)
+	assert.Greater(nData, 800)
+}
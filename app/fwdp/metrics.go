@@ -0,0 +1,46 @@
+package fwdp
+
+import (
+	"github.com/usnistgov/ndn-dpdk/core/metrics"
+)
+
+func init() {
+	metrics.AddCollector(func(w *metrics.Writer) {
+		dp := GqlDataPlane
+		if dp == nil {
+			return
+		}
+
+		for _, fwd := range dp.fwds {
+			label := metrics.L("fwd", fwd.id)
+			w.Struct("fwd", fwd.Counters(), label)
+			w.Struct("pit", fwd.Pit().Counters(), label)
+			w.Struct("cs", fwd.Cs().Counters(), label)
+		}
+
+		for _, th := range dp.dispatch {
+			cnt := ReadDispatchCounters(th, len(dp.fwds))
+			dispatchLabel := metrics.L("dispatch", th.DispatchThreadID())
+			for _, t := range []struct {
+				name string
+				help string
+				list []uint64
+			}{
+				{"dispatch_interests_queued", "Interests enqueued toward forwarding thread.", cnt.NInterestsQueued},
+				{"dispatch_interests_dropped", "Interests dropped toward forwarding thread.", cnt.NInterestsDropped},
+				{"dispatch_data_queued", "Data enqueued toward forwarding thread.", cnt.NDataQueued},
+				{"dispatch_data_dropped", "Data dropped toward forwarding thread.", cnt.NDataDropped},
+				{"dispatch_nacks_queued", "Nacks enqueued toward forwarding thread.", cnt.NNacksQueued},
+				{"dispatch_nacks_dropped", "Nacks dropped toward forwarding thread.", cnt.NNacksDropped},
+			} {
+				for i, n := range t.list {
+					w.Counter(t.name, t.help, n, dispatchLabel, metrics.L("fwd", i))
+				}
+			}
+		}
+
+		if dp.fwdisk != nil {
+			w.Struct("disk", dp.fwdisk.store.Counters())
+		}
+	})
+}
@@ -7,14 +7,67 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 )
 
 // Header constants.
 const (
 	Magic   = 0x35F0498A
-	Version = 2
+	Version = 3
 )
 
+// Action identifies the action type of a log entry.
+type Action uint8
+
+// Action types.
+const (
+	ActInterest      Action = 1 // Interest TX since RX
+	ActData          Action = 2 // retrieved Data TX since RX
+	ActNack          Action = 3 // Nack TX since Interest or Nack RX
+	ActCachedData    Action = 4 // in-memory cached Data TX since Interest RX
+	ActDiskData      Action = 5 // disk cached Data TX since Interest RX, including disk read
+	ActPitExpiry     Action = 6 // PIT entry expiry since Interest RX
+	ActCryptoDequeue Action = 7 // Data dequeued by crypto helper since RX
+)
+
+var actionStrings = map[Action]string{
+	ActInterest:      "OI",
+	ActData:          "OD",
+	ActNack:          "ON",
+	ActCachedData:    "OC",
+	ActDiskData:      "OS",
+	ActPitExpiry:     "PE",
+	ActCryptoDequeue: "CQ",
+}
+
+func (act Action) String() string {
+	if s, ok := actionStrings[act]; ok {
+		return s
+	}
+	return strconv.Itoa(int(act))
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+func (act Action) MarshalText() ([]byte, error) {
+	return []byte(act.String()), nil
+}
+
+// Entry is a decoded log entry.
+type Entry struct {
+	Action Action
+	LCore  uint8
+	Value  uint64 // duration in TSC unit
+}
+
+// ParseEntry decodes a log entry.
+func ParseEntry(entry uint64) Entry {
+	return Entry{
+		Action: Action(entry),
+		LCore:  uint8(entry >> 8),
+		Value:  entry >> 16,
+	}
+}
+
 // Reader represents a reader for high resolution logs.
 type Reader struct {
 	file  *os.File
@@ -0,0 +1,24 @@
+package hrlogreader_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/usnistgov/ndn-dpdk/app/hrlog/hrlogreader"
+	"github.com/usnistgov/ndn-dpdk/core/testenv"
+)
+
+func TestParseEntry(t *testing.T) {
+	assert, _ := testenv.MakeAR(t)
+
+	entry := hrlogreader.ParseEntry(0xA0B1C2D3E4F5_17_05)
+	assert.Equal(hrlogreader.ActDiskData, entry.Action)
+	assert.EqualValues(0x17, entry.LCore)
+	assert.EqualValues(0xA0B1C2D3E4F5, entry.Value)
+
+	assert.Equal("OS", entry.Action.String())
+	assert.Equal("200", hrlogreader.Action(200).String())
+
+	j, _ := json.Marshal(entry)
+	assert.JSONEq(`{"Action":"OS","LCore":23,"Value":176685338322165}`, string(j))
+}
@@ -0,0 +1,160 @@
+package nfdserver
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/container/rib"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/mgmt/nfdmgmt"
+	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
+)
+
+var errNoReply = errors.New("no reply")
+
+// localhostPrefix is the name prefix of /localhost scope.
+var localhostPrefix = ndn.ParseName("/localhost")
+
+type commandHandler func(s *Server, cp nfdmgmt.ControlParameters, dnFace iface.ID) nfdmgmt.ControlResponse
+
+var commandHandlers = map[string]commandHandler{
+	ndn.ParseName("/rib/register").String():   (*Server).ribRegister,
+	ndn.ParseName("/rib/unregister").String(): (*Server).ribUnregister,
+}
+
+func makeControlResponse(status int, text string, body ...tlv.Fielder) (cr nfdmgmt.ControlResponse) {
+	cr.StatusCode, cr.StatusText = status, text
+	if text == "" {
+		cr.StatusText = http.StatusText(status)
+	}
+	if len(body) > 0 {
+		cr.Body, _ = tlv.EncodeFrom(body...)
+	}
+	return cr
+}
+
+func (s *Server) makeControlResponseData(interest ndn.Interest, cr nfdmgmt.ControlResponse) (data ndn.Data, e error) {
+	content, e := tlv.EncodeFrom(cr)
+	if e != nil {
+		return data, e
+	}
+	return ndn.MakeData(interest, content), nil
+}
+
+// handleCommand authorizes and dispatches a control command.
+//
+// The face on which the command arrived is extracted from the IncomingFaceId field added by the forwarder.
+// Commands must be signed and must arrive on a local face.
+// The signature is not verified: the command prefix is in /localhost scope, so that local faces are trusted.
+func (s *Server) handleCommand(interest ndn.Interest, h commandHandler) nfdmgmt.ControlResponse {
+	_, cp, e := nfdmgmt.ParseCommandInterest(s.prefix, interest)
+	if e != nil {
+		return makeControlResponse(400, e.Error())
+	}
+	if interest.SigInfo == nil || len(interest.SigValue) == 0 {
+		return makeControlResponse(403, "command must be signed")
+	}
+
+	dnFace := iface.ID(interest.ToPacket().Lp.IncomingFaceID)
+	if !isLocalFace(dnFace) {
+		return makeControlResponse(403, "command must arrive on a local face")
+	}
+	return h(s, cp, dnFace)
+}
+
+// isLocalFace determines whether a face is connected to a local application.
+func isLocalFace(id iface.ID) bool {
+	face := iface.Get(id)
+	return face != nil && isLocalScheme(face.Locator().Scheme())
+}
+
+// isLocalScheme determines whether a locator scheme indicates a local face.
+// "pipe" is the scheme of internal faces.
+func isLocalScheme(scheme string) bool {
+	switch scheme {
+	case "unix", "memif", "pipe":
+		return true
+	}
+	return false
+}
+
+// lookupFace determines the face in rib/register and rib/unregister commands.
+//
+// As in NFD, an omitted or zero FaceId refers to the face on which the command arrived.
+func lookupFace(cp *nfdmgmt.ControlParameters, dnFace iface.ID) (id iface.ID, cr *nfdmgmt.ControlResponse) {
+	switch {
+	case cp.FaceID <= 0:
+		id = dnFace
+	case cp.FaceID <= iface.MaxID:
+		id = iface.ID(cp.FaceID)
+	}
+	if !id.Valid() || iface.Get(id) == nil {
+		resp := makeControlResponse(410, "face not found")
+		return 0, &resp
+	}
+	return id, nil
+}
+
+func (s *Server) ribRegister(cp nfdmgmt.ControlParameters, dnFace iface.ID) nfdmgmt.ControlResponse {
+	if len(cp.Name) == 0 {
+		return makeControlResponse(400, "Name is required")
+	}
+	id, cr := lookupFace(&cp, dnFace)
+	if cr != nil {
+		return *cr
+	}
+
+	cp.FaceID = int(id)
+	cp.Origin = max(cp.Origin, rib.OriginApp)
+	cp.Cost = max(cp.Cost, 0)
+	if cp.Flags < 0 {
+		cp.Flags = int(rib.RouteChildInherit)
+	}
+
+	r := rib.Route{
+		RouteKey: rib.RouteKey{
+			Name:   cp.Name,
+			Face:   id,
+			Origin: cp.Origin,
+		},
+		Cost:  cp.Cost,
+		Flags: rib.RouteFlags(cp.Flags),
+	}
+	if cp.ExpirationPeriod >= 0 {
+		r.Expires = time.Now().Add(cp.ExpirationPeriod)
+	}
+	if e := s.rib.Insert(r); e != nil {
+		return makeControlResponse(400, e.Error())
+	}
+	return makeControlResponse(200, "", cp)
+}
+
+func (s *Server) ribUnregister(cp nfdmgmt.ControlParameters, dnFace iface.ID) nfdmgmt.ControlResponse {
+	if len(cp.Name) == 0 {
+		return makeControlResponse(400, "Name is required")
+	}
+	id, cr := lookupFace(&cp, dnFace)
+	if cr != nil {
+		return *cr
+	}
+
+	resp := nfdmgmt.ControlParameters{
+		Name:             cp.Name,
+		FaceID:           int(id),
+		Origin:           max(cp.Origin, rib.OriginApp),
+		Cost:             -1,
+		Flags:            -1,
+		ExpirationPeriod: -1,
+	}
+	e := s.rib.Erase(rib.RouteKey{
+		Name:   resp.Name,
+		Face:   id,
+		Origin: resp.Origin,
+	})
+	if e != nil && !errors.Is(e, rib.ErrRouteNotFound) { // erasing a nonexistent route is not an error in NFD
+		return makeControlResponse(400, e.Error())
+	}
+	return makeControlResponse(200, "", resp)
+}
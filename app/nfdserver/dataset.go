@@ -0,0 +1,259 @@
+package nfdserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/core/version"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
+	"github.com/usnistgov/ndn-dpdk/ndn/mgmt/nfdmgmt"
+	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
+)
+
+// Status dataset TLV-TYPE assigned numbers.
+const (
+	ttFaceStatus      = 0x80
+	ttURI             = 0x72
+	ttLocalURI        = 0x81
+	ttFaceScope       = 0x84
+	ttFacePersistency = 0x85
+	ttLinkType        = 0x86
+	ttNInInterests    = 0x90
+	ttNInData         = 0x91
+	ttNInNacks        = 0x97
+	ttNOutInterests   = 0x92
+	ttNOutData        = 0x93
+	ttNOutNacks       = 0x98
+	ttNInBytes        = 0x94
+	ttNOutBytes       = 0x95
+
+	ttFibEntry      = 0x80
+	ttNextHopRecord = 0x81
+
+	ttRibEntry = 0x80
+	ttRoute    = 0x81
+
+	ttNfdVersion            = 0x80
+	ttStartTimestamp        = 0x81
+	ttCurrentTimestamp      = 0x82
+	ttNNameTreeEntries      = 0x83
+	ttNFibEntries           = 0x84
+	ttNPitEntries           = 0x85
+	ttNMeasurementsEntries  = 0x86
+	ttNCsEntries            = 0x87
+	ttNSatisfiedInterests   = 0x99
+	ttNUnsatisfiedInterests = 0x9A
+)
+
+const (
+	datasetChunkSize = 4096
+	datasetFreshness = time.Second
+)
+
+type datasetHandler func(s *Server) []tlv.Fielder
+
+var datasetHandlers = map[string]datasetHandler{
+	ndn.ParseName("/faces/list").String():     (*Server).listFaces,
+	ndn.ParseName("/fib/list").String():       (*Server).listFib,
+	ndn.ParseName("/rib/list").String():       (*Server).listRib,
+	ndn.ParseName("/status/general").String(): (*Server).generalStatus,
+}
+
+// dataset is a generated status dataset.
+// It is retained so that subsequent segments are served from the same version.
+type dataset struct {
+	version  ndn.NameComponent
+	segments [][]byte
+}
+
+func (s *Server) serveDataset(interest ndn.Interest, verb string, h datasetHandler) (data ndn.Data, e error) {
+	prefixLen := len(s.prefix) + 2
+	var ds *dataset
+	var seg int
+	switch len(interest.Name) {
+	case prefixLen: // initial Interest, generate a new version
+		ds = s.generateDataset(h)
+		s.datasetsMutex.Lock()
+		s.datasets[verb] = ds
+		s.datasetsMutex.Unlock()
+	case prefixLen + 2: // subsequent Interest, serve from retained version
+		version, segment := interest.Name[prefixLen], interest.Name[prefixLen+1]
+		var segNum tlv.NNI
+		if segment.Type != an.TtSegmentNameComponent || segNum.UnmarshalBinary(segment.Value) != nil {
+			return data, errNoReply
+		}
+		s.datasetsMutex.Lock()
+		ds = s.datasets[verb]
+		s.datasetsMutex.Unlock()
+		if ds == nil || !ds.version.Equal(version) || int(segNum) >= len(ds.segments) {
+			return data, errNoReply
+		}
+		seg = int(segNum)
+	default:
+		return data, errNoReply
+	}
+
+	name := interest.Name[:prefixLen].Append(ds.version, makeSegmentNameComponent(seg))
+	return ndn.MakeData(interest, name, datasetFreshness, ds.segments[seg],
+		ndn.FinalBlock(makeSegmentNameComponent(len(ds.segments)-1))), nil
+}
+
+func (s *Server) generateDataset(h datasetHandler) (ds *dataset) {
+	content, _ := tlv.EncodeFrom(h(s)...)
+	ds = &dataset{
+		version:  ndn.NameComponentFrom(an.TtVersionNameComponent, tlv.NNI(time.Now().UnixMilli())),
+		segments: slices.Collect(slices.Chunk(content, datasetChunkSize)),
+	}
+	if len(ds.segments) == 0 {
+		ds.segments = [][]byte{{}}
+	}
+	return ds
+}
+
+func makeSegmentNameComponent(seg int) ndn.NameComponent {
+	return ndn.NameComponentFrom(an.TtSegmentNameComponent, tlv.NNI(seg))
+}
+
+func (s *Server) listFaces() (list []tlv.Fielder) {
+	for _, face := range iface.List() {
+		id, loc, cnt := face.ID(), face.Locator(), face.Counters()
+		uri, localURI := makeFaceURIs(id, loc)
+		scope := 0 // non-local
+		if isLocalScheme(loc.Scheme()) {
+			scope = 1 // local
+		}
+		persistency := 2 // permanent
+		if face.Persistency() == iface.PersistencyOnDemand {
+			persistency = 1 // on-demand
+		}
+		list = append(list, tlv.TLVFrom(ttFaceStatus,
+			tlv.TLVNNI(nfdmgmt.TtFaceID, id),
+			tlv.TLVBytes(ttURI, []byte(uri)),
+			tlv.TLVBytes(ttLocalURI, []byte(localURI)),
+			tlv.TLVNNI(ttFaceScope, scope),
+			tlv.TLVNNI(ttFacePersistency, persistency),
+			tlv.TLVNNI(ttLinkType, 0), // point-to-point
+			tlv.TLVNNI(ttNInInterests, cnt.RxInterests),
+			tlv.TLVNNI(ttNInData, cnt.RxData),
+			tlv.TLVNNI(ttNInNacks, cnt.RxNacks),
+			tlv.TLVNNI(ttNOutInterests, cnt.TxInterests),
+			tlv.TLVNNI(ttNOutData, cnt.TxData),
+			tlv.TLVNNI(ttNOutNacks, cnt.TxNacks),
+			tlv.TLVNNI(ttNInBytes, cnt.RxOctets),
+			tlv.TLVNNI(ttNOutBytes, cnt.TxOctets),
+			tlv.TLVNNI(nfdmgmt.TtFlags, 0),
+		))
+	}
+	return
+}
+
+// makeFaceURIs derives NFD FaceUri strings from a face locator.
+// Remote and local addresses are taken from common locator fields; otherwise, the face ID is used.
+func makeFaceURIs(id iface.ID, loc iface.Locator) (uri, localURI string) {
+	var m map[string]any
+	json.Unmarshal([]byte(iface.LocatorString(loc)), &m)
+
+	pick := func(addrKey, ipKey, portKey string) string {
+		if addr, ok := m[addrKey].(string); ok && addr != "" {
+			return addr
+		}
+		if ip, ok := m[ipKey].(string); ok && ip != "" {
+			if port, ok := m[portKey].(float64); ok {
+				return net.JoinHostPort(ip, strconv.Itoa(int(port)))
+			}
+			return ip
+		}
+		return strconv.Itoa(int(id))
+	}
+	scheme := loc.Scheme()
+	return fmt.Sprintf("%s://%s", scheme, pick("remote", "remoteIP", "remoteUDP")),
+		fmt.Sprintf("%s://%s", scheme, pick("local", "localIP", "localUDP"))
+}
+
+func (s *Server) listFib() (list []tlv.Fielder) {
+	for _, entry := range s.dp.Fib().List() {
+		fields := []tlv.Fielder{entry.Name}
+		for _, nh := range entry.Nexthops {
+			// FIB entry has no per-nexthop cost; cost is taken from RIB routes, or zero if absent
+			cost, _ := s.rib.NexthopCost(entry.Name, nh)
+			fields = append(fields, tlv.TLV(ttNextHopRecord,
+				tlv.TLVNNI(nfdmgmt.TtFaceID, nh),
+				tlv.TLVNNI(nfdmgmt.TtCost, cost),
+			))
+		}
+		list = append(list, tlv.TLVFrom(ttFibEntry, fields...))
+	}
+	return
+}
+
+func (s *Server) listRib() (list []tlv.Fielder) {
+	routes := s.rib.List()
+	now := time.Now()
+	for i := 0; i < len(routes); {
+		name := routes[i].Name
+		fields := []tlv.Fielder{name}
+		for ; i < len(routes) && routes[i].Name.Equal(name); i++ {
+			r := routes[i]
+			rf := []tlv.Field{
+				tlv.TLVNNI(nfdmgmt.TtFaceID, r.Face),
+				tlv.TLVNNI(nfdmgmt.TtOrigin, r.Origin),
+				tlv.TLVNNI(nfdmgmt.TtCost, r.Cost),
+				tlv.TLVNNI(nfdmgmt.TtFlags, r.Flags),
+			}
+			if !r.Expires.IsZero() {
+				rf = append(rf, tlv.TLVNNI(nfdmgmt.TtExpirationPeriod, max(0, r.Expires.Sub(now).Milliseconds())))
+			}
+			fields = append(fields, tlv.TLV(ttRoute, rf...))
+		}
+		list = append(list, tlv.TLVFrom(ttRibEntry, fields...))
+	}
+	return
+}
+
+func (s *Server) generalStatus() (list []tlv.Fielder) {
+	var nPit, nCs, nSatisfied, nUnsatisfied uint64
+	for _, fwd := range s.dp.Fwds() {
+		pitCnt, csCnt := fwd.Pit().Counters(), fwd.Cs().Counters()
+		nPit += pitCnt.NEntries
+		nSatisfied += pitCnt.NDataHit
+		nUnsatisfied += pitCnt.NExpired
+		nCs += uint64(csCnt.DirectEntries + csCnt.IndirectEntries)
+	}
+
+	var faceCnt iface.Counters
+	for _, face := range iface.List() {
+		cnt := face.Counters()
+		faceCnt.RxInterests += cnt.RxInterests
+		faceCnt.RxData += cnt.RxData
+		faceCnt.RxNacks += cnt.RxNacks
+		faceCnt.TxInterests += cnt.TxInterests
+		faceCnt.TxData += cnt.TxData
+		faceCnt.TxNacks += cnt.TxNacks
+	}
+
+	nFib := s.dp.Fib().Len()
+	return []tlv.Fielder{
+		tlv.TLVBytes(ttNfdVersion, []byte("NDN-DPDK "+version.V.String())),
+		tlv.TLVNNI(ttStartTimestamp, s.start.UnixMilli()),
+		tlv.TLVNNI(ttCurrentTimestamp, time.Now().UnixMilli()),
+		tlv.TLVNNI(ttNNameTreeEntries, nFib),
+		tlv.TLVNNI(ttNFibEntries, nFib),
+		tlv.TLVNNI(ttNPitEntries, nPit),
+		tlv.TLVNNI(ttNMeasurementsEntries, 0),
+		tlv.TLVNNI(ttNCsEntries, nCs),
+		tlv.TLVNNI(ttNInInterests, faceCnt.RxInterests),
+		tlv.TLVNNI(ttNInData, faceCnt.RxData),
+		tlv.TLVNNI(ttNInNacks, faceCnt.RxNacks),
+		tlv.TLVNNI(ttNOutInterests, faceCnt.TxInterests),
+		tlv.TLVNNI(ttNOutData, faceCnt.TxData),
+		tlv.TLVNNI(ttNOutNacks, faceCnt.TxNacks),
+		tlv.TLVNNI(ttNSatisfiedInterests, nSatisfied),
+		tlv.TLVNNI(ttNUnsatisfiedInterests, nUnsatisfied),
+	}
+}
@@ -0,0 +1,144 @@
+// Package nfdserver implements a responder of NFD management protocol.
+package nfdserver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/app/fwdp"
+	"github.com/usnistgov/ndn-dpdk/container/rib"
+	"github.com/usnistgov/ndn-dpdk/core/logging"
+	"github.com/usnistgov/ndn-dpdk/iface/intface"
+	"github.com/usnistgov/ndn-dpdk/iface/socketface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/endpoint"
+	"github.com/usnistgov/ndn-dpdk/ndn/l3"
+	"github.com/usnistgov/ndn-dpdk/ndn/mgmt/nfdmgmt"
+	"go.uber.org/zap"
+	"go4.org/must"
+)
+
+var logger = logging.New("nfdserver")
+
+// Config contains NFD management responder configuration.
+type Config struct {
+	// Prefix is the command prefix.
+	// It must be in /localhost scope, because commands are authorized by their incoming face only.
+	// Default is /localhost/nfd.
+	Prefix ndn.Name `json:"prefix,omitempty"`
+
+	// Face configures the internal face.
+	Face socketface.Config `json:"face"`
+}
+
+// Server is an NFD management responder.
+//
+// It runs on an internal face, which receives Interests under the command prefix via a RIB route.
+type Server struct {
+	prefix ndn.Name
+	dp     *fwdp.DataPlane
+	rib    *rib.RIB
+	face   *intface.IntFace
+	p      endpoint.Producer
+	route  rib.RouteKey
+	start  time.Time
+
+	datasetsMutex sync.Mutex
+	datasets      map[string]*dataset
+}
+
+// Close stops the server.
+func (s *Server) Close() error {
+	errs := []error{
+		s.p.Close(),
+	}
+	if e := s.rib.Erase(s.route); e != nil && !errors.Is(e, rib.ErrRouteNotFound) {
+		errs = append(errs, e)
+	}
+	errs = append(errs, s.face.D.Close())
+	return errors.Join(errs...)
+}
+
+func (s *Server) handle(ctx context.Context, interest ndn.Interest) (ndn.Data, error) {
+	if len(interest.Name) < len(s.prefix)+2 {
+		return ndn.Data{}, errNoReply
+	}
+	verb := interest.Name[len(s.prefix) : len(s.prefix)+2].String()
+
+	if h, ok := datasetHandlers[verb]; ok {
+		return s.serveDataset(interest, verb, h)
+	}
+
+	var cr nfdmgmt.ControlResponse
+	if h, ok := commandHandlers[verb]; ok {
+		cr = s.handleCommand(interest, h)
+	} else {
+		cr = makeControlResponse(501, "unsupported command")
+	}
+
+	logger.Debug("control command",
+		zap.Stringer("name", interest.Name),
+		zap.Int("status", cr.StatusCode),
+		zap.String("text", cr.StatusText),
+	)
+	return s.makeControlResponseData(interest, cr)
+}
+
+// New creates a Server.
+//
+// The caller must provide the forwarder data plane and the RIB.
+func New(dp *fwdp.DataPlane, r *rib.RIB, cfg Config) (s *Server, e error) {
+	s = &Server{
+		prefix:   cfg.Prefix,
+		dp:       dp,
+		rib:      r,
+		start:    time.Now(),
+		datasets: map[string]*dataset{},
+	}
+	if len(s.prefix) == 0 {
+		s.prefix = nfdmgmt.PrefixLocalhost
+	}
+	if !localhostPrefix.IsPrefixOf(s.prefix) {
+		return nil, errors.New("command prefix must be in /localhost scope")
+	}
+
+	cfg.Face.IncomingFaceID = true
+	if s.face, e = intface.New(cfg.Face); e != nil {
+		return nil, e
+	}
+
+	fw := l3.NewForwarder()
+	if _, e = fw.AddFace(s.face.A); e != nil {
+		must.Close(s.face.D)
+		return nil, e
+	}
+
+	if s.p, e = endpoint.Produce(context.Background(), endpoint.ProducerOptions{
+		Prefix:      s.prefix,
+		NoAdvertise: true,
+		Handler:     s.handle,
+		Fw:          fw,
+	}); e != nil {
+		must.Close(s.face.D)
+		return nil, e
+	}
+
+	s.route = rib.RouteKey{
+		Name:   s.prefix,
+		Face:   s.face.ID,
+		Origin: rib.OriginApp,
+	}
+	if e = s.rib.Insert(rib.Route{
+		RouteKey: s.route,
+		Flags:    rib.RouteChildInherit,
+	}); e != nil {
+		must.Close(s.p)
+		must.Close(s.face.D)
+		return nil, e
+	}
+
+	logger.Info("NFD management responder started", zap.Stringer("prefix", s.prefix), s.face.ID.ZapField("face"))
+	return s, nil
+}
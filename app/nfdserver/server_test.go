@@ -0,0 +1,97 @@
+package nfdserver_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/app/fwdp/fwdptest"
+	"github.com/usnistgov/ndn-dpdk/app/nfdserver"
+	"github.com/usnistgov/ndn-dpdk/container/rib"
+	"github.com/usnistgov/ndn-dpdk/container/strategycode"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/iface/intface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/endpoint"
+	"github.com/usnistgov/ndn-dpdk/ndn/l3"
+	"github.com/usnistgov/ndn-dpdk/ndn/mgmt/nfdmgmt"
+	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
+	"go4.org/must"
+)
+
+func TestCommands(t *testing.T) {
+	assert, require := makeAR(t)
+	fixture := fwdptest.NewFixture(t)
+
+	sc, e := strategycode.LoadFile("multicast", "")
+	require.NoError(e)
+	r := rib.New(rib.Config{Fib: fixture.Fib, Strategy: sc.ID()})
+	defer r.Close()
+
+	_, e = nfdserver.New(fixture.DataPlane, r, nfdserver.Config{Prefix: ndn.ParseName("/nfd")})
+	assert.Error(e)
+
+	s, e := nfdserver.New(fixture.DataPlane, r, nfdserver.Config{})
+	require.NoError(e)
+	defer must.Close(s)
+
+	app, other := intface.MustNew(), intface.MustNew()
+	defer must.Close(app.D)
+	defer must.Close(other.D)
+	fw := l3.NewForwarder()
+	fwFace, e := fw.AddFace(app.A)
+	require.NoError(e)
+	fwFace.AddRoute(ndn.Name{})
+
+	c, e := nfdmgmt.New()
+	require.NoError(e)
+	c.ConsumerOpts.Fw = fw
+	invoke := func(cmd nfdmgmt.ControlCommand) nfdmgmt.ControlResponse {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		cr, e := c.Invoke(ctx, cmd)
+		require.NoError(e)
+		return cr
+	}
+	findFaces := func(name string) (faces []iface.ID) {
+		for _, route := range r.Find(ndn.ParseName(name)) {
+			faces = append(faces, route.Face)
+		}
+		return
+	}
+
+	// omitted FaceId refers to the face on which the command arrived
+	cr := invoke(nfdmgmt.RibRegisterCommand{Name: ndn.ParseName("/A"), Cost: 5})
+	assert.Equal(200, cr.StatusCode)
+	assert.Equal([]iface.ID{app.ID}, findFaces("/A"))
+	if routes := r.Find(ndn.ParseName("/A")); assert.Len(routes, 1) {
+		assert.Equal(5, routes[0].Cost)
+		assert.Equal(rib.OriginApp, routes[0].Origin)
+	}
+	if cost, ok := r.NexthopCost(ndn.ParseName("/A"), app.ID); assert.True(ok) {
+		assert.Equal(5, cost)
+	}
+
+	// explicit FaceId
+	cr = invoke(nfdmgmt.RibRegisterCommand{Name: ndn.ParseName("/B"), FaceID: int(other.ID)})
+	assert.Equal(200, cr.StatusCode)
+	assert.Equal([]iface.ID{other.ID}, findFaces("/B"))
+
+	cr = invoke(nfdmgmt.RibRegisterCommand{Name: ndn.ParseName("/C"), FaceID: iface.MaxID})
+	assert.Equal(410, cr.StatusCode)
+	assert.Len(r.Find(ndn.ParseName("/C")), 0)
+
+	cr = invoke(nfdmgmt.RibUnregisterCommand{Name: ndn.ParseName("/A")})
+	assert.Equal(200, cr.StatusCode)
+	assert.Len(r.Find(ndn.ParseName("/A")), 0)
+
+	// unsigned command is rejected
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	interest := nfdmgmt.MakeCommandInterest(nfdmgmt.PrefixLocalhost, nfdmgmt.RibRegisterCommand{Name: ndn.ParseName("/D")})
+	data, e := endpoint.Consume(ctx, interest, endpoint.ConsumerOptions{Fw: fw})
+	require.NoError(e)
+	require.NoError(tlv.Decode(data.Content, &cr))
+	assert.Equal(403, cr.StatusCode)
+	assert.Len(r.Find(ndn.ParseName("/D")), 0)
+}
@@ -6,6 +6,15 @@ const (
 	// MaxNames is the maximum number of name filters.
 	MaxNames = 4
 
+	// FilterMaxInsns is the maximum number of instructions in a compiled filter expression.
+	FilterMaxInsns = 32
+
+	// FilterMaxOperands is the maximum total length of name and PIT token operands in a filter expression.
+	FilterMaxOperands = 256
+
+	// ReassMaxPackets is the maximum number of partially received packets in FaceSource reassembly mode.
+	ReassMaxPackets = 8
+
 	// WriterBurstSize is the burst size in the writer.
 	WriterBurstSize = 64
 
@@ -27,6 +36,40 @@ const (
 	_ = "enumgen::Pdump"
 )
 
+// filterOp is a filter program opcode.
+type filterOp uint8
+
+// filterOp values.
+const (
+	filterOpAnd filterOp = iota
+	filterOpOr
+	filterOpNot
+	filterOpL3Type
+	filterOpNackReason
+	filterOpCongMark
+	filterOpHopLimit
+	filterOpNameLen
+	filterOpNamePrefix
+	filterOpPitTokenPrefix
+
+	_ = "enumgen:PdumpFilterOp:PdumpFilterOp:filterOp"
+)
+
+// filterCmp is a comparison operator in filter program.
+type filterCmp uint8
+
+// filterCmp values.
+const (
+	filterCmpEq filterCmp = iota
+	filterCmpNe
+	filterCmpLt
+	filterCmpLe
+	filterCmpGt
+	filterCmpGe
+
+	_ = "enumgen:PdumpFilterCmp:PdumpFilterCmp:filterCmp"
+)
+
 // Limits and defaults.
 const (
 	MinFileSize     = 1 << 16
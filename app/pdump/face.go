@@ -88,6 +88,18 @@ type FaceConfig struct {
 	Face   iface.Face
 	Dir    Direction
 	Names  []NameFilterEntry
+
+	// Filter is an optional filter expression, evaluated in addition to name filters.
+	Filter *Filter
+
+	// Reassemble enables NDNLPv2 reassembly, so that name filters apply to fragmented packets.
+	// Fragments are buffered until all fragments of a packet have arrived, and then either all
+	// or none of them are captured, depending on the name of the reassembled packet.
+	// Captured fragments are written in fragment index order, not arrival order.
+	//
+	// The reassembly buffer is not thread-safe, so that this is rejected on the incoming
+	// direction of a face served by more than one RxLoop.
+	Reassemble bool
 }
 
 func (cfg *FaceConfig) validate() error {
@@ -115,9 +127,28 @@ func (cfg *FaceConfig) validate() error {
 		}
 	}
 
+	if cfg.Reassemble && cfg.Face != nil && cfg.Dir == DirIncoming {
+		if n := countRxLoops(cfg.Face); n > 1 {
+			errs = append(errs, fmt.Errorf("cannot reassemble on a face served by %d RxLoops", n))
+		}
+	}
+
 	return errors.Join(errs...)
 }
 
+// countRxLoops returns the number of RxLoops that receive packets on a face.
+func countRxLoops(face iface.Face) (n int) {
+	hasFace := func(rxg iface.RxGroup) bool {
+		return slices.ContainsFunc(rxg.Faces(), func(f iface.Face) bool { return f.ID() == face.ID() })
+	}
+	for _, rxl := range iface.ListRxLoops() {
+		if slices.ContainsFunc(rxl.List(), hasFace) {
+			n++
+		}
+	}
+	return n
+}
+
 // NameFilterEntry matches a name prefix and specifies its sample rate.
 // An empty name matches all packets.
 type NameFilterEntry struct {
@@ -152,6 +183,7 @@ func (s *FaceSource) closeImpl() error {
 
 	go func() {
 		urcu.Synchronize()
+		C.PdumpFaceSource_Clear(s.c)
 		s.Writer.stopSource()
 		s.logger.Info("FaceSource freed")
 		eal.Free(s.c)
@@ -177,7 +209,7 @@ func NewFaceSource(cfg FaceConfig) (s *FaceSource, e error) {
 	}
 	socket := s.Face.NumaSocket()
 
-	s.logger = logger.With(s.Face.ID().ZapField("face"), zap.String("dir", string(s.Dir)))
+	s.logger = logger.With(s.Face.ID().ZapField("face"), zap.String("dir", string(s.Dir)), zap.Stringer("filter", s.Filter), zap.Bool("reassemble", s.Reassemble))
 	s.c = eal.Zmalloc[C.PdumpFaceSource]("PdumpFaceSource", C.sizeof_PdumpFaceSource, socket)
 	s.c.base = C.PdumpSource{
 		directMp: (*C.struct_rte_mempool)(pktmbuf.Direct.Get(socket).Ptr()),
@@ -187,6 +219,10 @@ func NewFaceSource(cfg FaceConfig) (s *FaceSource, e error) {
 		mbufPort: C.uint16_t(s.Face.ID()),
 		mbufCopy: true,
 	}
+	if !s.Filter.Empty() {
+		s.Filter.copyToC(&s.c.expr)
+	}
+	s.c.reassemble = C.bool(s.Reassemble)
 	pcg32.Init(unsafe.Pointer(&s.c.rng))
 
 	// sort by descending name length for longest prefix match
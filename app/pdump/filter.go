@@ -0,0 +1,387 @@
+package pdump
+
+/*
+#include "../../csrc/pdump/filter.h"
+*/
+import "C"
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unsafe"
+
+	"github.com/usnistgov/ndn-dpdk/dpdk/pktmbuf"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
+	"github.com/usnistgov/ndn-dpdk/ndni"
+)
+
+// Filter is a compiled packet filter expression.
+//
+// Syntax:
+//
+//	expr  = term *(("or" / "||") term)
+//	term  = unary *(("and" / "&&") unary)
+//	unary = ("not" / "!") unary / "(" expr ")" / pred
+//	pred  = "interest" / "data" / "nack"
+//	      / "nackreason" cmp (number / "congestion" / "duplicate" / "noroute" / "unspecified")
+//	      / "congmark" [cmp number]
+//	      / "hoplimit" cmp number
+//	      / "namelen" cmp number
+//	      / "name" name-prefix
+//	      / "pittoken" hex-prefix
+//	cmp   = "==" / "!=" / "<" / "<=" / ">" / ">="
+//
+// "congmark" without comparison means "congmark != 0".
+// "namelen" counts name components; "name" and "namelen" do not match a non-first fragment.
+type Filter struct {
+	root     filterNode
+	insns    []filterInsn
+	operands []byte
+}
+
+type filterInsn struct {
+	op    filterOp
+	cmp   filterCmp
+	len   int
+	value uint32
+}
+
+// Empty returns true if the filter accepts all packets.
+func (f *Filter) Empty() bool {
+	return f == nil || f.root == nil
+}
+
+// String returns the normalized expression.
+func (f *Filter) String() string {
+	if f.Empty() {
+		return ""
+	}
+	return f.root.String()
+}
+
+func (f *Filter) copyToC(c *C.PdumpFilter) {
+	*c = C.PdumpFilter{nInsns: C.uint8_t(len(f.insns))}
+	for i, insn := range f.insns {
+		c.insns[i] = C.PdumpFilterInsn{
+			op:    C.PdumpFilterOp(insn.op),
+			cmp:   C.PdumpFilterCmp(insn.cmp),
+			len:   C.uint16_t(insn.len),
+			value: C.uint32_t(insn.value),
+		}
+	}
+	copy(unsafe.Slice((*byte)(unsafe.Pointer(&c.operands[0])), len(c.operands)), f.operands)
+}
+
+// Match determines whether a packet matches the filter.
+// pkt should be positioned at NDNLPv2 header or L3 header.
+func (f *Filter) Match(pkt *pktmbuf.Packet) bool {
+	var c C.PdumpFilter
+	if !f.Empty() {
+		f.copyToC(&c)
+	}
+
+	var info C.PdumpPacketInfo
+	C.PdumpPacketInfo_Parse(&info, (*C.struct_rte_mbuf)(pkt.Ptr()))
+	return bool(C.PdumpFilter_Eval(&c, &info))
+}
+
+// ParseFilter parses and compiles a filter expression.
+// An empty expression accepts all packets.
+func ParseFilter(expr string) (f *Filter, e error) {
+	p := filterParser{}
+	if p.tokens, e = lexFilter(expr); e != nil {
+		return nil, e
+	}
+
+	f = &Filter{}
+	if len(p.tokens) == 0 {
+		return f, nil
+	}
+	if f.root, e = p.parseExpr(); e != nil {
+		return nil, e
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+
+	f.root.emit(f)
+	switch {
+	case len(f.insns) > FilterMaxInsns:
+		return nil, fmt.Errorf("filter expression must have at most %d instructions", FilterMaxInsns)
+	case len(f.operands) > FilterMaxOperands:
+		return nil, fmt.Errorf("filter expression operands must have at most %d octets", FilterMaxOperands)
+	}
+	return f, nil
+}
+
+type filterNode interface {
+	fmt.Stringer
+	emit(f *Filter)
+}
+
+type filterBinary struct {
+	op   filterOp
+	a, b filterNode
+}
+
+func (n filterBinary) String() string {
+	word := map[filterOp]string{filterOpAnd: "and", filterOpOr: "or"}[n.op]
+	return "(" + n.a.String() + " " + word + " " + n.b.String() + ")"
+}
+
+func (n filterBinary) emit(f *Filter) {
+	n.a.emit(f)
+	n.b.emit(f)
+	f.insns = append(f.insns, filterInsn{op: n.op})
+}
+
+type filterNot struct {
+	a filterNode
+}
+
+func (n filterNot) String() string {
+	return "not " + n.a.String()
+}
+
+func (n filterNot) emit(f *Filter) {
+	n.a.emit(f)
+	f.insns = append(f.insns, filterInsn{op: filterOpNot})
+}
+
+type filterPred struct {
+	insn    filterInsn
+	operand []byte
+	text    string
+}
+
+func (n filterPred) String() string {
+	return n.text
+}
+
+func (n filterPred) emit(f *Filter) {
+	insn := n.insn
+	if n.operand != nil {
+		insn.value, insn.len = uint32(len(f.operands)), len(n.operand)
+		f.operands = append(f.operands, n.operand...)
+	}
+	f.insns = append(f.insns, insn)
+}
+
+var (
+	filterCmps = map[string]filterCmp{
+		"==": filterCmpEq,
+		"!=": filterCmpNe,
+		"<":  filterCmpLt,
+		"<=": filterCmpLe,
+		">":  filterCmpGt,
+		">=": filterCmpGe,
+	}
+	filterL3Types = map[string]ndni.PktType{
+		"interest": ndni.PktInterest,
+		"data":     ndni.PktData,
+		"nack":     ndni.PktNack,
+	}
+	filterNackReasons = map[string]uint32{
+		"congestion":  an.NackCongestion,
+		"duplicate":   an.NackDuplicate,
+		"noroute":     an.NackNoRoute,
+		"unspecified": an.NackUnspecified,
+	}
+	filterNumOps = map[string]filterOp{
+		"nackreason": filterOpNackReason,
+		"congmark":   filterOpCongMark,
+		"hoplimit":   filterOpHopLimit,
+		"namelen":    filterOpNameLen,
+	}
+)
+
+func lexFilter(expr string) (tokens []string, e error) {
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+			continue
+		case c == '(' || c == ')':
+			tokens = append(tokens, expr[i:i+1])
+			i++
+			continue
+		case strings.HasPrefix(expr[i:], "&&"), strings.HasPrefix(expr[i:], "||"),
+			strings.HasPrefix(expr[i:], "=="), strings.HasPrefix(expr[i:], "!="),
+			strings.HasPrefix(expr[i:], "<="), strings.HasPrefix(expr[i:], ">="):
+			tokens = append(tokens, expr[i:i+2])
+			i += 2
+			continue
+		case c == '!' || c == '<' || c == '>':
+			tokens = append(tokens, expr[i:i+1])
+			i++
+			continue
+		}
+
+		j := i
+		if c == '/' { // name extends to whitespace or parenthesis
+			for j < len(expr) && !strings.ContainsRune(" \t\n()", rune(expr[j])) {
+				j++
+			}
+		} else {
+			for j < len(expr) && (expr[j] == '_' || unicode.IsLetter(rune(expr[j])) || unicode.IsDigit(rune(expr[j]))) {
+				j++
+			}
+		}
+		if j == i {
+			return nil, fmt.Errorf("unexpected character %q at offset %d", c, i)
+		}
+		tokens = append(tokens, expr[i:j])
+		i = j
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *filterParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return strings.ToLower(p.tokens[p.pos])
+}
+
+func (p *filterParser) next() (token string, e error) {
+	if p.pos >= len(p.tokens) {
+		return "", errors.New("unexpected end of filter expression")
+	}
+	token = p.tokens[p.pos]
+	p.pos++
+	return token, nil
+}
+
+func (p *filterParser) parseExpr() (n filterNode, e error) {
+	if n, e = p.parseTerm(); e != nil {
+		return nil, e
+	}
+	for t := p.peek(); t == "or" || t == "||"; t = p.peek() {
+		p.pos++
+		b, e := p.parseTerm()
+		if e != nil {
+			return nil, e
+		}
+		n = filterBinary{op: filterOpOr, a: n, b: b}
+	}
+	return n, nil
+}
+
+func (p *filterParser) parseTerm() (n filterNode, e error) {
+	if n, e = p.parseUnary(); e != nil {
+		return nil, e
+	}
+	for t := p.peek(); t == "and" || t == "&&"; t = p.peek() {
+		p.pos++
+		b, e := p.parseUnary()
+		if e != nil {
+			return nil, e
+		}
+		n = filterBinary{op: filterOpAnd, a: n, b: b}
+	}
+	return n, nil
+}
+
+func (p *filterParser) parseUnary() (n filterNode, e error) {
+	switch p.peek() {
+	case "not", "!":
+		p.pos++
+		a, e := p.parseUnary()
+		if e != nil {
+			return nil, e
+		}
+		return filterNot{a: a}, nil
+	case "(":
+		p.pos++
+		if n, e = p.parseExpr(); e != nil {
+			return nil, e
+		}
+		if t, _ := p.next(); t != ")" {
+			return nil, errors.New("missing )")
+		}
+		return n, nil
+	}
+	return p.parsePred()
+}
+
+func (p *filterParser) parsePred() (n filterNode, e error) {
+	keyword, e := p.next()
+	if e != nil {
+		return nil, e
+	}
+	keyword = strings.ToLower(keyword)
+
+	if l3, ok := filterL3Types[keyword]; ok {
+		return filterPred{
+			insn: filterInsn{op: filterOpL3Type, cmp: filterCmpEq, value: uint32(l3)},
+			text: keyword,
+		}, nil
+	}
+
+	if op, ok := filterNumOps[keyword]; ok {
+		cmp, hasCmp := filterCmps[p.peek()]
+		if !hasCmp {
+			if op != filterOpCongMark {
+				return nil, fmt.Errorf("%s needs a comparison", keyword)
+			}
+			return filterPred{insn: filterInsn{op: op, cmp: filterCmpNe}, text: keyword}, nil
+		}
+		cmpToken, _ := p.next()
+
+		operand, e := p.next()
+		if e != nil {
+			return nil, e
+		}
+		value, ok := filterNackReasons[strings.ToLower(operand)]
+		if !ok || op != filterOpNackReason {
+			v, e := strconv.ParseUint(operand, 0, 16)
+			if e != nil {
+				return nil, fmt.Errorf("%s operand %q is not a number", keyword, operand)
+			}
+			value = uint32(v)
+		}
+		return filterPred{
+			insn: filterInsn{op: op, cmp: cmp, value: value},
+			text: keyword + " " + cmpToken + " " + operand,
+		}, nil
+	}
+
+	operand, e := p.next()
+	switch keyword {
+	case "name":
+		if e != nil || !strings.HasPrefix(operand, "/") {
+			return nil, errors.New("name needs a name prefix")
+		}
+		name := ndn.ParseName(operand)
+		value, _ := name.MarshalBinary()
+		return filterPred{
+			insn:    filterInsn{op: filterOpNamePrefix},
+			operand: value,
+			text:    "name " + name.String(),
+		}, nil
+	case "pittoken":
+		if e != nil {
+			return nil, errors.New("pittoken needs a hexadecimal prefix")
+		}
+		value, e := hex.DecodeString(strings.TrimPrefix(strings.ToLower(operand), "0x"))
+		if e != nil || len(value) > 32 {
+			return nil, fmt.Errorf("pittoken operand %q is not a hexadecimal prefix up to 32 octets", operand)
+		}
+		return filterPred{
+			insn:    filterInsn{op: filterOpPitTokenPrefix},
+			operand: value,
+			text:    "pittoken " + hex.EncodeToString(value),
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown filter keyword %q", keyword)
+}
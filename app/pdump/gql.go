@@ -148,6 +148,22 @@ func init() {
 					return s.Names, nil
 				},
 			},
+			"filter": &graphql.Field{
+				Description: "Filter expression.",
+				Type:        gqlserver.NonNullString,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					s := p.Source.(*FaceSource)
+					return s.Filter.String(), nil
+				},
+			},
+			"reassemble": &graphql.Field{
+				Description: "Whether NDNLPv2 reassembly is enabled.",
+				Type:        gqlserver.NonNullBoolean,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					s := p.Source.(*FaceSource)
+					return s.Reassemble, nil
+				},
+			},
 		},
 	}, gqlserver.NodeConfig[*FaceSource]{
 		GetID: func(s *FaceSource) string {
@@ -188,6 +204,14 @@ func init() {
 				Description: "Name filter.",
 				Type:        gqlserver.NewListNonNullBoth(GqlNameFilterEntryInput),
 			},
+			"filter": &graphql.ArgumentConfig{
+				Description: "Filter expression, such as 'nack and nackreason == congestion'.",
+				Type:        graphql.String,
+			},
+			"reassemble": &graphql.ArgumentConfig{
+				Description: "Enable NDNLPv2 reassembly, so that name filters apply to fragmented packets.",
+				Type:        graphql.Boolean,
+			},
 		},
 		Type: graphql.NewNonNull(GqlFaceSourceType.Object),
 		Resolve: func(p graphql.ResolveParams) (any, error) {
@@ -196,6 +220,14 @@ func init() {
 				Face:   iface.GqlFaceType.Retrieve(p.Args["face"].(string)),
 				Dir:    p.Args["dir"].(Direction),
 			}
+			if expr, ok := p.Args["filter"].(string); ok {
+				filter, e := ParseFilter(expr)
+				if e != nil {
+					return nil, e
+				}
+				cfg.Filter = filter
+			}
+			cfg.Reassemble, _ = p.Args["reassemble"].(bool)
 			jsonhelper.Roundtrip(p.Args["names"], &cfg.Names)
 			return NewFaceSource(cfg)
 		},
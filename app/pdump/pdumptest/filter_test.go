@@ -0,0 +1,114 @@
+package pdumptest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/usnistgov/ndn-dpdk/app/pdump"
+	"github.com/usnistgov/ndn-dpdk/dpdk/pktmbuf/mbuftestenv"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
+	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
+)
+
+func TestParseFilter(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	for input, normalized := range map[string]string{
+		"":                                       "",
+		"Interest":                               "interest",
+		"data or nack and congmark":              "(data or (nack and congmark))",
+		"(data || nack) && !hoplimit >= 4":       "((data or nack) and not hoplimit >= 4)",
+		"nack and nackreason == congestion":      "(nack and nackreason == congestion)",
+		"name /A/B and namelen<4":                "(name /8=A/8=B and namelen < 4)",
+		"pittoken 0xA0B1 or pittoken c2":         "(pittoken a0b1 or pittoken c2)",
+		"not (interest and not name /I) or data": "(not (interest and not name /8=I) or data)",
+	} {
+		f, e := pdump.ParseFilter(input)
+		if assert.NoError(e, input) {
+			assert.Equal(normalized, f.String(), input)
+			assert.Equal(input == "", f.Empty(), input)
+		}
+	}
+
+	for _, input := range []string{
+		"interest and",
+		"(interest",
+		"interest)",
+		"unknown",
+		"hoplimit",
+		"hoplimit == x",
+		"nackreason == 65536",
+		"name",
+		"name A",
+		"pittoken",
+		"pittoken 0xZZ",
+		"pittoken " + strings.Repeat("00", 33),
+		"interest $ data",
+		strings.Repeat("interest or ", 40) + "data",
+	} {
+		_, e := pdump.ParseFilter(input)
+		assert.Error(e, input)
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	assert, require := makeAR(t)
+
+	interest := ndn.MakeInterest("/I/1/2", ndn.HopLimit(8), ndn.LpL3{PitToken: []byte{0xA0, 0xB1, 0xC2}})
+	data := ndn.MakeData("/D/1", ndn.LpL3{CongMark: 1})
+	nack := ndn.MakeNack(an.NackCongestion, ndn.MakeInterest("/N/1"))
+
+	fragmenter := ndn.NewLpFragmenter(1000)
+	frags, _ := fragmenter.Fragment(ndn.MakeData("/F"+strings.Repeat("/Z", 800), make([]byte, 1100)).ToPacket())
+	require.Len(frags, 4)
+
+	match := func(expr string, npkt tlv.Fielder) bool {
+		f, e := pdump.ParseFilter(expr)
+		require.NoError(e, expr)
+		wire, _ := tlv.EncodeFrom(npkt)
+		pkt := mbuftestenv.MakePacket(wire)
+		defer pkt.Close()
+		return f.Match(pkt)
+	}
+
+	assert.True(match("", interest))
+	assert.True(match("interest", interest))
+	assert.False(match("interest", data))
+	assert.False(match("interest", nack))
+	assert.True(match("nack", nack))
+	assert.True(match("data", frags[0]))
+	assert.False(match("data", frags[1]))
+	assert.True(match("not interest and not data and not nack", frags[2]))
+
+	assert.True(match("hoplimit == 8", interest))
+	assert.True(match("hoplimit > 7 && hoplimit <= 8", interest))
+	assert.False(match("hoplimit != 8", interest))
+	assert.False(match("hoplimit >= 0", data))
+
+	assert.True(match("nackreason == congestion", nack))
+	assert.True(match("nackreason == 50", nack))
+	assert.False(match("nackreason == noroute", nack))
+	assert.False(match("nackreason == congestion", interest))
+
+	assert.True(match("congmark", data))
+	assert.True(match("congmark == 1", data))
+	assert.False(match("congmark", interest))
+
+	assert.True(match("pittoken a0", interest))
+	assert.True(match("pittoken a0b1c2", interest))
+	assert.False(match("pittoken a0b1c2d3", interest))
+	assert.False(match("pittoken b1", interest))
+
+	assert.True(match("name /I", interest))
+	assert.True(match("name /I/1/2", interest))
+	assert.False(match("name /I/1/2/3", interest))
+	assert.True(match("name /N and namelen == 2", nack))
+	assert.True(match("namelen == 3", interest))
+	assert.False(match("namelen < 3", interest))
+	assert.True(match("name /F/Z/Z", frags[0]))
+	assert.False(match("name /F", frags[1]))
+
+	assert.True(match("(interest and name /I) or (data and congmark)", data))
+	assert.False(match("not (interest or data)", data))
+}
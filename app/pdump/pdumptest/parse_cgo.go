@@ -18,3 +18,12 @@ func extractName(npkt tlv.Fielder) []byte {
 	lnameC := C.Pdump_ExtractName((*C.struct_rte_mbuf)(m.Ptr()))
 	return C.GoBytes(unsafe.Pointer(lnameC.value), C.int(lnameC.length))
 }
+
+func parseFragment(npkt tlv.Fielder) (ok bool, seqNumBase uint64, fragIndex, fragCount int, payloadLen int) {
+	wire, _ := tlv.EncodeFrom(npkt)
+	m := mbuftestenv.MakePacket(wire)
+	defer m.Close()
+	var frag C.PdumpFragment
+	ok = bool(C.Pdump_ParseFragment((*C.struct_rte_mbuf)(m.Ptr()), &frag))
+	return ok, uint64(frag.seqNumBase), int(frag.fragIndex), int(frag.fragCount), int(frag.payloadLen)
+}
@@ -38,3 +38,29 @@ func TestExtractNameFragment(t *testing.T) {
 	assert.Len(extractName(frags[2]), 0)
 	assert.Len(extractName(frags[3]), 0)
 }
+
+func TestParseFragment(t *testing.T) {
+	assert, require := makeAR(t)
+
+	ok, _, _, _, _ := parseFragment(ndn.MakeInterest("/I/1"))
+	assert.False(ok)
+
+	fragmenter := ndn.NewLpFragmenter(1000)
+	data := ndn.MakeData("/D"+strings.Repeat("/Z", 800), make([]byte, 1100))
+	frags, _ := fragmenter.Fragment(data.ToPacket())
+	require.Len(frags, 4)
+
+	var seqNumBase0 uint64
+	for i, frag := range frags {
+		ok, seqNumBase, fragIndex, fragCount, payloadLen := parseFragment(frag)
+		assert.True(ok)
+		if i == 0 {
+			seqNumBase0 = seqNumBase
+		} else {
+			assert.Equal(seqNumBase0, seqNumBase)
+		}
+		assert.Equal(i, fragIndex)
+		assert.Equal(4, fragCount)
+		assert.Len(frag.Fragment.Payload, payloadLen)
+	}
+}
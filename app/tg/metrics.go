@@ -0,0 +1,45 @@
+package tg
+
+import (
+	"github.com/usnistgov/ndn-dpdk/core/metrics"
+)
+
+func init() {
+	metrics.AddCollector(func(w *metrics.Writer) {
+		mapFaceGenMutex.RLock()
+		defer mapFaceGenMutex.RUnlock()
+		for id, gen := range mapFaceGen {
+			faceLabel := metrics.L("face", id)
+
+			if gen.producer != nil {
+				cnt := gen.producer.Counters()
+				w.Struct("tgproducer", cnt, faceLabel)
+				for i, pcnt := range cnt.PerPattern {
+					patternLabel := metrics.L("pattern", i)
+					w.Counter("tgproducer_pattern_interests", "Interests matching the pattern.", pcnt.NInterests, faceLabel, patternLabel)
+					for j, n := range pcnt.PerReply {
+						w.Counter("tgproducer_pattern_replies", "Replies sent with the reply definition.", n, faceLabel, patternLabel, metrics.L("reply", j))
+					}
+				}
+			}
+
+			if gen.fileServer != nil {
+				w.Struct("fileserver", gen.fileServer.Counters(), faceLabel)
+			}
+
+			if gen.consumer != nil {
+				cnt := gen.consumer.Counters()
+				w.Struct("tgconsumer", cnt, faceLabel)
+				for i, pcnt := range cnt.PerPattern {
+					w.Struct("tgconsumer_pattern", pcnt, faceLabel, metrics.L("pattern", i))
+				}
+			}
+
+			if gen.fetcher != nil {
+				for i, task := range gen.fetcher.Tasks() {
+					w.Struct("fetch", task.Counters(), faceLabel, metrics.L("task", i))
+				}
+			}
+		}
+	})
+}
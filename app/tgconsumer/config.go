@@ -28,8 +28,20 @@ const (
 	_ = "enumgen::Tgc"
 )
 
+// MaxWindow is maximum number of outstanding Interests per pattern in closed-loop mode.
+const MaxWindow = 65536
+
 const defaultInterval = 1 * time.Millisecond
 
+// ArrivalProcess identifies Interest arrival process in open-loop mode.
+type ArrivalProcess string
+
+// ArrivalProcess values.
+const (
+	ArrivalConstant ArrivalProcess = "constant"
+	ArrivalPoisson  ArrivalProcess = "poisson"
+)
+
 // Config describes consumer configuration.
 type Config struct {
 	RxQueue iface.PktQueueConfig `json:"rxQueue,omitempty"`
@@ -40,10 +52,29 @@ type Config struct {
 	// Default is 1ms.
 	Interval nnduration.Nanoseconds `json:"interval"`
 
+	// Arrival selects Interest arrival process in open-loop mode.
+	//  - "constant" (default): Interests are sent in bursts at a constant interval.
+	//  - "poisson": inter-arrival times of individual Interests are exponentially distributed,
+	//    with Interval as the mean.
+	Arrival ArrivalProcess `json:"arrival,omitempty"`
+
+	// Window enables closed-loop mode, if positive.
+	// In closed-loop mode, each pattern keeps at most Window outstanding Interests, and sends a
+	// new Interest whenever Data or Nack arrives, or an outstanding Interest times out after its
+	// InterestLifetime.
+	// Interval, Arrival, and pattern weights are ignored in closed-loop mode.
+	// It must not exceed MaxWindow.
+	Window int `json:"window,omitempty"`
+
 	// Patterns defines traffic patterns.
-	// It must contain between 1 and MaxPatterns entries.
+	// It must contain between 1 and MaxPatterns entries, unless Trace is specified.
 	Patterns []Pattern `json:"patterns"`
 
+	// Trace enables trace-driven replay.
+	// If specified, Interval and Patterns must be omitted.
+	// Patterns are derived from trace prefixes, so that counters are reported per trace prefix.
+	Trace *TraceConfig `json:"trace,omitempty"`
+
 	nWeights, nDigestPatterns int
 }
 
@@ -51,6 +82,30 @@ type Config struct {
 func (cfg *Config) Validate() error {
 	cfg.RxQueue.DisableCoDel = true
 
+	switch cfg.Arrival {
+	case "":
+		cfg.Arrival = ArrivalConstant
+	case ArrivalConstant, ArrivalPoisson:
+	default:
+		return fmt.Errorf("unknown arrival process %s", cfg.Arrival)
+	}
+	if cfg.Window < 0 || cfg.Window > MaxWindow {
+		return fmt.Errorf("Window must be between 0 and %d", MaxWindow)
+	}
+
+	if cfg.Trace != nil {
+		if len(cfg.Patterns) > 0 || cfg.Interval != 0 || cfg.Arrival != ArrivalConstant || cfg.Window != 0 {
+			return errors.New("Interval, Arrival, Window, and Patterns cannot be specified together with Trace")
+		}
+		if cfg.Trace.Filename == "" {
+			return errors.New("trace filename is empty")
+		}
+		trace := *cfg.Trace
+		trace.applyDefaults()
+		cfg.Trace = &trace
+		return nil
+	}
+
 	if len(cfg.Patterns) == 0 {
 		return errors.New("no pattern specified")
 	}
@@ -62,10 +117,17 @@ func (cfg *Config) Validate() error {
 	nWeights, nDigestPatterns := 0, 0
 	for i, pattern := range cfg.Patterns {
 		pattern.applyDefaults()
-		patterns = append(patterns, pattern)
 		nWeights += pattern.Weight
 		if pattern.Digest != nil {
 			nDigestPatterns++
+			if pattern.Digest.PayloadLenDist != nil {
+				return errors.New("pattern Digest cannot have PayloadLenDist")
+			}
+			digest := *pattern.Digest
+			if e := digest.Validate(); e != nil {
+				return e
+			}
+			pattern.Digest = &digest
 		}
 		if pattern.SeqNumOffset != 0 {
 			if pattern.Digest != nil {
@@ -74,7 +136,21 @@ func (cfg *Config) Validate() error {
 			if i == 0 {
 				return errors.New("first pattern cannot have SeqNumOffset")
 			}
+			if cfg.Patterns[i-1].Popularity != nil {
+				return errors.New("pattern with SeqNumOffset cannot follow a pattern with Popularity")
+			}
+		}
+		if pattern.Popularity != nil {
+			if pattern.Digest != nil || pattern.SeqNumOffset != 0 {
+				return errors.New("pattern cannot have Popularity together with Digest or SeqNumOffset")
+			}
+			popularity := *pattern.Popularity
+			if e := popularity.validate(); e != nil {
+				return e
+			}
+			pattern.Popularity = &popularity
 		}
+		patterns = append(patterns, pattern)
 	}
 	if nWeights > MaxSumWeight {
 		return fmt.Errorf("sum of weight cannot exceed %d", MaxSumWeight)
@@ -92,12 +168,17 @@ type Pattern struct {
 
 	// If specified, append implicit digest to Interest name.
 	// For Data to satisfy Interests, the producer pattern must reply with the same DataGenConfig.
+	// PayloadLenDist is disallowed, because the Data must be reproducible.
 	Digest *ndni.DataGenConfig `json:"digest,omitempty"`
 
 	// If non-zero, request cached Data. This must appear after a pattern without SeqNumOffset.
 	// The consumer derives sequence number by subtracting SeqNumOffset from the previous pattern's
 	// sequence number. Sufficient CS capacity is necessary for Data to actually come from CS.
 	SeqNumOffset int `json:"seqNumOffset,omitempty"`
+
+	// If specified, draw sequence number from a catalog of names according to a popularity distribution.
+	// This cannot be used together with Digest or SeqNumOffset.
+	Popularity *PopularityConfig `json:"popularity,omitempty"`
 }
 
 func (pattern *Pattern) applyDefaults() {
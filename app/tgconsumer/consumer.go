@@ -6,6 +6,7 @@ package tgconsumer
 #include "../../csrc/tgconsumer/tx.h"
 
 static_assert(offsetof(TgcTxPattern, digest) == offsetof(TgcTxPattern, seqNumOffset), "");
+static_assert(offsetof(TgcTxPattern, digest) == offsetof(TgcTxPattern, popularity), "");
 enum { c_offsetof_TgcTxPattern_DigestSeqNumOffset = offsetof(TgcTxPattern, digest) };
 */
 import "C"
@@ -58,6 +59,9 @@ type Consumer struct {
 
 	digestCrypto *cryptodev.CryptoDev
 	dPatterns    []*C.TgcTxDigestPattern
+	popularities []*C.TgcTxPopularity
+	windows      []*C.TgcWindow
+	trace        *C.TgcTrace
 }
 
 var _ tgdef.Consumer = &Consumer{}
@@ -81,10 +85,14 @@ func (c *Consumer) initPatterns() (e error) {
 	}
 
 	c.rxC.nPatterns = C.uint8_t(len(c.cfg.Patterns))
+	c.txC.nPatterns = c.rxC.nPatterns
 	c.txC.nWeights = C.uint32_t(c.cfg.nWeights)
 	w := 0
 	for i, pattern := range c.cfg.Patterns {
 		c.assignPattern(i, pattern, dataGenVec.Take)
+		if c.cfg.Window > 0 {
+			c.assignWindow(i, pattern)
+		}
 
 		for range pattern.Weight {
 			c.txC.weight[w] = C.uint8_t(i)
@@ -115,6 +123,8 @@ func (c *Consumer) assignPattern(i int, pattern Pattern, takeDataGenMbuf func()
 	switch {
 	case pattern.Digest != nil:
 		c.assignDigestPattern(pattern, txP, takeDataGenMbuf)
+	case pattern.Popularity != nil:
+		c.assignPopularityPattern(pattern, rxP, txP)
 	case pattern.SeqNumOffset != 0:
 		txP.makeSuffix = C.TgcTxPattern_MakeSuffix(C.TgcTxPattern_MakeSuffix_Offset)
 		*(*C.uint64_t)(unsafe.Add(unsafe.Pointer(txP), C.c_offsetof_TgcTxPattern_DigestSeqNumOffset)) = C.uint64_t(pattern.SeqNumOffset)
@@ -148,6 +158,38 @@ func (c *Consumer) assignDigestPattern(pattern Pattern, txP *C.TgcTxPattern, tak
 	c.dPatterns = append(c.dPatterns, dp)
 }
 
+func (c *Consumer) assignPopularityPattern(pattern Pattern, rxP *C.TgcRxPattern, txP *C.TgcTxPattern) {
+	txP.makeSuffix = C.TgcTxPattern_MakeSuffix(C.TgcTxPattern_MakeSuffix_Popularity)
+	rxP.hitRtt = C.TscDuration(eal.ToTscDuration(pattern.Popularity.HitRtt.Duration()))
+
+	var cdf []uint32
+	if pattern.Popularity.Distribution != PopularityUniform {
+		cdf = pattern.Popularity.cdf()
+	}
+	pop := eal.Zmalloc[C.TgcTxPopularity]("TgcTxPopularity", C.sizeof_TgcTxPopularity+len(cdf)*C.sizeof_uint32_t, c.socket)
+	pop.seqNumBase = txP.seqNumV
+	pop.catalogSize = C.uint32_t(pattern.Popularity.CatalogSize)
+	pop.uniform = C.bool(len(cdf) == 0)
+	copy(unsafe.Slice((*uint32)(unsafe.Pointer(&pop.cdf)), len(cdf)), cdf)
+
+	*(**C.TgcTxPopularity)(unsafe.Add(unsafe.Pointer(txP), C.c_offsetof_TgcTxPattern_DigestSeqNumOffset)) = pop
+	c.popularities = append(c.popularities, pop)
+}
+
+func (c *Consumer) assignWindow(i int, pattern Pattern) {
+	win := eal.Zmalloc[C.TgcWindow]("TgcWindow", C.sizeof_TgcWindow+c.cfg.Window*C.sizeof_TscTime, c.socket)
+	win.capacity = C.uint32_t(c.cfg.Window)
+	win.timeout = C.TscDuration(eal.ToTscDuration(pattern.InterestLifetime.DurationOr(
+		nnduration.Milliseconds(ndn.DefaultInterestLifetime / time.Millisecond))))
+	c.rxC.pattern[i].window = win
+	c.txC.pattern[i].window = win
+	c.windows = append(c.windows, win)
+}
+
+func (c *Consumer) windowSlots(win *C.TgcWindow) []C.TscTime {
+	return unsafe.Slice((*C.TscTime)(unsafe.Pointer(&win.slots)), int(win.capacity))
+}
+
 func (c *Consumer) prepareDigest(nDigestPatterns int) (e error) {
 	c.closeDigest()
 	if nDigestPatterns == 0 {
@@ -165,6 +207,49 @@ func (c *Consumer) prepareDigest(nDigestPatterns int) (e error) {
 	return nil
 }
 
+func (c *Consumer) initTrace(plan tracePlan) {
+	n := len(plan.records)
+	c.trace = eal.Zmalloc[C.TgcTrace]("TgcTrace", C.sizeof_TgcTrace+n*C.sizeof_TgcTraceRecord, c.socket)
+	c.trace.period = C.TscDuration(eal.ToTscDuration(plan.period))
+	c.trace.nRecords = C.uint32_t(n)
+	c.trace.loop = C.bool(c.cfg.Trace.Loop)
+
+	c.trace.tpl = eal.Zmalloc[C.InterestTemplate]("TgcTraceTpl", len(plan.templates)*C.sizeof_InterestTemplate, c.socket)
+	tpls := unsafe.Slice(c.trace.tpl, len(plan.templates))
+	for i, tplCfg := range plan.templates {
+		tplCfg.Apply(ndni.InterestTemplateFromPtr(unsafe.Pointer(&tpls[i])))
+	}
+
+	suffixes := eal.Zmalloc[C.uint8_t]("TgcTraceSuffixes", max(1, len(plan.suffixes)), c.socket)
+	copy(unsafe.Slice((*byte)(suffixes), len(plan.suffixes)), plan.suffixes)
+	c.trace.suffixes = suffixes
+
+	records := unsafe.Slice((*C.TgcTraceRecord)(unsafe.Pointer(&c.trace.records)), n)
+	for i, rec := range plan.records {
+		records[i] = C.TgcTraceRecord{
+			offset:       C.TscDuration(eal.ToTscDuration(rec.offset)),
+			suffixOffset: C.uint32_t(rec.suffixOffset),
+			suffixL:      C.uint16_t(rec.suffixL),
+			tplID:        C.uint16_t(rec.tplID),
+			patternID:    C.uint8_t(rec.patternID),
+		}
+	}
+
+	c.txC.trace = c.trace
+	c.rxC.trace = true
+}
+
+func (c *Consumer) closeTrace() {
+	if c.trace == nil {
+		return
+	}
+	c.txC.trace = nil
+	eal.Free(c.trace.tpl)
+	eal.Free(c.trace.suffixes)
+	eal.Free(c.trace)
+	c.trace = nil
+}
+
 // Interval returns average Interest interval.
 func (c Consumer) Interval() time.Duration {
 	return eal.FromTscDuration(int64(c.txC.burstInterval)) / iface.MaxBurstSize
@@ -197,6 +282,15 @@ func (c Consumer) Workers() []ealthread.ThreadWithRole {
 func (c *Consumer) Launch() {
 	c.rxC.runNum++
 	c.txC.runNum = c.rxC.runNum
+	if c.trace != nil {
+		c.trace.pos = 0
+	}
+	for _, win := range c.windows {
+		// outstanding Interests from previous run would be ignored due to different runNum
+		clear(c.windowSlots(win))
+		win.nSent = win.nCompleted + win.nTimeouts
+		win.nextSweep = 0
+	}
 	ealthread.Launch(c.rx)
 	ealthread.Launch(c.tx)
 }
@@ -218,6 +312,15 @@ func (c *Consumer) StopDelay(delay time.Duration) error {
 func (c *Consumer) Close() error {
 	c.Stop()
 	c.closeDigest()
+	c.closeTrace()
+	for _, pop := range c.popularities {
+		eal.Free(pop)
+	}
+	c.popularities = nil
+	for _, win := range c.windows {
+		eal.Free(win)
+	}
+	c.windows = nil
 	must.Close(c.rxQueue())
 	eal.Free(c.rxC)
 	eal.Free(c.txC)
@@ -243,6 +346,18 @@ func New(face iface.Face, cfg Config) (c *Consumer, e error) {
 		return nil, e
 	}
 
+	var plan tracePlan
+	if cfg.Trace != nil {
+		records, e := ReadTrace(*cfg.Trace)
+		if e != nil {
+			return nil, e
+		}
+		if plan, e = planTrace(*cfg.Trace, records); e != nil {
+			return nil, e
+		}
+		cfg.Patterns, cfg.nWeights = plan.patterns, len(plan.patterns)
+	}
+
 	socket := face.NumaSocket()
 	c = &Consumer{
 		cfg:    cfg,
@@ -276,10 +391,15 @@ func New(face iface.Face, cfg Config) (c *Consumer, e error) {
 
 	c.txC.burstInterval = C.TscDuration(eal.ToTscDuration(
 		cfg.Interval.DurationOr(nnduration.Nanoseconds(defaultInterval)) * iface.MaxBurstSize))
+	c.txC.poisson = C.bool(cfg.Arrival == ArrivalPoisson)
+	c.txC.closedLoop = C.bool(cfg.Window > 0)
 	if e := c.initPatterns(); e != nil {
 		must.Close(c)
 		return nil, fmt.Errorf("error setting patterns %w", e)
 	}
+	if cfg.Trace != nil {
+		c.initTrace(plan)
+	}
 
 	c.ClearCounters()
 	return c, nil
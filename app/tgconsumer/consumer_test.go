@@ -2,6 +2,9 @@ package tgconsumer_test
 
 import (
 	"encoding/binary"
+	"math"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -133,3 +136,223 @@ func TestConsumer(t *testing.T) {
 	assert.InDelta(nInterestsB2, cnt.PerPattern[2].NData, 100)
 	assert.InDelta(nInterestsC, cnt.PerPattern[3].NData, 100)
 }
+
+func TestConsumerPopularity(t *testing.T) {
+	assert, require := makeAR(t)
+
+	face := intface.MustNew()
+	defer face.D.Close()
+
+	nameZ, nameU := ndn.ParseName("/Z"), ndn.ParseName("/U")
+	cfg := tgconsumer.Config{
+		Interval: nnduration.Nanoseconds(200 * time.Microsecond),
+		Patterns: []tgconsumer.Pattern{
+			{
+				InterestTemplateConfig: ndni.InterestTemplateConfig{
+					Prefix: nameZ,
+				},
+				Popularity: &tgconsumer.PopularityConfig{
+					CatalogSize: 100,
+					Alpha:       1.2,
+					HitRtt:      nnduration.Nanoseconds(10 * time.Millisecond),
+				},
+			},
+			{
+				InterestTemplateConfig: ndni.InterestTemplateConfig{
+					Prefix: nameU,
+				},
+				Popularity: &tgconsumer.PopularityConfig{
+					CatalogSize:  10,
+					Distribution: tgconsumer.PopularityUniform,
+				},
+			},
+		},
+	}
+
+	c, e := tgconsumer.New(face.D, cfg)
+	require.NoError(e)
+	defer c.Close()
+	tgtestenv.Open(t, c)
+	assert.Equal(tgconsumer.PopularityZipf, c.Patterns()[0].Popularity.Distribution)
+
+	var seqNumsMutex sync.Mutex
+	seqNumsZ, seqNumsU := map[uint64]int{}, map[uint64]int{}
+	nDelayed := 0
+	go func() {
+		for packet := range face.Rx {
+			require.NotNil(packet.Interest)
+			interest := *packet.Interest
+			seqNum := binary.LittleEndian.Uint64(interest.Name.Get(-1).Value)
+			data := ndn.MakeData(interest)
+			delayed := false
+			seqNumsMutex.Lock()
+			switch {
+			case nameZ.IsPrefixOf(interest.Name):
+				seqNumsZ[seqNum]++
+				if seqNumsZ[seqNum] == 1 { // first retrieval is a cache miss
+					nDelayed++
+					delayed = true
+				}
+			case nameU.IsPrefixOf(interest.Name):
+				seqNumsU[seqNum]++
+			}
+			seqNumsMutex.Unlock()
+
+			if delayed {
+				time.AfterFunc(20*time.Millisecond, func() { face.Tx <- data })
+			} else {
+				face.Tx <- data
+			}
+		}
+	}()
+
+	c.Launch()
+	time.Sleep(900 * time.Millisecond)
+	e = c.StopDelay(200 * time.Millisecond)
+	assert.NoError(e)
+
+	seqNumsMutex.Lock()
+	defer seqNumsMutex.Unlock()
+	assert.LessOrEqual(len(seqNumsZ), 100)
+	assert.Len(seqNumsU, 10)
+
+	nInterestsZ, maxCount := 0, 0
+	var minSeqNum uint64 = math.MaxUint64
+	for seqNum, count := range seqNumsZ {
+		nInterestsZ += count
+		minSeqNum = min(minSeqNum, seqNum)
+		maxCount = max(maxCount, count)
+	}
+	assert.Equal(seqNumsZ[minSeqNum], maxCount)
+	p0 := cfg.Patterns[0].Popularity.Probability(0)
+	assert.InDelta(p0, float64(maxCount)/float64(nInterestsZ), 0.05)
+
+	cnt := c.Counters()
+	require.Len(cnt.PerPattern, 2)
+	cntZ := cnt.PerPattern[0]
+	require.NotNil(cntZ.Cache)
+	assert.InDelta(nDelayed, cntZ.Cache.NMisses, 5)
+	assert.InDelta(cntZ.NData-uint64(nDelayed), cntZ.Cache.NHits, 5)
+	assert.Nil(cnt.PerPattern[1].Cache)
+}
+
+func TestConsumerClosedLoop(t *testing.T) {
+	assert, require := makeAR(t)
+
+	face := intface.MustNew()
+	defer face.D.Close()
+
+	nameA, nameB := ndn.ParseName("/A"), ndn.ParseName("/B")
+	cfg := tgconsumer.Config{
+		Window: 4,
+		Patterns: []tgconsumer.Pattern{
+			{
+				InterestTemplateConfig: ndni.InterestTemplateConfig{
+					Prefix: nameA,
+				},
+			},
+			{
+				InterestTemplateConfig: ndni.InterestTemplateConfig{
+					Prefix:           nameB,
+					InterestLifetime: 100,
+				},
+			},
+		},
+	}
+
+	c, e := tgconsumer.New(face.D, cfg)
+	require.NoError(e)
+	defer c.Close()
+	tgtestenv.Open(t, c)
+
+	var nPendingA, maxPendingA atomic.Int32
+	var nInterestsA, nInterestsB atomic.Int32
+	go func() {
+		for packet := range face.Rx {
+			require.NotNil(packet.Interest)
+			interest := *packet.Interest
+			switch {
+			case nameA.IsPrefixOf(interest.Name):
+				nInterestsA.Add(1)
+				pending := nPendingA.Add(1)
+				if pending > maxPendingA.Load() {
+					maxPendingA.Store(pending)
+				}
+				data := ndn.MakeData(interest)
+				time.AfterFunc(10*time.Millisecond, func() {
+					nPendingA.Add(-1)
+					face.Tx <- data
+				})
+			case nameB.IsPrefixOf(interest.Name):
+				nInterestsB.Add(1) // no reply, Interest would time out
+			}
+		}
+	}()
+
+	c.Launch()
+	time.Sleep(500 * time.Millisecond)
+	e = c.StopDelay(50 * time.Millisecond)
+	assert.NoError(e)
+
+	assert.LessOrEqual(maxPendingA.Load(), int32(4))
+	assert.InDelta(200, nInterestsA.Load(), 80)
+	assert.InDelta(20, nInterestsB.Load(), 8)
+
+	cnt := c.Counters()
+	require.Len(cnt.PerPattern, 2)
+	cntA, cntB := cnt.PerPattern[0], cnt.PerPattern[1]
+	require.NotNil(cntA.Window)
+	require.NotNil(cntB.Window)
+	assert.EqualValues(nInterestsA.Load(), cntA.NInterests)
+	assert.InDelta(cntA.NInterests, cntA.NData, 4)
+	assert.Zero(cntA.Window.NTimeouts)
+	assert.EqualValues(nInterestsB.Load(), cntB.NInterests)
+	assert.EqualValues(4, cntB.Window.NOutstanding)
+	assert.EqualValues(cntB.NInterests-4, cntB.Window.NTimeouts)
+}
+
+func TestConsumerPoisson(t *testing.T) {
+	assert, require := makeAR(t)
+
+	face := intface.MustNew()
+	defer face.D.Close()
+
+	cfg := tgconsumer.Config{
+		Interval: nnduration.Nanoseconds(200 * time.Microsecond),
+		Arrival:  tgconsumer.ArrivalPoisson,
+		Patterns: []tgconsumer.Pattern{
+			{
+				InterestTemplateConfig: ndni.InterestTemplateConfig{
+					Prefix: ndn.ParseName("/P"),
+				},
+			},
+		},
+	}
+
+	c, e := tgconsumer.New(face.D, cfg)
+	require.NoError(e)
+	defer c.Close()
+	tgtestenv.Open(t, c)
+
+	go func() {
+		for packet := range face.Rx {
+			face.Tx <- ndn.MakeData(*packet.Interest)
+		}
+	}()
+
+	c.Launch()
+	time.Sleep(900 * time.Millisecond)
+	e = c.StopDelay(100 * time.Millisecond)
+	assert.NoError(e)
+
+	cnt := c.Counters()
+	assert.InDelta(4500, cnt.NInterests, 1000)
+	assert.InDelta(cnt.NInterests, cnt.NData, 100)
+	assert.Nil(cnt.PerPattern[0].Window)
+
+	_, e = tgconsumer.New(face.D, tgconsumer.Config{
+		Arrival:  "bursty",
+		Patterns: cfg.Patterns,
+	})
+	assert.Error(e)
+}
@@ -46,14 +46,59 @@ func (cnt PacketCounters) String() string {
 		cnt.NNacks, cnt.NackRatio()*100.0)
 }
 
+// CacheCounters contains cache hit/miss counters, where Data is classified by RTT.
+type CacheCounters struct {
+	NHits   uint64 `json:"nHits" gqldesc:"Data with RTT below hitRtt."`
+	NMisses uint64 `json:"nMisses" gqldesc:"Data with RTT at or above hitRtt."`
+}
+
+// HitRatio returns NHits/(NHits+NMisses).
+func (cnt CacheCounters) HitRatio() float64 {
+	return float64(cnt.NHits) / float64(cnt.NHits+cnt.NMisses)
+}
+
+func (cnt CacheCounters) String() string {
+	return fmt.Sprintf("%dhit %dmiss(%0.2f%%)", cnt.NHits, cnt.NMisses, cnt.HitRatio()*100.0)
+}
+
+// WindowCounters contains closed-loop window counters.
+type WindowCounters struct {
+	NOutstanding uint64 `json:"nOutstanding" gqldesc:"Currently outstanding Interests."`
+	NTimeouts    uint64 `json:"nTimeouts" gqldesc:"Interests timed out without Data or Nack."`
+}
+
+func (cnt WindowCounters) String() string {
+	return fmt.Sprintf("%doutstanding %dtimeout", cnt.NOutstanding, cnt.NTimeouts)
+}
+
 // PatternCounters contains per-pattern counters.
 type PatternCounters struct {
 	PacketCounters
-	Rtt runningstat.Snapshot `json:"rtt" gqldesc:"RTT in nanoseconds."`
+	Rtt    runningstat.Snapshot `json:"rtt" gqldesc:"RTT in nanoseconds."`
+	Cache  *CacheCounters       `json:"cache,omitempty" gqldesc:"Cache hit/miss counters; null if hitRtt is not configured."`
+	Window *WindowCounters      `json:"window,omitempty" gqldesc:"Window counters; null if not in closed-loop mode."`
 }
 
 func (cnt PatternCounters) String() string {
-	return fmt.Sprintf("%s rtt=%s", cnt.PacketCounters, formatRttCounters(cnt.Rtt))
+	s := fmt.Sprintf("%s rtt=%s", cnt.PacketCounters, formatRttCounters(cnt.Rtt))
+	if cnt.Cache != nil {
+		s += fmt.Sprintf(" cache=%s", *cnt.Cache)
+	}
+	if cnt.Window != nil {
+		s += fmt.Sprintf(" window=%s", *cnt.Window)
+	}
+	return s
+}
+
+// TraceCounters contains trace-driven replay counters.
+type TraceCounters struct {
+	NRecords uint32 `json:"nRecords" gqldesc:"Number of Interests in the trace."`
+	Pos      uint32 `json:"pos" gqldesc:"Index of next Interest in the trace."`
+	NLoops   uint64 `json:"nLoops" gqldesc:"Number of completed loops."`
+}
+
+func (cnt TraceCounters) String() string {
+	return fmt.Sprintf("%d/%d %dloops", cnt.Pos, cnt.NRecords, cnt.NLoops)
 }
 
 // Counters contains consumer counters.
@@ -62,10 +107,14 @@ type Counters struct {
 	NAllocError uint64               `json:"nAllocError"`
 	Rtt         runningstat.Snapshot `json:"rtt" gqldesc:"RTT in nanoseconds."`
 	PerPattern  []PatternCounters    `json:"perPattern"`
+	Trace       *TraceCounters       `json:"trace,omitempty" gqldesc:"Trace-driven replay counters; null if not in trace-driven replay mode."`
 }
 
 func (cnt Counters) String() string {
 	s := fmt.Sprintf("%s %dalloc-error rtt=%s", cnt.PacketCounters, cnt.NAllocError, formatRttCounters(cnt.Rtt))
+	if cnt.Trace != nil {
+		s += fmt.Sprintf(" trace=%s", *cnt.Trace)
+	}
 	for i, pcnt := range cnt.PerPattern {
 		s += fmt.Sprintf(", pattern(%d) %s", i, pcnt)
 	}
@@ -84,6 +133,20 @@ func (c *Consumer) Counters() (cnt Counters) {
 		pcnt.NData = rtt.Count
 		pcnt.NNacks = uint64(crP.nNacks)
 		pcnt.Rtt = rtt
+		if crP.hitRtt != 0 {
+			nHits := uint64(crP.nHits)
+			pcnt.Cache = &CacheCounters{
+				NHits:   nHits,
+				NMisses: pcnt.NData - nHits,
+			}
+		}
+		if win := crP.window; win != nil {
+			nTimeouts := uint64(win.nTimeouts)
+			pcnt.Window = &WindowCounters{
+				NOutstanding: uint64(win.nSent) - uint64(win.nCompleted) - nTimeouts,
+				NTimeouts:    nTimeouts,
+			}
+		}
 		cnt.PerPattern = append(cnt.PerPattern, pcnt)
 
 		cnt.NInterests += pcnt.NInterests
@@ -93,6 +156,13 @@ func (c *Consumer) Counters() (cnt Counters) {
 	}
 
 	cnt.NAllocError = uint64(c.txC.nAllocError)
+	if c.trace != nil {
+		cnt.Trace = &TraceCounters{
+			NRecords: uint32(c.trace.nRecords),
+			Pos:      uint32(c.trace.pos),
+			NLoops:   uint64(c.trace.nLoops),
+		}
+	}
 	return cnt
 }
 
@@ -107,10 +177,18 @@ func (c *Consumer) ClearCounters() {
 		c.clearCounter(i)
 	}
 	c.txC.nAllocError = 0
+	if c.trace != nil {
+		c.trace.nLoops = 0
+	}
 }
 
 func (c *Consumer) clearCounter(index int) {
 	c.rxC.pattern[index].nNacks = 0
+	c.rxC.pattern[index].nHits = 0
 	c.rttStat(index).Init(0)
 	c.txC.pattern[index].nInterests = 0
+	if win := c.rxC.pattern[index].window; win != nil {
+		clear(c.windowSlots(win))
+		win.nCompleted, win.nSent, win.nTimeouts = 0, 0, 0
+	}
 }
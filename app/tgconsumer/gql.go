@@ -19,14 +19,31 @@ var GqlRetrieveByFaceID func(id iface.ID) *Consumer
 
 // GraphQL types.
 var (
-	GqlPatternInput        *graphql.InputObject
-	GqlConfigInput         *graphql.InputObject
-	GqlPatternCountersType *graphql.Object
-	GqlCountersType        *graphql.Object
-	GqlConsumerType        *gqlserver.NodeType[*Consumer]
+	GqlArrivalProcessEnum         *graphql.Enum
+	GqlPopularityDistributionEnum *graphql.Enum
+	GqlPopularityInput            *graphql.InputObject
+	GqlPatternInput               *graphql.InputObject
+	GqlTraceConfigInput           *graphql.InputObject
+	GqlConfigInput                *graphql.InputObject
+	GqlCacheCountersType          *graphql.Object
+	GqlWindowCountersType         *graphql.Object
+	GqlPatternCountersType        *graphql.Object
+	GqlTraceCountersType          *graphql.Object
+	GqlCountersType               *graphql.Object
+	GqlConsumerType               *gqlserver.NodeType[*Consumer]
 )
 
 func init() {
+	GqlArrivalProcessEnum = gqlserver.NewStringEnum("TgcArrivalProcess", "Traffic generator consumer arrival process.", ArrivalConstant, ArrivalPoisson)
+	GqlPopularityDistributionEnum = gqlserver.NewStringEnum("TgcPopularityDistribution", "Traffic generator consumer popularity distribution.", PopularityZipf, PopularityUniform)
+	GqlPopularityInput = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "TgcPopularityInput",
+		Description: "Traffic generator consumer popularity distribution definition.",
+		Fields: gqlserver.BindInputFields[PopularityConfig](gqlserver.FieldTypes{
+			reflect.TypeFor[PopularityDistribution](): GqlPopularityDistributionEnum,
+			reflect.TypeFor[nnduration.Nanoseconds](): nnduration.GqlNanoseconds,
+		}),
+	})
 	GqlPatternInput = graphql.NewInputObject(graphql.InputObjectConfig{
 		Name:        "TgcPatternInput",
 		Description: "Traffic generator consumer pattern definition.",
@@ -34,8 +51,14 @@ func init() {
 			reflect.TypeFor[ndn.Name]():                gqlserver.NonNullString,
 			reflect.TypeFor[nnduration.Milliseconds](): nnduration.GqlMilliseconds,
 			reflect.TypeFor[ndni.DataGenConfig]():      ndni.GqlDataGenInput,
+			reflect.TypeFor[PopularityConfig]():        GqlPopularityInput,
 		}),
 	})
+	GqlTraceConfigInput = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "TgcTraceConfigInput",
+		Description: "Traffic generator consumer trace-driven replay config.",
+		Fields:      gqlserver.BindInputFields[TraceConfig](nil),
+	})
 	GqlConfigInput = graphql.NewInputObject(graphql.InputObjectConfig{
 		Name:        "TgcConfigInput",
 		Description: "Traffic generator consumer config.",
@@ -43,20 +66,37 @@ func init() {
 			reflect.TypeFor[iface.PktQueueConfig]():   iface.GqlPktQueueInput,
 			reflect.TypeFor[nnduration.Nanoseconds](): nnduration.GqlNanoseconds,
 			reflect.TypeFor[Pattern]():                GqlPatternInput,
+			reflect.TypeFor[TraceConfig]():            GqlTraceConfigInput,
+			reflect.TypeFor[ArrivalProcess]():         GqlArrivalProcessEnum,
 		}),
 	})
 
+	GqlCacheCountersType = graphql.NewObject(graphql.ObjectConfig{
+		Name:   "TgcCacheCounters",
+		Fields: gqlserver.BindFields[CacheCounters](nil),
+	})
+	GqlWindowCountersType = graphql.NewObject(graphql.ObjectConfig{
+		Name:   "TgcWindowCounters",
+		Fields: gqlserver.BindFields[WindowCounters](nil),
+	})
 	GqlPatternCountersType = graphql.NewObject(graphql.ObjectConfig{
 		Name: "TgcPatternCounters",
 		Fields: gqlserver.BindFields[PatternCounters](gqlserver.FieldTypes{
 			reflect.TypeFor[runningstat.Snapshot](): runningstat.GqlSnapshotType,
+			reflect.TypeFor[CacheCounters]():        GqlCacheCountersType,
+			reflect.TypeFor[WindowCounters]():       GqlWindowCountersType,
 		}),
 	})
+	GqlTraceCountersType = graphql.NewObject(graphql.ObjectConfig{
+		Name:   "TgcTraceCounters",
+		Fields: gqlserver.BindFields[TraceCounters](nil),
+	})
 	GqlCountersType = graphql.NewObject(graphql.ObjectConfig{
 		Name: "TgcCounters",
 		Fields: gqlserver.BindFields[Counters](gqlserver.FieldTypes{
 			reflect.TypeFor[runningstat.Snapshot](): runningstat.GqlSnapshotType,
 			reflect.TypeFor[PatternCounters]():      GqlPatternCountersType,
+			reflect.TypeFor[TraceCounters]():        GqlTraceCountersType,
 		}),
 	})
 
@@ -0,0 +1,103 @@
+package tgconsumer
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
+)
+
+// MaxCatalogSize is maximum number of names in a popularity catalog.
+const MaxCatalogSize = 1 << 22
+
+// PopularityDistribution identifies a popularity distribution.
+type PopularityDistribution string
+
+// PopularityDistribution values.
+const (
+	PopularityZipf    PopularityDistribution = "zipf"
+	PopularityUniform PopularityDistribution = "uniform"
+)
+
+// PopularityConfig configures sequence number selection from a catalog of names.
+//
+// The catalog contains CatalogSize names, each is the pattern prefix followed by a sequence number.
+// Every time the pattern is selected, the consumer draws a name from the catalog according to the
+// popularity distribution, so that popular names are requested repeatedly.
+type PopularityConfig struct {
+	// CatalogSize is the number of names in the catalog.
+	// It must be between 1 and MaxCatalogSize.
+	CatalogSize int `json:"catalogSize"`
+
+	// Distribution selects popularity distribution.
+	// Default is Zipf.
+	Distribution PopularityDistribution `json:"distribution,omitempty"`
+
+	// Alpha is the exponent of Zipf distribution.
+	// The name with rank k (1-based) is selected with probability proportional to 1/k^Alpha.
+	// Default is 1.0.
+	Alpha float64 `json:"alpha,omitempty"`
+
+	// HitRtt is the RTT threshold for classifying Data as cache hits.
+	// If non-zero, Data with RTT below this threshold is counted as a cache hit, and other Data is
+	// counted as a cache miss.
+	// This requires cache misses to have distinctly longer RTT, such as placing the producer
+	// behind a link with additional delay.
+	// Default is zero, which disables hit/miss counters; PatternCounters.Cache would be nil.
+	HitRtt nnduration.Nanoseconds `json:"hitRtt,omitempty" gqldesc:"RTT threshold for classifying Data as cache hits. Default is zero, which disables hit/miss counters."`
+}
+
+func (cfg *PopularityConfig) validate() error {
+	if cfg.CatalogSize < 1 || cfg.CatalogSize > MaxCatalogSize {
+		return fmt.Errorf("CatalogSize must be between 1 and %d", MaxCatalogSize)
+	}
+	switch cfg.Distribution {
+	case "":
+		cfg.Distribution = PopularityZipf
+	case PopularityZipf, PopularityUniform:
+	default:
+		return fmt.Errorf("unknown popularity distribution %s", cfg.Distribution)
+	}
+	if cfg.Alpha == 0 {
+		cfg.Alpha = 1.0
+	}
+	if cfg.Alpha < 0 || math.IsNaN(cfg.Alpha) || math.IsInf(cfg.Alpha, 0) {
+		return errors.New("Alpha must be positive")
+	}
+	return nil
+}
+
+// cdf computes cumulative distribution function of Zipf distribution, scaled to math.MaxUint32.
+func (cfg PopularityConfig) cdf() (cdf []uint32) {
+	weights := make([]float64, cfg.CatalogSize)
+	sum := 0.0
+	for i := range weights {
+		weights[i] = math.Pow(float64(i+1), -cfg.Alpha)
+		sum += weights[i]
+	}
+
+	cdf = make([]uint32, cfg.CatalogSize)
+	cum := 0.0
+	for i, w := range weights {
+		cum += w
+		cdf[i] = uint32(min(math.MaxUint32, math.Round(cum/sum*math.MaxUint32)))
+	}
+	cdf[len(cdf)-1] = math.MaxUint32
+	return cdf
+}
+
+// Probability returns the probability of selecting the name with rank k (0-based).
+func (cfg PopularityConfig) Probability(k int) float64 {
+	if k < 0 || k >= cfg.CatalogSize {
+		return 0
+	}
+	if cfg.Distribution == PopularityUniform {
+		return 1.0 / float64(cfg.CatalogSize)
+	}
+	sum := 0.0
+	for i := range cfg.CatalogSize {
+		sum += math.Pow(float64(i+1), -cfg.Alpha)
+	}
+	return math.Pow(float64(k+1), -cfg.Alpha) / sum
+}
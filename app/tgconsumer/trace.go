@@ -0,0 +1,400 @@
+package tgconsumer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gopacket/gopacket"
+	"github.com/gopacket/gopacket/layers"
+	"github.com/gopacket/gopacket/pcapgo"
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
+	"github.com/usnistgov/ndn-dpdk/ndni"
+)
+
+const (
+	// MaxTraceTemplates is maximum number of distinct (prefix, CanBePrefix, MustBeFresh, InterestLifetime, HopLimit)
+	// combinations in a trace.
+	MaxTraceTemplates = 1024
+
+	// MaxTraceRecords is maximum number of Interests in a trace.
+	MaxTraceRecords = 1 << 24
+
+	// MaxTraceSuffixes is maximum total length of name suffixes in a trace.
+	MaxTraceSuffixes = math.MaxUint32
+)
+
+// TraceConfig configures trace-driven replay.
+//
+// In trace-driven replay mode, the consumer transmits Interests from a trace file with their
+// original inter-arrival times, instead of selecting patterns randomly at a constant interval.
+type TraceConfig struct {
+	// Filename is the trace file name.
+	// Format is determined from file extension:
+	//  - .pcapng: packet dump written by app/pdump; only SLL interfaces (faces) are considered.
+	//  - .csv: CSV with columns timestamp,name,flags,lifetime; see ReadTrace.
+	//  - .json or .jsonl: JSON array or a sequence of JSON objects; see ReadTrace.
+	Filename string `json:"filename"`
+
+	// Outgoing selects outgoing Interests from a pcapng trace.
+	// Default is incoming Interests.
+	Outgoing bool `json:"outgoing,omitempty"`
+
+	// PrefixLen is the number of name components that identifies a trace prefix.
+	// Each distinct trace prefix becomes a traffic pattern, and has its own counters.
+	// Default is 1.
+	PrefixLen int `json:"prefixLen,omitempty"`
+
+	// Speed is the speed-up factor.
+	// Inter-arrival times in the trace are divided by this factor.
+	// Default is 1.0.
+	Speed float64 `json:"speed,omitempty"`
+
+	// Loop indicates whether to restart from the beginning after reaching the end of trace.
+	Loop bool `json:"loop,omitempty"`
+}
+
+func (cfg *TraceConfig) applyDefaults() {
+	cfg.PrefixLen = max(1, cfg.PrefixLen)
+	if cfg.Speed <= 0 {
+		cfg.Speed = 1.0
+	}
+}
+
+// TraceRecord represents an Interest in a trace.
+type TraceRecord struct {
+	// Time is transmission time relative to the first Interest.
+	Time time.Duration
+
+	// InterestTemplateConfig contains the Interest name in Prefix field, and other Interest fields.
+	ndni.InterestTemplateConfig
+}
+
+// ReadTrace reads Interests from a trace file.
+// Returned records are sorted by transmission time.
+//
+// CSV format has one Interest per line, with columns:
+//   - timestamp: seconds since an arbitrary epoch, such as "1697040000.000125".
+//   - name: Interest name in URI format.
+//   - flags: optional, "P" for CanBePrefix, "F" for MustBeFresh, "-" or empty for neither.
+//   - lifetime: optional, InterestLifetime in milliseconds.
+//
+// A header line that starts with "timestamp" is skipped.
+//
+// JSON format has one object per Interest, with keys "timestamp" (same as CSV), "name",
+// "canBePrefix", "mustBeFresh", "lifetime" (milliseconds), and "hopLimit".
+func ReadTrace(cfg TraceConfig) (records []TraceRecord, e error) {
+	f, e := os.Open(cfg.Filename)
+	if e != nil {
+		return nil, e
+	}
+	defer f.Close()
+
+	switch ext := strings.ToLower(filepath.Ext(cfg.Filename)); ext {
+	case ".pcapng":
+		records, e = readTracePcapng(f, cfg.Outgoing)
+	case ".csv":
+		records, e = readTraceCsv(f)
+	case ".json", ".jsonl":
+		records, e = readTraceJSON(f)
+	default:
+		return nil, fmt.Errorf("unknown trace file extension %s", ext)
+	}
+	if e != nil {
+		return nil, fmt.Errorf("%s: %w", cfg.Filename, e)
+	}
+
+	switch {
+	case len(records) == 0:
+		return nil, fmt.Errorf("%s: no Interest in trace", cfg.Filename)
+	case len(records) > MaxTraceRecords:
+		return nil, fmt.Errorf("%s: trace cannot have more than %d Interests", cfg.Filename, MaxTraceRecords)
+	}
+
+	slices.SortStableFunc(records, func(a, b TraceRecord) int { return int(a.Time - b.Time) })
+	t0 := records[0].Time
+	for i := range records {
+		records[i].Time -= t0
+	}
+	return records, nil
+}
+
+// parseTraceTimestamp parses a timestamp in decimal seconds, without floating point precision loss.
+func parseTraceTimestamp(s string) (time.Duration, error) {
+	sec, frac, _ := strings.Cut(strings.TrimSpace(s), ".")
+	if len(frac) > 9 {
+		frac = frac[:9]
+	}
+	frac += strings.Repeat("0", 9-len(frac))
+
+	secV, e := strconv.ParseUint(sec, 10, 32)
+	if e != nil {
+		return 0, fmt.Errorf("bad timestamp %q", s)
+	}
+	fracV, e := strconv.ParseUint(frac, 10, 32)
+	if e != nil {
+		return 0, fmt.Errorf("bad timestamp %q", s)
+	}
+	return time.Duration(secV)*time.Second + time.Duration(fracV), nil
+}
+
+func traceRecordFromInterest(t time.Duration, interest ndn.Interest) (rec TraceRecord) {
+	rec.Time = t
+	rec.Prefix = interest.Name
+	rec.CanBePrefix = interest.CanBePrefix
+	rec.MustBeFresh = interest.MustBeFresh
+	if interest.Lifetime != ndn.DefaultInterestLifetime {
+		rec.InterestLifetime = nnduration.Milliseconds(interest.Lifetime.Milliseconds())
+	}
+	rec.HopLimit = interest.HopLimit
+	return
+}
+
+func readTracePcapng(r io.Reader, outgoing bool) (records []TraceRecord, e error) {
+	reader, e := pcapgo.NewNgReader(r, pcapgo.DefaultNgReaderOptions)
+	if e != nil {
+		return nil, e
+	}
+
+	wantType := layers.LinuxSLLPacketTypeHost
+	if outgoing {
+		wantType = layers.LinuxSLLPacketTypeOutgoing
+	}
+
+	var sll layers.LinuxSLL
+	parser := gopacket.NewDecodingLayerParser(layers.LayerTypeLinuxSLL, &sll)
+	parser.IgnoreUnsupported = true
+	decoded := []gopacket.LayerType{}
+	for {
+		wire, ci, e := reader.ReadPacketData()
+		if errors.Is(e, io.EOF) {
+			break
+		} else if e != nil {
+			return nil, e
+		}
+
+		if intf, e := reader.Interface(ci.InterfaceIndex); e != nil || intf.LinkType != layers.LinkTypeLinuxSLL {
+			continue
+		}
+		if parser.DecodeLayers(wire, &decoded) != nil || len(decoded) == 0 || sll.PacketType != wantType {
+			continue
+		}
+
+		var npkt ndn.Packet
+		if tlv.Decode(sll.Payload, &npkt) != nil || npkt.Interest == nil {
+			continue
+		}
+		records = append(records, traceRecordFromInterest(time.Duration(ci.Timestamp.UnixNano()), *npkt.Interest))
+	}
+	return records, nil
+}
+
+func readTraceCsv(r io.Reader) (records []TraceRecord, e error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	for {
+		row, e := reader.Read()
+		if errors.Is(e, io.EOF) {
+			break
+		} else if e != nil {
+			return nil, e
+		}
+		line, _ := reader.FieldPos(0)
+
+		if len(records) == 0 && strings.HasPrefix(strings.ToLower(row[0]), "timestamp") {
+			continue
+		}
+		if len(row) < 2 {
+			return nil, fmt.Errorf("line %d: expect at least timestamp,name", line)
+		}
+
+		var rec TraceRecord
+		if rec.Time, e = parseTraceTimestamp(row[0]); e != nil {
+			return nil, fmt.Errorf("line %d: %w", line, e)
+		}
+		if e = rec.Prefix.UnmarshalText([]byte(row[1])); e != nil || len(rec.Prefix) == 0 {
+			return nil, fmt.Errorf("line %d: bad name %q", line, row[1])
+		}
+		if len(row) > 2 {
+			for _, flag := range strings.ToUpper(row[2]) {
+				switch flag {
+				case 'P':
+					rec.CanBePrefix = true
+				case 'F':
+					rec.MustBeFresh = true
+				case '-':
+				default:
+					return nil, fmt.Errorf("line %d: bad flags %q", line, row[2])
+				}
+			}
+		}
+		if len(row) > 3 && row[3] != "" {
+			lifetime, e := strconv.ParseUint(row[3], 10, 32)
+			if e != nil {
+				return nil, fmt.Errorf("line %d: bad lifetime %q", line, row[3])
+			}
+			rec.InterestLifetime = nnduration.Milliseconds(lifetime)
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+type traceJSONRecord struct {
+	Timestamp   json.Number             `json:"timestamp"`
+	Name        ndn.Name                `json:"name"`
+	CanBePrefix bool                    `json:"canBePrefix"`
+	MustBeFresh bool                    `json:"mustBeFresh"`
+	Lifetime    nnduration.Milliseconds `json:"lifetime"`
+	HopLimit    ndn.HopLimit            `json:"hopLimit"`
+}
+
+func readTraceJSON(r io.Reader) (records []TraceRecord, e error) {
+	br := bufio.NewReader(r)
+	var jRecords []traceJSONRecord
+	decoder := json.NewDecoder(br)
+	decoder.UseNumber()
+	if first, _ := peekNonSpace(br); first == '[' {
+		if e := decoder.Decode(&jRecords); e != nil {
+			return nil, e
+		}
+	} else {
+		for {
+			var jRecord traceJSONRecord
+			if e := decoder.Decode(&jRecord); errors.Is(e, io.EOF) {
+				break
+			} else if e != nil {
+				return nil, e
+			}
+			jRecords = append(jRecords, jRecord)
+		}
+	}
+
+	for i, jRecord := range jRecords {
+		var rec TraceRecord
+		if rec.Time, e = parseTraceTimestamp(jRecord.Timestamp.String()); e != nil {
+			return nil, fmt.Errorf("record %d: %w", i, e)
+		}
+		if len(jRecord.Name) == 0 {
+			return nil, fmt.Errorf("record %d: empty name", i)
+		}
+		rec.Prefix = jRecord.Name
+		rec.CanBePrefix = jRecord.CanBePrefix
+		rec.MustBeFresh = jRecord.MustBeFresh
+		rec.InterestLifetime = jRecord.Lifetime
+		rec.HopLimit = jRecord.HopLimit
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+func peekNonSpace(br *bufio.Reader) (byte, error) {
+	for {
+		b, e := br.Peek(1)
+		if e != nil {
+			return 0, e
+		}
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			br.Discard(1)
+		default:
+			return b[0], nil
+		}
+	}
+}
+
+// traceTemplateKey identifies an InterestTemplate used in trace-driven replay.
+type traceTemplateKey struct {
+	pattern     int
+	canBePrefix bool
+	mustBeFresh bool
+	lifetime    nnduration.Milliseconds
+	hopLimit    ndn.HopLimit
+}
+
+// tracePlan is the result of classifying trace records into patterns and templates.
+type tracePlan struct {
+	patterns  []Pattern
+	templates []ndni.InterestTemplateConfig
+	records   []tracePlanRecord
+	suffixes  []byte
+	period    time.Duration
+}
+
+type tracePlanRecord struct {
+	offset       time.Duration
+	suffixOffset int
+	suffixL      int
+	tplID        int
+	patternID    int
+}
+
+func planTrace(cfg TraceConfig, records []TraceRecord) (plan tracePlan, e error) {
+	patternIDs := map[string]int{}
+	tplIDs := map[traceTemplateKey]int{}
+	for _, rec := range records {
+		prefixLen := min(cfg.PrefixLen, len(rec.Prefix))
+		prefix := rec.Prefix.GetPrefix(prefixLen)
+
+		prefixKey := prefix.String()
+		patternID, ok := patternIDs[prefixKey]
+		if !ok {
+			if patternID = len(plan.patterns); patternID >= MaxPatterns {
+				return plan, fmt.Errorf("trace cannot have more than %d distinct prefixes", MaxPatterns)
+			}
+			patternIDs[prefixKey] = patternID
+			var pattern Pattern
+			pattern.applyDefaults()
+			pattern.Prefix = prefix
+			plan.patterns = append(plan.patterns, pattern)
+		}
+
+		key := traceTemplateKey{patternID, rec.CanBePrefix, rec.MustBeFresh, rec.InterestLifetime, rec.HopLimit}
+		tplID, ok := tplIDs[key]
+		if !ok {
+			if tplID = len(plan.templates); tplID >= MaxTraceTemplates {
+				return plan, fmt.Errorf("trace cannot have more than %d distinct Interest templates", MaxTraceTemplates)
+			}
+			tplIDs[key] = tplID
+			tpl := rec.InterestTemplateConfig
+			tpl.Prefix = prefix
+			plan.templates = append(plan.templates, tpl)
+		}
+
+		suffix, _ := tlv.EncodeValueOnly(rec.Prefix.Slice(prefixLen).Field())
+		if len(plan.suffixes)+len(suffix) > MaxTraceSuffixes {
+			return plan, fmt.Errorf("trace name suffixes cannot exceed %d octets", MaxTraceSuffixes)
+		}
+		plan.records = append(plan.records, tracePlanRecord{
+			offset:       time.Duration(float64(rec.Time) / cfg.Speed),
+			suffixOffset: len(plan.suffixes),
+			suffixL:      len(suffix),
+			tplID:        tplID,
+			patternID:    patternID,
+		})
+		plan.suffixes = append(plan.suffixes, suffix...)
+	}
+
+	// loop period is trace duration plus average inter-arrival time
+	span := plan.records[len(plan.records)-1].offset
+	if n := len(plan.records); n > 1 && span > 0 {
+		plan.period = span + span/time.Duration(n-1)
+	} else {
+		plan.period = span + defaultInterval
+	}
+	return plan, nil
+}
@@ -0,0 +1,135 @@
+package tgconsumer_test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/app/tg/tgtestenv"
+	"github.com/usnistgov/ndn-dpdk/app/tgconsumer"
+	"github.com/usnistgov/ndn-dpdk/iface/intface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+)
+
+func TestReadTrace(t *testing.T) {
+	assert, require := makeAR(t)
+	dir := t.TempDir()
+
+	csvFile := filepath.Join(dir, "trace.csv")
+	require.NoError(os.WriteFile(csvFile, []byte(`timestamp,name,flags,lifetime
+1697040000.5,/A/2,F,
+1697040000.000125,/A/1,PF,500
+1697040001,/B/1
+`), 0o666))
+	records, e := tgconsumer.ReadTrace(tgconsumer.TraceConfig{Filename: csvFile})
+	require.NoError(e)
+	require.Len(records, 3)
+	assert.Equal(time.Duration(0), records[0].Time)
+	nameEqual(assert, "/A/1", records[0].Prefix)
+	assert.True(records[0].CanBePrefix)
+	assert.True(records[0].MustBeFresh)
+	assert.EqualValues(500, records[0].InterestLifetime)
+	assert.Equal(499875*time.Microsecond, records[1].Time)
+	assert.False(records[1].CanBePrefix)
+	assert.True(records[1].MustBeFresh)
+	assert.EqualValues(0, records[1].InterestLifetime)
+	assert.Equal(999875*time.Microsecond, records[2].Time)
+	nameEqual(assert, "/B/1", records[2].Prefix)
+
+	jsonFile := filepath.Join(dir, "trace.jsonl")
+	require.NoError(os.WriteFile(jsonFile, []byte(`
+{"timestamp":20.25,"name":"/A/1","canBePrefix":true,"hopLimit":8}
+{"timestamp":20.000001,"name":"/A/0","lifetime":1000}
+`), 0o666))
+	records, e = tgconsumer.ReadTrace(tgconsumer.TraceConfig{Filename: jsonFile})
+	require.NoError(e)
+	require.Len(records, 2)
+	nameEqual(assert, "/A/0", records[0].Prefix)
+	assert.EqualValues(1000, records[0].InterestLifetime)
+	assert.Equal(249999*time.Microsecond, records[1].Time)
+	assert.True(records[1].CanBePrefix)
+	assert.EqualValues(8, records[1].HopLimit)
+
+	badFile := filepath.Join(dir, "bad.csv")
+	require.NoError(os.WriteFile(badFile, []byte("1.0,/A,X\n"), 0o666))
+	_, e = tgconsumer.ReadTrace(tgconsumer.TraceConfig{Filename: badFile})
+	assert.Error(e)
+}
+
+func TestTraceReplay(t *testing.T) {
+	assert, require := makeAR(t)
+
+	face := intface.MustNew()
+	defer face.D.Close()
+
+	traceFile := filepath.Join(t.TempDir(), "trace.csv")
+	require.NoError(os.WriteFile(traceFile, []byte(`0.000,/A/x/1,P,
+0.100,/B/y,,
+0.200,/A/x/2,,
+0.300,/B/z,,
+`), 0o666))
+
+	cfg := tgconsumer.Config{
+		Trace: &tgconsumer.TraceConfig{
+			Filename: traceFile,
+			Speed:    2.0,
+			Loop:     true,
+		},
+	}
+	c, e := tgconsumer.New(face.D, cfg)
+	require.NoError(e)
+	defer c.Close()
+	tgtestenv.Open(t, c)
+
+	patterns := c.Patterns()
+	require.Len(patterns, 2)
+	nameEqual(assert, "/A", patterns[0].Prefix)
+	nameEqual(assert, "/B", patterns[1].Prefix)
+
+	var receivedMutex sync.Mutex
+	received := []ndn.Name{}
+	go func() {
+		for packet := range face.Rx {
+			require.NotNil(packet.Interest)
+			interest := *packet.Interest
+			receivedMutex.Lock()
+			received = append(received, interest.Name)
+			receivedMutex.Unlock()
+			if string(interest.Name[0].Value) == "A" {
+				assert.Equal(string(interest.Name[2].Value) == "1", interest.CanBePrefix)
+				face.Tx <- ndn.MakeData(interest)
+			}
+		}
+	}()
+
+	// trace duration is 300ms and loop period is 400ms; with speed-up factor 2, each loop takes 200ms
+	c.Launch()
+	time.Sleep(450 * time.Millisecond)
+	e = c.StopDelay(100 * time.Millisecond)
+	assert.NoError(e)
+
+	receivedMutex.Lock()
+	defer receivedMutex.Unlock()
+	require.GreaterOrEqual(len(received), 8)
+	for i, expected := range []string{"/A/x/1", "/B/y", "/A/x/2", "/B/z", "/A/x/1", "/B/y", "/A/x/2", "/B/z"} {
+		nameEqual(assert, expected, received[i])
+	}
+
+	cnt := c.Counters()
+	require.NotNil(cnt.Trace)
+	assert.EqualValues(4, cnt.Trace.NRecords)
+	assert.GreaterOrEqual(cnt.Trace.NLoops, uint64(2))
+	require.Len(cnt.PerPattern, 2)
+	assert.InDelta(len(received)/2, cnt.PerPattern[0].NInterests, 1)
+	assert.InDelta(cnt.PerPattern[0].NInterests, cnt.PerPattern[0].NData, 1)
+	assert.InDelta(len(received)/2, cnt.PerPattern[1].NInterests, 1)
+	assert.EqualValues(0, cnt.PerPattern[1].NData)
+
+	_, e = tgconsumer.New(face.D, tgconsumer.Config{
+		Patterns: patterns,
+		Trace:    &tgconsumer.TraceConfig{Filename: traceFile},
+	})
+	assert.Error(e)
+}
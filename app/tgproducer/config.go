@@ -22,6 +22,9 @@ const (
 	// MaxSumWeight is maximum sum of weights among replies.
 	MaxSumWeight = 256
 
+	// MaxSigInfoLen is maximum encoded length of SignatureInfo in signed Data.
+	MaxSigInfoLen = 256
+
 	_ = "enumgen::Tgp"
 )
 
@@ -32,6 +35,7 @@ var (
 	ErrPrefixTooLong   = fmt.Errorf("prefix cannot exceed %d octets", ndni.NameMaxLength)
 	ErrTooManyReplies  = fmt.Errorf("cannot add more than %d replies", MaxReplies)
 	ErrTooManyWeights  = fmt.Errorf("sum of weight cannot exceed %d", MaxSumWeight)
+	ErrSignerNotData   = errors.New("signer can only be specified on Data reply")
 )
 
 // Config describes producer configuration.
@@ -57,7 +61,10 @@ func (cfg *Config) Validate() error {
 	patterns := []Pattern{}
 	nDataGen := 0
 	for _, pattern := range cfg.Patterns {
-		sumWeight, nData := pattern.applyDefaults()
+		sumWeight, nData, e := pattern.applyDefaults()
+		if e != nil {
+			return e
+		}
 		if sumWeight > MaxSumWeight {
 			return ErrTooManyWeights
 		}
@@ -78,7 +85,7 @@ type Pattern struct {
 	Replies []Reply  `json:"replies"` // if empty, reply with Data FreshnessPeriod=1
 }
 
-func (pattern *Pattern) applyDefaults() (sumWeight, nDataGen int) {
+func (pattern *Pattern) applyDefaults() (sumWeight, nDataGen int, e error) {
 	if len(pattern.Replies) == 0 {
 		pattern.Replies = []Reply{
 			{
@@ -95,6 +102,19 @@ func (pattern *Pattern) applyDefaults() (sumWeight, nDataGen int) {
 		sumWeight += reply.Weight
 		if reply.Kind() == ReplyData {
 			nDataGen++
+			if e := reply.DataGenConfig.Validate(); e != nil {
+				return 0, 0, e
+			}
+		}
+		if reply.Signer != nil {
+			if reply.Kind() != ReplyData {
+				return 0, 0, ErrSignerNotData
+			}
+			signer := *reply.Signer
+			if e := signer.validate(); e != nil {
+				return 0, 0, e
+			}
+			reply.Signer = &signer
 		}
 	}
 	return
@@ -117,8 +137,9 @@ type Reply struct {
 	Weight int `json:"weight,omitempty"` // weight of random choice, minimum/default is 1
 
 	ndni.DataGenConfig
-	Nack    uint8 `json:"nack,omitempty"`    // if not NackNone, reply with Nack instead of Data
-	Timeout bool  `json:"timeout,omitempty"` // if true, drop the Interest instead of sending Data
+	Signer  *SignerConfig `json:"signer,omitempty"`  // if not nil, sign Data instead of using Null signature
+	Nack    uint8         `json:"nack,omitempty"`    // if not NackNone, reply with Nack instead of Data
+	Timeout bool          `json:"timeout,omitempty"` // if true, drop the Interest instead of sending Data
 }
 
 // Kind returns ReplyKind.
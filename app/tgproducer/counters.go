@@ -31,10 +31,16 @@ type Counters struct {
 	NInterests  uint64            `json:"nInterests"`
 	NNoMatch    uint64            `json:"nNoMatch"`
 	NAllocError uint64            `json:"nAllocError"`
+	NSigned     uint64            `json:"nSigned"`     // Data with computed signature
+	NSignDrops  uint64            `json:"nSignDrops"`  // Data dropped due to full crypto queue
+	NSignErrors uint64            `json:"nSignErrors"` // Data dropped due to signing failure
 }
 
 func (cnt Counters) String() string {
 	s := fmt.Sprintf("%dI %dno-match %dalloc-error", cnt.NInterests, cnt.NNoMatch, cnt.NAllocError)
+	if cnt.NSigned+cnt.NSignDrops+cnt.NSignErrors > 0 {
+		s += fmt.Sprintf(" %dsigned %dsign-drop %dsign-error", cnt.NSigned, cnt.NSignDrops, cnt.NSignErrors)
+	}
 	for i, pcnt := range cnt.PerPattern {
 		s += fmt.Sprintf(", pattern(%d) %s", i, pcnt)
 	}
@@ -53,6 +59,9 @@ func (w *worker) accumulateCounters(cnt *Counters) {
 	}
 	cnt.NNoMatch += uint64(w.c.nNoMatch)
 	cnt.NAllocError += uint64(w.c.nAllocError)
+	cnt.NSigned += uint64(w.c.nSigned)
+	cnt.NSignDrops += uint64(w.c.nSignDrops)
+	cnt.NSignErrors += uint64(w.c.nSignErrors)
 }
 
 // Counters retrieves counters.
@@ -16,20 +16,33 @@ var GqlRetrieveByFaceID func(id iface.ID) *Producer
 
 // GraphQL types.
 var (
-	GqlReplyInput          *graphql.InputObject
-	GqlPatternInput        *graphql.InputObject
-	GqlConfigInput         *graphql.InputObject
-	GqlPatternCountersType *graphql.Object
-	GqlCountersType        *graphql.Object
-	GqlProducerType        *gqlserver.NodeType[*Producer]
+	GqlSignatureAlgorithmEnum *graphql.Enum
+	GqlSignerInput            *graphql.InputObject
+	GqlReplyInput             *graphql.InputObject
+	GqlPatternInput           *graphql.InputObject
+	GqlConfigInput            *graphql.InputObject
+	GqlPatternCountersType    *graphql.Object
+	GqlCountersType           *graphql.Object
+	GqlProducerType           *gqlserver.NodeType[*Producer]
 )
 
 func init() {
+	GqlSignatureAlgorithmEnum = gqlserver.NewStringEnum("TgpSignatureAlgorithm", "Traffic generator producer signature algorithm.", SignatureEcdsa, SignatureHmac)
+	GqlSignerInput = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "TgpSignerInput",
+		Description: "Traffic generator producer Data signing definition.",
+		Fields: gqlserver.BindInputFields[SignerConfig](gqlserver.FieldTypes{
+			reflect.TypeFor[SignatureAlgorithm](): GqlSignatureAlgorithmEnum,
+			reflect.TypeFor[ndn.Name]():           gqlserver.NonNullString,
+			reflect.TypeFor[[]byte]():             graphql.String,
+		}),
+	})
 	GqlReplyInput = graphql.NewInputObject(graphql.InputObjectConfig{
 		Name:        "TgpReplyInput",
 		Description: "Traffic generator producer reply definition.",
 		Fields: gqlserver.BindInputFields[Reply](gqlserver.FieldTypes{
-			reflect.TypeFor[ndn.Name](): gqlserver.NonNullString,
+			reflect.TypeFor[ndn.Name]():     gqlserver.NonNullString,
+			reflect.TypeFor[SignerConfig](): GqlSignerInput,
 		}),
 	})
 	GqlPatternInput = graphql.NewInputObject(graphql.InputObjectConfig{
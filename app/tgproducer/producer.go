@@ -6,16 +6,23 @@ import (
 	"fmt"
 
 	"github.com/usnistgov/ndn-dpdk/app/tg/tgdef"
+	"github.com/usnistgov/ndn-dpdk/core/logging"
+	"github.com/usnistgov/ndn-dpdk/dpdk/cryptodev"
 	"github.com/usnistgov/ndn-dpdk/dpdk/ealthread"
 	"github.com/usnistgov/ndn-dpdk/iface"
 	"github.com/usnistgov/ndn-dpdk/ndni"
+	"go.uber.org/zap"
 	"go4.org/must"
 )
 
+var logger = logging.New("tgproducer")
+
 // Producer represents a traffic generator producer instance.
 type Producer struct {
-	cfg     Config
-	workers []*worker
+	cfg          Config
+	workers      []*worker
+	signCrypto   *cryptodev.CryptoDev
+	hmacSessions map[*SignerConfig]*cryptodev.Session
 }
 
 var _ tgdef.Producer = &Producer{}
@@ -32,12 +39,69 @@ func (p *Producer) initPatterns() error {
 		return e
 	}
 
+	p.initSigning()
 	for _, w := range p.workers {
-		w.setPatterns(p.cfg.Patterns, dataGenVec.Take)
+		if e := w.setPatterns(p.cfg.Patterns, dataGenVec.Take, p.hmacSessions); e != nil {
+			return e
+		}
 	}
 	return nil
 }
 
+// initSigning creates a crypto device for offloaded signing.
+// If the crypto device cannot be created, signatures are computed in software.
+func (p *Producer) initSigning() {
+	var offloaded []*SignerConfig
+	for _, pattern := range p.cfg.Patterns {
+		for _, reply := range pattern.Replies {
+			if reply.Signer != nil && !reply.Signer.NoOffload {
+				offloaded = append(offloaded, reply.Signer)
+			}
+		}
+	}
+	if len(offloaded) == 0 {
+		return
+	}
+
+	var cfg cryptodev.VDevConfig
+	cfg.NQueuePairs = len(p.workers)
+	cfg.Socket = p.Face().NumaSocket()
+	cd, e := cryptodev.CreateVDev(cfg)
+	if e != nil {
+		logger.Warn("crypto device unavailable, signing in software", zap.Error(e))
+		return
+	}
+	p.signCrypto = cd
+	for i, w := range p.workers {
+		w.setCrypto(cd.QueuePairs()[i])
+	}
+
+	p.hmacSessions = map[*SignerConfig]*cryptodev.Session{}
+	for _, signer := range offloaded {
+		if signer.Algorithm != SignatureHmac {
+			continue
+		}
+		sess, e := cd.NewHmacSha256Session(signer.Key)
+		if e != nil {
+			logger.Warn("HMAC session unavailable, signing in software", zap.Error(e))
+			continue
+		}
+		p.hmacSessions[signer] = sess
+	}
+}
+
+func (p *Producer) closeSigning() (errs []error) {
+	for _, sess := range p.hmacSessions {
+		errs = append(errs, sess.Close())
+	}
+	p.hmacSessions = nil
+	if p.signCrypto != nil {
+		errs = append(errs, p.signCrypto.Close())
+		p.signCrypto = nil
+	}
+	return errs
+}
+
 // Face returns the associated face.
 func (p Producer) Face() iface.Face {
 	return p.workers[0].face()
@@ -73,6 +137,7 @@ func (p *Producer) Close() error {
 		errs = append(errs, w.close())
 	}
 	p.workers = nil
+	errs = append(errs, p.closeSigning()...)
 	return errors.Join(errs...)
 }
 
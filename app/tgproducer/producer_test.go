@@ -3,6 +3,7 @@ package tgproducer_test
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -183,3 +184,89 @@ func TestDataProducer(t *testing.T) {
 	e = p.Stop()
 	assert.NoError(e)
 }
+
+func TestSigned(t *testing.T) {
+	assert, require := makeAR(t)
+
+	face := intface.MustNew()
+	defer face.D.Close()
+
+	keyName := ndn.ParseName("/K/KEY/k1")
+	signers := []*tgproducer.SignerConfig{
+		{Algorithm: tgproducer.SignatureEcdsa, KeyName: keyName},
+		{Algorithm: tgproducer.SignatureHmac, KeyName: keyName},
+		{Algorithm: tgproducer.SignatureEcdsa, KeyName: keyName, NoOffload: true},
+		{Algorithm: tgproducer.SignatureHmac, KeyName: keyName, NoOffload: true},
+	}
+	cfg := tgproducer.Config{}
+	for i, signer := range signers {
+		cfg.Patterns = append(cfg.Patterns, tgproducer.Pattern{
+			Prefix: ndn.ParseName(fmt.Sprintf("/%d", i)),
+			Replies: []tgproducer.Reply{
+				{
+					DataGenConfig: ndni.DataGenConfig{
+						PayloadLen: 500,
+					},
+					Signer: signer,
+				},
+			},
+		})
+	}
+
+	p, e := tgproducer.New(face.D, cfg)
+	require.NoError(e)
+	defer p.Close()
+	tgtestenv.Open(t, p)
+
+	verifiers := []ndn.Verifier{}
+	for _, pattern := range p.Patterns() {
+		verifier, e := pattern.Replies[0].Signer.Verifier()
+		require.NoError(e)
+		verifiers = append(verifiers, verifier)
+	}
+
+	nData := make([]atomic.Int32, len(signers))
+	go func() {
+		for packet := range face.Rx {
+			require.NotNil(packet.Data)
+			i := int(packet.Data.Name[0].Value[0] - '0')
+			assert.NoError(verifiers[i].Verify(*packet.Data), i)
+			assert.Error(verifiers[(i+1)%len(verifiers)].Verify(*packet.Data), i)
+			nData[i].Add(1)
+		}
+	}()
+
+	p.Launch()
+	for i := range 50 {
+		for j := range signers {
+			face.Tx <- ndn.MakeInterest(fmt.Sprintf("/%d/%d", j, i))
+		}
+		time.Sleep(50 * time.Microsecond)
+	}
+	time.Sleep(200 * time.Millisecond)
+	e = p.Stop()
+	assert.NoError(e)
+
+	for i := range nData {
+		assert.EqualValues(50, nData[i].Load(), i)
+	}
+	cnt := p.Counters()
+	assert.EqualValues(200, cnt.NSigned)
+	assert.Zero(cnt.NSignDrops)
+	assert.Zero(cnt.NSignErrors)
+
+	_, e = tgproducer.New(face.D, tgproducer.Config{
+		Patterns: []tgproducer.Pattern{
+			{
+				Prefix: ndn.ParseName("/N"),
+				Replies: []tgproducer.Reply{
+					{
+						Nack:   an.NackNoRoute,
+						Signer: &tgproducer.SignerConfig{Algorithm: tgproducer.SignatureHmac},
+					},
+				},
+			},
+		},
+	})
+	assert.ErrorIs(e, tgproducer.ErrSignerNotData)
+}
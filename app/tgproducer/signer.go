@@ -0,0 +1,126 @@
+package tgproducer
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
+	"github.com/usnistgov/ndn-dpdk/ndn/keychain"
+	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
+)
+
+// SignatureAlgorithm identifies a Data signing algorithm.
+type SignatureAlgorithm string
+
+// SignatureAlgorithm values.
+const (
+	SignatureEcdsa SignatureAlgorithm = "ecdsa" // SignatureSha256WithEcdsa on P-256 curve
+	SignatureHmac  SignatureAlgorithm = "hmac"  // SignatureHmacWithSha256
+)
+
+// SignerConfig configures Data signing in a reply.
+//
+// The producer appends a SignatureInfo that contains the signature type and KeyLocator, and then
+// computes the signature over the signed portion of the Data.
+// If a DPDK crypto device is available, the computation is offloaded: HMAC-SHA256 is computed
+// entirely by the crypto device, while ECDSA has its SHA256 digest computed by the crypto device
+// and the digest signed in software.
+// Otherwise, the signature is computed in software.
+type SignerConfig struct {
+	// Algorithm selects signature algorithm.
+	Algorithm SignatureAlgorithm `json:"algorithm"`
+
+	// KeyName is the KeyLocator name.
+	// If empty, KeyLocator is omitted.
+	KeyName ndn.Name `json:"keyName,omitempty"`
+
+	// Key is the signing key.
+	// For ECDSA, it is a P-256 private key in PKCS#8 format.
+	// For HMAC, it is a secret key between 1 and 64 octets.
+	// If omitted, a random key is generated, which can be retrieved from Producer.Patterns().
+	Key []byte `json:"key,omitempty"`
+
+	// NoOffload disables crypto device offload, so that signatures are always computed in software.
+	NoOffload bool `json:"noOffload,omitempty"`
+}
+
+func (cfg *SignerConfig) validate() error {
+	switch cfg.Algorithm {
+	case SignatureEcdsa:
+		if len(cfg.Key) == 0 {
+			pvt, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			if e != nil {
+				return e
+			}
+			if cfg.Key, e = x509.MarshalPKCS8PrivateKey(pvt); e != nil {
+				return e
+			}
+		}
+		if _, e := cfg.ecdsaKey(); e != nil {
+			return e
+		}
+	case SignatureHmac:
+		if len(cfg.Key) == 0 {
+			cfg.Key = make([]byte, keychain.HMACKeyLength)
+			if _, e := rand.Read(cfg.Key); e != nil {
+				return e
+			}
+		}
+		if len(cfg.Key) > 64 {
+			return errors.New("HMAC key cannot exceed 64 octets")
+		}
+	default:
+		return fmt.Errorf("unknown signature algorithm %s", cfg.Algorithm)
+	}
+
+	if sigInfo := cfg.sigInfo(); len(sigInfo) > MaxSigInfoLen {
+		return fmt.Errorf("SignatureInfo cannot exceed %d octets", MaxSigInfoLen)
+	}
+	return nil
+}
+
+func (cfg SignerConfig) ecdsaKey() (*ecdsa.PrivateKey, error) {
+	key, e := x509.ParsePKCS8PrivateKey(cfg.Key)
+	if e != nil {
+		return nil, fmt.Errorf("ECDSA key: %w", e)
+	}
+	pvt, ok := key.(*ecdsa.PrivateKey)
+	if !ok || pvt.Curve != elliptic.P256() {
+		return nil, errors.New("ECDSA key is not on P-256 curve")
+	}
+	return pvt, nil
+}
+
+func (cfg SignerConfig) sigType() uint32 {
+	if cfg.Algorithm == SignatureHmac {
+		return an.SigHmacWithSha256
+	}
+	return an.SigSha256WithEcdsa
+}
+
+func (cfg SignerConfig) sigInfo() []byte {
+	si := ndn.SigInfo{
+		Type:       cfg.sigType(),
+		KeyLocator: ndn.KeyLocator{Name: cfg.KeyName},
+	}
+	wire, _ := tlv.EncodeFrom(si.EncodeAs(an.TtDSigInfo))
+	return wire
+}
+
+// Verifier returns a verifier for Data signed by this signer.
+// The configuration must have been validated, and KeyName must be a key name.
+func (cfg SignerConfig) Verifier() (ndn.Verifier, error) {
+	if cfg.Algorithm == SignatureHmac {
+		return keychain.NewHMACPublicKey(cfg.KeyName, cfg.Key)
+	}
+	pvt, e := cfg.ecdsaKey()
+	if e != nil {
+		return nil, e
+	}
+	return keychain.NewECDSAPublicKey(cfg.KeyName, &pvt.PublicKey)
+}
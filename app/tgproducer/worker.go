@@ -5,11 +5,13 @@ package tgproducer
 */
 import "C"
 import (
+	"errors"
 	"unsafe"
 
 	"github.com/usnistgov/ndn-dpdk/app/tg/tgdef"
 	"github.com/usnistgov/ndn-dpdk/core/cptr"
 	"github.com/usnistgov/ndn-dpdk/core/pcg32"
+	"github.com/usnistgov/ndn-dpdk/dpdk/cryptodev"
 	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
 	"github.com/usnistgov/ndn-dpdk/dpdk/ealthread"
 	"github.com/usnistgov/ndn-dpdk/dpdk/pktmbuf"
@@ -45,7 +47,8 @@ func (w worker) rxQueue() *iface.PktQueue {
 	return iface.PktQueueFromPtr(unsafe.Pointer(&w.c.rxQueue))
 }
 
-func (w *worker) setPatterns(patterns []Pattern, takeDataGenMbuf func() *pktmbuf.Packet) {
+func (w *worker) setPatterns(patterns []Pattern, takeDataGenMbuf func() *pktmbuf.Packet,
+	hmacSessions map[*SignerConfig]*cryptodev.Session) error {
 	w.freeDataGen()
 
 	w.c.nPatterns = C.uint8_t(len(patterns))
@@ -56,7 +59,47 @@ func (w *worker) setPatterns(patterns []Pattern, takeDataGenMbuf func() *pktmbuf
 			panic(e)
 		}
 		pattern.assign(&w.c.pattern[i], takeDataGenMbuf)
+
+		for j, reply := range pattern.Replies {
+			if reply.Signer == nil {
+				continue
+			}
+			signer, e := w.newSigner(*reply.Signer, hmacSessions[reply.Signer])
+			if e != nil {
+				return e
+			}
+			w.c.pattern[i].reply[j].signer = signer
+		}
+	}
+	return nil
+}
+
+func (w *worker) setCrypto(qp *cryptodev.QueuePair) {
+	qp.CopyToC(unsafe.Pointer(&w.c.cqp))
+}
+
+func (w *worker) newSigner(cfg SignerConfig, hmacSess *cryptodev.Session) (*C.TgpSigner, error) {
+	signer := eal.Zmalloc[C.TgpSigner]("TgpSigner", C.sizeof_TgpSigner, w.NumaSocket())
+	signer.sigType = C.uint8_t(cfg.sigType())
+	signer.sigInfoL = C.uint16_t(copy(cptr.AsByteSlice(signer.sigInfo[:]), cfg.sigInfo()))
+	if !cfg.NoOffload {
+		switch cfg.Algorithm {
+		case SignatureHmac:
+			if hmacSess != nil {
+				signer.sess = hmacSess.Ptr()
+			}
+		case SignatureEcdsa:
+			signer.sess = w.c.cqp.sha256sess
+		}
+	}
+
+	keyC := C.CBytes(cfg.Key)
+	defer C.free(keyC)
+	if !C.TgpSigner_Init(signer, (*C.uint8_t)(keyC), C.size_t(len(cfg.Key))) {
+		eal.Free(signer)
+		return nil, errors.New("TgpSigner_Init error")
 	}
+	return signer, nil
 }
 
 func (w *worker) close() error {
@@ -67,10 +110,17 @@ func (w *worker) close() error {
 }
 
 func (w *worker) freeDataGen() {
-	for _, pattern := range w.c.pattern[:w.c.nPatterns] {
-		for _, r := range pattern.reply[:pattern.nReplies] {
+	for i := range w.c.pattern[:w.c.nPatterns] {
+		pattern := &w.c.pattern[i]
+		for j := range pattern.reply[:pattern.nReplies] {
+			r := &pattern.reply[j]
 			dataGen := ndni.DataGenFromPtr(unsafe.Pointer(&r.dataGen))
 			dataGen.Close()
+			if r.signer != nil {
+				C.TgpSigner_Uninit(r.signer)
+				eal.Free(r.signer)
+				r.signer = nil
+			}
 		}
 	}
 }
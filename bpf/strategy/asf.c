@@ -0,0 +1,273 @@
+/**
+ * @file
+ * The ASF strategy is an adaptive SRTT-based forwarding strategy, similar to NFD's ASF strategy.
+ * It forwards each Interest to the nexthop with lowest smoothed RTT, and periodically probes an
+ * alternative nexthop to discover faster paths. A strategy timer detects Interest timeouts, in
+ * which case the Interest is retried on the next best nexthop. Timeouts and Nacks are counted as
+ * penalties; a nexthop with too many consecutive penalties is ranked after all other nexthops
+ * until it returns Data again.
+ */
+#include "api.h"
+
+enum StatusCode {
+  S_OK = 0,
+  S_UNKNOWN = 2,
+  S_NO_NEXTHOP = 3,
+  S_FORWARD = 11,
+  S_FORWARD_PROBE = 12,
+  S_RETRY = 21,
+  S_RETRY_NONE = 22,
+  S_DATA = 31,
+  S_DATA_UNKNOWN = 32,
+};
+
+enum {
+  NoNexthop = 0xFF,
+  TierMeasured = 0,
+  TierUnmeasured = 1,
+  TierCongested = 2,
+  TierPenalized = 3,
+};
+
+typedef struct FibEntryInfo {
+  TscDuration probeInterval;
+  TscDuration timeout;
+  TscTime nextProbe;
+  uint8_t maxPenalty;
+  uint8_t probeIndex;
+  uint8_t penalty[FibMaxNexthops];
+} FibEntryInfo;
+
+typedef struct PitEntryInfo {
+  uint8_t nhIndex;
+  uint8_t tried;
+} PitEntryInfo;
+
+/** @brief Obtain sRtt as an integer whose ordering matches the non-negative float ordering. */
+SUBROUTINE uint32_t
+SRttKey(const RttValue* rttv) {
+  union {
+    float f;
+    uint32_t u;
+  } v = {.f = rttv->sRtt};
+  return v.u;
+}
+
+/** @brief Determine whether a nexthop is a downstream of the PIT entry. */
+SUBROUTINE bool
+IsDownstream(SgCtx* ctx, FaceID nh) {
+  for (int i = 0; i < PitMaxDns; ++i) {
+    FaceID dn = ctx->pitEntry->dns[i].face;
+    if (dn == 0) {
+      break;
+    }
+    if (dn == nh) {
+      return true;
+    }
+  }
+  return false;
+}
+
+/**
+ * @brief Compute nexthop ranking key; lower is better.
+ * @return ranking key, or UINT64_MAX if nexthop is unusable.
+ */
+SUBROUTINE uint64_t
+RankNexthop(SgCtx* ctx, uint8_t i, FaceID nh) {
+  FibEntryInfo* fei = SgCtx_FibScratchT(ctx, FibEntryInfo);
+  SgFaceStatus st = {0};
+  if (!SgGetFaceStatus(ctx, nh, &st)) {
+    return UINT64_MAX;
+  }
+
+  uint64_t tier = TierMeasured;
+  uint32_t srtt = SRttKey(&ctx->fibEntryDyn->rtt[i]);
+  if (fei->penalty[i] >= fei->maxPenalty) {
+    tier = TierPenalized;
+  } else if (SgFaceStatus_IsCongestedSince(&st, ctx->now - fei->probeInterval)) {
+    tier = TierCongested;
+  } else if (srtt == 0) {
+    tier = TierUnmeasured;
+  }
+  return (tier << 32) | srtt;
+}
+
+/**
+ * @brief Find best nexthop not yet tried for the PIT entry.
+ * @return nexthop index, or NoNexthop.
+ */
+SUBROUTINE uint8_t
+FindBest(SgCtx* ctx, uint8_t excluded) {
+  uint8_t best = NoNexthop;
+  uint64_t bestKey = UINT64_MAX;
+  SgFibNexthopIt it;
+  for (SgFibNexthopIt_InitCtx(&it, ctx); SgFibNexthopIt_Valid(&it); SgFibNexthopIt_Next(&it)) {
+    if ((excluded & RTE_BIT32(it.i)) != 0 || IsDownstream(ctx, it.nh)) {
+      continue;
+    }
+    uint64_t key = RankNexthop(ctx, it.i, it.nh);
+    if (key < bestKey) {
+      best = it.i;
+      bestKey = key;
+    }
+  }
+  return best;
+}
+
+/** @brief Forward Interest to nexthop and start timeout timer. */
+SUBROUTINE bool
+Forward(SgCtx* ctx, uint8_t i) {
+  FibEntryInfo* fei = SgCtx_FibScratchT(ctx, FibEntryInfo);
+  PitEntryInfo* pei = SgCtx_PitScratchT(ctx, PitEntryInfo);
+  pei->tried |= RTE_BIT32(i);
+  if (SgForwardInterest(ctx, ctx->fibEntry->nexthops[i]) != SGFWDI_OK) {
+    return false;
+  }
+  pei->nhIndex = i;
+  SgSetTimer(ctx, fei->timeout);
+  return true;
+}
+
+/** @brief Forward Interest to an alternative nexthop other than @p best , in round-robin order. */
+SUBROUTINE bool
+Probe(SgCtx* ctx, uint8_t best) {
+  FibEntryInfo* fei = SgCtx_FibScratchT(ctx, FibEntryInfo);
+  uint8_t nNexthops = ctx->fibEntry->nNexthops;
+  for (uint8_t j = 0; j < FibMaxNexthops && j < nNexthops; ++j) {
+    uint8_t i = ++fei->probeIndex % nNexthops;
+    FaceID nh = ctx->fibEntry->nexthops[i];
+    if (i == best || SgFibNexthopFilter_Rejected(ctx->nhFlt, i) || IsDownstream(ctx, nh)) {
+      continue;
+    }
+    SgFaceStatus st = {0};
+    if (!SgGetFaceStatus(ctx, nh, &st)) {
+      continue;
+    }
+    return SgForwardInterest(ctx, nh) == SGFWDI_OK;
+  }
+  return false;
+}
+
+SUBROUTINE uint64_t
+RxInterest(SgCtx* ctx) {
+  FibEntryInfo* fei = SgCtx_FibScratchT(ctx, FibEntryInfo);
+  PitEntryInfo* pei = SgCtx_PitScratchT(ctx, PitEntryInfo);
+  pei->tried = 0;
+
+  uint8_t best = FindBest(ctx, 0);
+  while (best != NoNexthop && !Forward(ctx, best)) {
+    best = FindBest(ctx, pei->tried);
+  }
+  if (best == NoNexthop) {
+    return S_NO_NEXTHOP;
+  }
+
+  if (ctx->now >= fei->nextProbe) {
+    fei->nextProbe = ctx->now + fei->probeInterval;
+    if (Probe(ctx, best)) {
+      return S_FORWARD_PROBE;
+    }
+  }
+  return S_FORWARD;
+}
+
+/** @brief Penalize the current nexthop and retry on the next best nexthop. */
+SUBROUTINE uint64_t
+Retry(SgCtx* ctx, uint8_t failed) {
+  FibEntryInfo* fei = SgCtx_FibScratchT(ctx, FibEntryInfo);
+  PitEntryInfo* pei = SgCtx_PitScratchT(ctx, PitEntryInfo);
+  if (failed < FibMaxNexthops && fei->penalty[failed] < UINT8_MAX) {
+    ++fei->penalty[failed];
+  }
+
+  uint8_t next = FindBest(ctx, pei->tried);
+  while (next != NoNexthop && !Forward(ctx, next)) {
+    next = FindBest(ctx, pei->tried);
+  }
+  return next == NoNexthop ? S_RETRY_NONE : S_RETRY;
+}
+
+SUBROUTINE uint64_t
+Timer(SgCtx* ctx) {
+  PitEntryInfo* pei = SgCtx_PitScratchT(ctx, PitEntryInfo);
+  return Retry(ctx, pei->nhIndex);
+}
+
+SUBROUTINE uint8_t
+FindRxNexthop(SgCtx* ctx) {
+  SgFibNexthopIt it;
+  for (SgFibNexthopIt_Init(&it, ctx->fibEntry, 0); SgFibNexthopIt_Valid(&it);
+       SgFibNexthopIt_Next(&it)) {
+    if (it.nh == ctx->pkt->rxFace) {
+      return it.i;
+    }
+  }
+  return NoNexthop;
+}
+
+SUBROUTINE uint64_t
+RxData(SgCtx* ctx) {
+  FibEntryInfo* fei = SgCtx_FibScratchT(ctx, FibEntryInfo);
+  uint8_t i = FindRxNexthop(ctx);
+  if (i >= FibMaxNexthops) {
+    return S_DATA_UNKNOWN;
+  }
+  fei->penalty[i] = 0;
+  return S_DATA;
+}
+
+SUBROUTINE uint64_t
+RxNack(SgCtx* ctx) {
+  return Retry(ctx, FindRxNexthop(ctx));
+}
+
+uint64_t
+SgMain(SgCtx* ctx) {
+  switch (ctx->eventKind) {
+    case SGEVT_INTEREST:
+      return RxInterest(ctx);
+    case SGEVT_DATA:
+      return RxData(ctx);
+    case SGEVT_NACK:
+      return RxNack(ctx);
+    case SGEVT_TIMER:
+      return Timer(ctx);
+    default:
+      return S_UNKNOWN;
+  }
+}
+
+uint64_t
+SgInit(SgCtx* ctx) {
+  FibEntryInfo* fei = SgCtx_FibScratchT(ctx, FibEntryInfo);
+  fei->probeInterval = SgTscFromMillis(ctx, SgGetJSONScalar(ctx, "probeInterval", 1000));
+  fei->timeout = SgTscFromMillis(ctx, SgGetJSONScalar(ctx, "timeout", 500));
+  fei->maxPenalty = SgGetJSONScalar(ctx, "maxPenalty", 3);
+  return 0;
+}
+
+SGINIT_SCHEMA({
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "probeInterval": {
+      "description": "interval between probes of alternative nexthops, in milliseconds",
+      "type": "integer",
+      "minimum": 1,
+      "maximum": 3600000
+    },
+    "timeout": {
+      "description": "Interest timeout before retrying on the next best nexthop, in milliseconds",
+      "type": "integer",
+      "minimum": 1,
+      "maximum": 60000
+    },
+    "maxPenalty": {
+      "description": "consecutive timeouts or Nacks before a nexthop is ranked last",
+      "type": "integer",
+      "minimum": 1,
+      "maximum": 255
+    }
+  },
+  "additionalProperties": false
+});
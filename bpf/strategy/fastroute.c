@@ -2,13 +2,20 @@
  * @file
  * The fast route strategy multicasts the first Interest, observes which
  * nexthop replies first, and keeps using it. It then periodically probes
- * an unselected nexthop, and switches to it if it is faster.
+ * an unselected nexthop, and switches to it if it is faster. Probing is
+ * more frequent while the selected nexthop face is congested.
  */
 #include "api.h"
 
 // how often to send probe Interest, in number of packets
 #define PROBE_INTERVAL 1024
 
+// how often to send probe Interest while selected nexthop is congested, in number of packets
+#define PROBE_INTERVAL_CONGESTED 64
+
+// how long a congestion signal on a face is considered recent, in milliseconds
+#define CONGESTION_RECENT 100
+
 enum StatusCode {
   S_OK = 0,
   S_UNKNOWN = 2,
@@ -33,6 +40,15 @@ typedef struct PitEntryInfo {
   bool multicastOrProbe;
 } PitEntryInfo;
 
+SUBROUTINE bool
+IsCongested(SgCtx* ctx, FaceID nh) {
+  SgFaceStatus st = {0};
+  if (!SgGetFaceStatus(ctx, nh, &st)) {
+    return true;
+  }
+  return SgFaceStatus_IsCongestedSince(&st, ctx->now - SgTscFromMillis(ctx, CONGESTION_RECENT));
+}
+
 SUBROUTINE bool
 Unicast(SgCtx* ctx) {
   FibEntryInfo* fei = SgCtx_FibScratchT(ctx, FibEntryInfo);
@@ -55,6 +71,10 @@ Probe(SgCtx* ctx) {
   }
 
   FaceID nh = ctx->fibEntry->nexthops[i];
+  SgFaceStatus st = {0};
+  if (!SgGetFaceStatus(ctx, nh, &st)) {
+    return S_PROBE_NONE;
+  }
   SgForwardInterestResult res = SgForwardInterest(ctx, nh);
   if (res != SGFWDI_OK) {
     return S_PROBE_ERR;
@@ -70,7 +90,10 @@ RxInterest(SgCtx* ctx) {
 
   // unicast to selected nexthop
   if (fei->hasSelectedNexthop && Unicast(ctx)) {
-    if (++fei->nUnicast >= PROBE_INTERVAL) {
+    uint16_t probeInterval = IsCongested(ctx, ctx->fibEntry->nexthops[fei->selectedNexthop])
+                               ? PROBE_INTERVAL_CONGESTED
+                               : PROBE_INTERVAL;
+    if (++fei->nUnicast >= probeInterval) {
       fei->nUnicast = 0;
       return Probe(ctx);
     }
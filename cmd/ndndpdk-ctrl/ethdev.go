@@ -87,6 +87,14 @@ func init() {
 				Usage:       "enable RxFlow with specified number of `queues`",
 				DefaultText: "disable RxFlow",
 			},
+			&cli.BoolFlag{
+				Name:  "on-demand-ether",
+				Usage: "create on-demand Ethernet faces for unknown peers",
+			},
+			&cli.IntSliceFlag{
+				Name:  "on-demand-udp",
+				Usage: "create on-demand UDP faces for unknown peers on local UDP `port`",
+			},
 		},
 		Action: func(c *cli.Context) error {
 			vars := map[string]any{
@@ -112,6 +120,12 @@ func init() {
 			if c.IsSet("rx-flow") {
 				vars["rxFlowQueues"] = c.Uint("rx-flow")
 			}
+			if c.Bool("on-demand-ether") || c.IsSet("on-demand-udp") {
+				vars["onDemand"] = map[string]any{
+					"ether":    c.Bool("on-demand-ether"),
+					"udpPorts": c.IntSlice("on-demand-udp"),
+				}
+			}
 
 			return clientDoPrint(c.Context, `
 				mutation createEthPort(
@@ -120,6 +134,7 @@ func init() {
 					$netif: String
 					$mtu: Int
 					$rxFlowQueues: Int
+					$onDemand: EthOnDemandInput
 				) {
 					createEthPort(
 						driver: $driver
@@ -127,6 +142,7 @@ func init() {
 						netif: $netif
 						mtu: $mtu
 						rxFlowQueues: $rxFlowQueues
+						onDemand: $onDemand
 					) {`+gqlEthDevFields+`}
 				}
 			`, vars, "createEthPort")
@@ -43,6 +43,7 @@ func init() {
 					faces {
 						id
 						locator
+						persistency
 						counters @include(if: $withCounters) {`+gqlFaceCounters+`}
 					}
 				}
@@ -80,6 +81,7 @@ func init() {
 						id
 						... on Face {
 							locator
+							persistency
 							counters @include(if: $withCounters) {`+gqlFaceCounters+`}
 						}
 					}
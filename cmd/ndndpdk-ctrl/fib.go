@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/chaseisabelle/flagz"
 	"github.com/urfave/cli/v2"
@@ -25,6 +26,11 @@ func init() {
 						strategy {
 							id
 						}
+						suppress {
+							min
+							max
+							multiplier
+						}
 					}
 				}
 			`, nil, "fib")
@@ -35,6 +41,8 @@ func init() {
 func init() {
 	var name, strategy, params string
 	var nexthops flagz.Flagz
+	var suppressMin, suppressMax time.Duration
+	var suppressMultiplier float64
 	defineCommand(&cli.Command{
 		Category: "fib",
 		Name:     "insert-fib",
@@ -62,6 +70,24 @@ func init() {
 				Usage:       "forwarding strategy parameters `JSON`",
 				Destination: &params,
 			},
+			&cli.DurationFlag{
+				Name:        "suppress-min",
+				Usage:       "PIT suppression initial/minimum duration",
+				DefaultText: "forwarder-wide",
+				Destination: &suppressMin,
+			},
+			&cli.DurationFlag{
+				Name:        "suppress-max",
+				Usage:       "PIT suppression maximum duration",
+				DefaultText: "forwarder-wide",
+				Destination: &suppressMax,
+			},
+			&cli.Float64Flag{
+				Name:        "suppress-multiplier",
+				Usage:       "PIT suppression multiplier on each transmission",
+				DefaultText: "forwarder-wide",
+				Destination: &suppressMultiplier,
+			},
 		},
 		Action: func(c *cli.Context) error {
 			vars := map[string]any{
@@ -78,10 +104,23 @@ func init() {
 				}
 				vars["params"] = paramsJ
 			}
+			if c.IsSet("suppress-min") || c.IsSet("suppress-max") || c.IsSet("suppress-multiplier") {
+				suppress := map[string]any{}
+				if suppressMin > 0 {
+					suppress["min"] = suppressMin.String()
+				}
+				if suppressMax > 0 {
+					suppress["max"] = suppressMax.String()
+				}
+				if suppressMultiplier > 0 {
+					suppress["multiplier"] = suppressMultiplier
+				}
+				vars["suppress"] = suppress
+			}
 
 			return clientDoPrint(c.Context, `
-				mutation insertFibEntry($name: Name!, $nexthops: [ID!]!, $strategy: ID, $params: JSON) {
-					insertFibEntry(name: $name, nexthops: $nexthops, strategy: $strategy, params: $params) {
+				mutation insertFibEntry($name: Name!, $nexthops: [ID!]!, $strategy: ID, $params: JSON, $suppress: FibSuppressConfigInput) {
+					insertFibEntry(name: $name, nexthops: $nexthops, strategy: $strategy, params: $params, suppress: $suppress) {
 						id
 					}
 				}
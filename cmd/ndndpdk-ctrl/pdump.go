@@ -10,9 +10,9 @@ import (
 )
 
 func init() {
-	var filename, name string
+	var filename, name, filter string
 	var faces, ports flagz.Flagz
-	var wantRX, wantTX, wantRxUnmatched bool
+	var wantRX, wantTX, wantRxUnmatched, reassemble bool
 	var sampleProb float64
 	var duration time.Duration
 
@@ -50,11 +50,13 @@ func init() {
 	createFaceSource := func(c *cli.Context, face, dir string) error {
 		var result withID
 		if e := clientDoPrint(c.Context, `
-			mutation createPdumpFaceSource($writer: ID!, $face: ID!, $dir: PdumpDirection!, $name: Name!, $sampleProb: Float!) {
-				createPdumpFaceSource(writer: $writer, face: $face, dir: $dir, names: [{ name: $name, sampleProbability: $sampleProb }]) {
+			mutation createPdumpFaceSource($writer: ID!, $face: ID!, $dir: PdumpDirection!, $name: Name!, $sampleProb: Float!, $filter: String, $reassemble: Boolean) {
+				createPdumpFaceSource(writer: $writer, face: $face, dir: $dir, names: [{ name: $name, sampleProbability: $sampleProb }], filter: $filter, reassemble: $reassemble) {
 					id
 					face { id locator }
 					dir
+					filter
+					reassemble
 				}
 			}
 		`, map[string]any{
@@ -63,6 +65,8 @@ func init() {
 			"dir":        dir,
 			"name":       name,
 			"sampleProb": sampleProb,
+			"filter":     filter,
+			"reassemble": reassemble,
 		}, "createPdumpFaceSource", &result); e != nil {
 			return e
 		}
@@ -173,6 +177,16 @@ func init() {
 				Value:       1.0,
 				Destination: &sampleProb,
 			},
+			&cli.StringFlag{
+				Name:        "filter",
+				Usage:       "filter `expression`, such as 'nack and nackreason == congestion'",
+				Destination: &filter,
+			},
+			&cli.BoolFlag{
+				Name:        "reassemble",
+				Usage:       "reassemble NDNLPv2 fragments for name matching",
+				Destination: &reassemble,
+			},
 		}, commonFlags...),
 		Action: func(c *cli.Context) error {
 			defer closeAll(c)
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"github.com/usnistgov/ndn-dpdk/container/rib"
+)
+
+func init() {
+	defineCommand(&cli.Command{
+		Category: "rib",
+		Name:     "list-rib",
+		Usage:    "List RIB routes",
+		Action: func(c *cli.Context) error {
+			return clientDoPrint(c.Context, `
+				{
+					rib {
+						id
+						name
+						face {
+							id
+						}
+						origin
+						cost
+						childInherit
+						capture
+						expires
+					}
+				}
+			`, nil, "rib")
+		},
+	})
+}
+
+func init() {
+	var name, face string
+	var origin, cost int
+	var childInherit, capture bool
+	var expires time.Duration
+	defineCommand(&cli.Command{
+		Category: "rib",
+		Name:     "insert-rib",
+		Usage:    "Insert or replace a RIB route",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "name",
+				Usage:       "name `prefix`",
+				Destination: &name,
+				Required:    true,
+			},
+			&cli.StringFlag{
+				Name:        "face",
+				Usage:       "nexthop face `ID`",
+				Destination: &face,
+				Required:    true,
+			},
+			&cli.IntFlag{
+				Name:        "origin",
+				Usage:       "route origin",
+				Value:       rib.OriginStatic,
+				Destination: &origin,
+			},
+			&cli.IntFlag{
+				Name:        "cost",
+				Usage:       "route cost",
+				Destination: &cost,
+			},
+			&cli.BoolFlag{
+				Name:        "child-inherit",
+				Usage:       "allow longer prefixes to inherit this route",
+				Value:       true,
+				Destination: &childInherit,
+			},
+			&cli.BoolFlag{
+				Name:        "capture",
+				Usage:       "block this prefix and longer prefixes from inheriting routes of shorter prefixes",
+				Destination: &capture,
+			},
+			&cli.DurationFlag{
+				Name:        "expires",
+				Usage:       "route lifetime",
+				DefaultText: "no expiration",
+				Destination: &expires,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			vars := map[string]any{
+				"name":         name,
+				"face":         face,
+				"origin":       origin,
+				"cost":         cost,
+				"childInherit": childInherit,
+				"capture":      capture,
+			}
+			if expires > 0 {
+				vars["expires"] = expires.String()
+			}
+
+			return clientDoPrint(c.Context, `
+				mutation insertRibRoute($name: Name!, $face: ID!, $origin: Int, $cost: Int, $childInherit: Boolean, $capture: Boolean, $expires: NNMilliseconds) {
+					insertRibRoute(name: $name, face: $face, origin: $origin, cost: $cost, childInherit: $childInherit, capture: $capture, expires: $expires) {
+						id
+					}
+				}
+			`, vars, "insertRibRoute")
+		},
+	})
+}
+
+func init() {
+	defineDeleteCommand("rib", "erase-rib", "Erase a RIB route", "RIB route")
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+func init() {
+	defineCommand(&cli.Command{
+		Category: "face",
+		Name:     "list-socket-listener",
+		Aliases:  []string{"list-socket-listeners"},
+		Usage:    "List socket listeners",
+		Action: func(c *cli.Context) error {
+			return clientDoPrint(c.Context, `
+				{
+					socketListeners {
+						id
+						scheme
+						local
+						idleTimeout
+						faces {
+							id
+							locator
+						}
+					}
+				}
+			`, nil, "socketListeners")
+		},
+	})
+}
+
+func init() {
+	var cfg struct {
+		Scheme      string `json:"scheme"`
+		Local       string `json:"local"`
+		MTU         int    `json:"mtu,omitempty"`
+		IdleTimeout int64  `json:"idleTimeout,omitempty"`
+	}
+	var idleTimeout time.Duration
+	defineCommand(&cli.Command{
+		Category: "face",
+		Name:     "create-socket-listener",
+		Usage:    "Create a socket listener that creates on-demand faces",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "scheme",
+				Usage:       "socket network: tcp, unix, or udp",
+				Destination: &cfg.Scheme,
+				Required:    true,
+			},
+			&cli.StringFlag{
+				Name:        "local",
+				Usage:       "local `address` to listen on",
+				Destination: &cfg.Local,
+				Required:    true,
+			},
+			&cli.IntFlag{
+				Name:        "mtu",
+				Usage:       "on-demand face `MTU`",
+				DefaultText: "maximum",
+				Destination: &cfg.MTU,
+			},
+			&cli.DurationFlag{
+				Name:        "idle-timeout",
+				Usage:       "close on-demand faces after `duration` without traffic",
+				DefaultText: "600s",
+				Destination: &idleTimeout,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			cfg.IdleTimeout = idleTimeout.Milliseconds()
+			return clientDoPrint(c.Context, `
+				mutation createSocketListener($listener: JSON!) {
+					createSocketListener(listener: $listener) {
+						id
+						local
+					}
+				}
+			`, map[string]any{
+				"listener": cfg,
+			}, "createSocketListener")
+		},
+	})
+}
+
+func init() {
+	defineDeleteCommand("face", "destroy-socket-listener", "Destroy a socket listener and its on-demand faces", "socket listener")
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"math"
+	"strings"
 	"time"
 
 	"github.com/urfave/cli/v2"
@@ -129,14 +130,15 @@ func init() {
 }
 
 func init() {
-	var fetcher, name, filename string
+	var fetcher, name, filename, directory, ccAlgo string
 	var segmentBegin, segmentEnd uint64
 	var fileSize int64
-	var segmentLen int
+	var segmentLen, fixedCwnd int
+	var aimdDecrease float64
 	defineCommand(&cli.Command{
 		Category: "trafficgen",
 		Name:     "start-fetch",
-		Usage:    "Start fetching a segmented object",
+		Usage:    "Start fetching a segmented object or a file server directory",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:        "fetcher",
@@ -178,6 +180,28 @@ func init() {
 				Usage:       "segment length `octets`",
 				Destination: &segmentLen,
 			},
+			&cli.StringFlag{
+				Name:        "directory",
+				Usage:       "fetch ndn6-file-server directory into local `directory`",
+				DefaultText: "fetch a segmented object",
+				Destination: &directory,
+			},
+			&cli.StringFlag{
+				Name:        "cc",
+				Usage:       "congestion control `algorithm`: CUBIC, AIMD, BBR, FIXED",
+				Value:       "CUBIC",
+				Destination: &ccAlgo,
+			},
+			&cli.Float64Flag{
+				Name:        "aimd-decrease",
+				Usage:       "AIMD window decrease `factor`",
+				Destination: &aimdDecrease,
+			},
+			&cli.IntFlag{
+				Name:        "fixed-cwnd",
+				Usage:       "FIXED congestion `window`",
+				Destination: &fixedCwnd,
+			},
 		},
 		Action: func(c *cli.Context) error {
 			task := map[string]any{
@@ -194,6 +218,21 @@ func init() {
 				task["fileSize"] = fileSize
 				task["segmentLen"] = segmentLen
 			}
+			if directory != "" {
+				task["directory"] = directory
+			}
+			if c.IsSet("cc") || c.IsSet("aimd-decrease") || c.IsSet("fixed-cwnd") {
+				cc := map[string]any{
+					"algo": strings.ToUpper(ccAlgo),
+				}
+				if c.IsSet("aimd-decrease") {
+					cc["aimdDecrease"] = aimdDecrease
+				}
+				if c.IsSet("fixed-cwnd") {
+					cc["fixedCwnd"] = fixedCwnd
+				}
+				task["congestionControl"] = cc
+			}
 			return clientDoPrint(c.Context, `
 				mutation fetch($fetcher: ID!, $task: FetchTaskDefInput!) {
 					fetch(fetcher: $fetcher, task: $task) {
@@ -207,6 +246,12 @@ func init() {
 							filename
 							fileSize
 							segmentLen
+							directory
+							congestionControl {
+								algo
+								aimdDecrease
+								fixedCwnd
+							}
 						}
 						worker {
 							id
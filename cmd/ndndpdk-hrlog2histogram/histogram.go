@@ -1,15 +1,18 @@
 package main
 
+import (
+	"github.com/usnistgov/ndn-dpdk/app/hrlog/hrlogreader"
+)
+
 type histogram struct {
-	Act    uint8
-	LCore  uint8
+	Act    hrlogreader.Action
+	LCore  *uint8 `json:",omitempty"`
 	Counts []int
 }
 
-func newHistogram(act uint8, lcore uint8) *histogram {
+func newHistogram(act hrlogreader.Action) *histogram {
 	return &histogram{
 		Act:    act,
-		LCore:  lcore,
 		Counts: make([]int, 4096),
 	}
 }
@@ -2,17 +2,26 @@
 package main
 
 import (
+	"cmp"
 	"encoding/json"
 	"flag"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/usnistgov/ndn-dpdk/app/hrlog/hrlogreader"
 )
 
+type histogramKey struct {
+	Act   hrlogreader.Action
+	LCore uint8
+}
+
 func main() {
 	var filename string
+	var perLCore bool
 	flag.StringVar(&filename, "f", "", "input .hrlog filename")
+	flag.BoolVar(&perLCore, "lcore", false, "separate histograms of each lcore")
 	flag.Parse()
 
 	r, e := hrlogreader.Open(filename)
@@ -21,24 +30,36 @@ func main() {
 	}
 	tscMul := float64(time.Second) / float64(time.Microsecond) / float64(r.TscHz)
 
-	hists := map[uint16]*histogram{}
-	for entry := range r.Read() {
-		lcoreAct := uint16(entry)
-		value := entry >> 16
-		microseconds := float64(value) * tscMul
+	hists := map[histogramKey]*histogram{}
+	for value := range r.Read() {
+		entry := hrlogreader.ParseEntry(value)
+		microseconds := float64(entry.Value) * tscMul
 
-		hist := hists[lcoreAct]
+		key := histogramKey{Act: entry.Action}
+		if perLCore {
+			key.LCore = entry.LCore
+		}
+		hist := hists[key]
 		if hist == nil {
-			hist = newHistogram(uint8(lcoreAct), uint8(lcoreAct>>8))
-			hists[lcoreAct] = hist
+			hist = newHistogram(entry.Action)
+			if perLCore {
+				hist.LCore = &key.LCore
+			}
+			hists[key] = hist
 		}
 		hist.Add(int(microseconds))
 	}
 
-	var histArray []*histogram
+	histArray := []*histogram{}
 	for _, hist := range hists {
 		hist.Trim()
 		histArray = append(histArray, hist)
 	}
+	slices.SortFunc(histArray, func(a, b *histogram) int {
+		if c := cmp.Compare(a.Act, b.Act); c != 0 || !perLCore {
+			return c
+		}
+		return cmp.Compare(*a.LCore, *b.LCore)
+	})
 	json.NewEncoder(os.Stdout).Encode(histArray)
 }
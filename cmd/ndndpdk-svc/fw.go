@@ -2,8 +2,10 @@ package main
 
 import (
 	"github.com/usnistgov/ndn-dpdk/app/fwdp"
+	"github.com/usnistgov/ndn-dpdk/app/nfdserver"
 	"github.com/usnistgov/ndn-dpdk/container/fib"
 	"github.com/usnistgov/ndn-dpdk/container/ndt"
+	"github.com/usnistgov/ndn-dpdk/container/rib"
 	"github.com/usnistgov/ndn-dpdk/container/strategycode"
 	"github.com/usnistgov/ndn-dpdk/iface"
 )
@@ -13,6 +15,9 @@ const defaultStrategyName = "multicast"
 type fwArgs struct {
 	CommonArgs
 	fwdp.Config
+
+	// NfdServer enables NFD management responder, if not nil.
+	NfdServer *nfdserver.Config `json:"nfdServer,omitempty"`
 }
 
 func (a fwArgs) Activate() error {
@@ -29,6 +34,7 @@ func (a fwArgs) Activate() error {
 	fwdp.GqlDataPlane = dp
 	iface.GqlCreateFaceAllowed = true
 	ndt.GqlNdt = dp.Ndt()
+	ndt.GqlBalancer = dp.NdtBalancer()
 	fib.GqlFib = dp.Fib()
 
 	fib.GqlDefaultStrategy, e = strategycode.LoadFile(defaultStrategyName, "")
@@ -36,5 +42,16 @@ func (a fwArgs) Activate() error {
 		return e
 	}
 
+	rib.GqlRib = rib.New(rib.Config{
+		Fib:      dp.Fib(),
+		Strategy: fib.GqlDefaultStrategy.ID(),
+	})
+
+	if a.NfdServer != nil {
+		if _, e = nfdserver.New(dp, rib.GqlRib, *a.NfdServer); e != nil {
+			return e
+		}
+	}
+
 	return nil
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
 	"github.com/usnistgov/ndn-dpdk/core/jsonhelper"
 	"github.com/usnistgov/ndn-dpdk/core/logging"
+	"github.com/usnistgov/ndn-dpdk/core/metrics"
 	"github.com/usnistgov/ndn-dpdk/core/version"
 	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
 	"github.com/usnistgov/ndn-dpdk/dpdk/spdkenv"
@@ -32,6 +33,7 @@ func init() {
 		w.Header().Add("Content-Type", "text/plain")
 		w.Write([]byte("User-Agent: *\nDisallow: /\n"))
 	})
+	http.Handle("/metrics", metrics.Handler)
 }
 
 func init() {
@@ -2,13 +2,16 @@ package fib_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/usnistgov/ndn-dpdk/container/fib"
 	"github.com/usnistgov/ndn-dpdk/container/fib/fibdef"
 	"github.com/usnistgov/ndn-dpdk/container/fib/fibtestenv"
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
 	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
 	"github.com/usnistgov/ndn-dpdk/iface"
 	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndni"
 )
 
 func TestReplica1(t *testing.T) {
@@ -202,3 +205,44 @@ func TestLpm(t *testing.T) {
 	checkEntryNames()
 	checkLpms(0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0)
 }
+
+func TestSuppress(t *testing.T) {
+	assert, require := makeAR(t)
+
+	var th0 fibtestenv.LookupThread
+	f, e := fib.New(fibdef.Config{
+		Capacity:   1023,
+		StartDepth: 2,
+	}, []fib.LookupThread{&th0})
+	require.NoError(e)
+	defer f.Close()
+
+	lpmSuppress := func(name string) *ndni.SuppressConfig {
+		entryR := f.Replica(th0.Socket).Lpm(ndn.ParseName(name))
+		require.NotNil(entryR)
+		return entryR.Read().Suppress
+	}
+
+	require.NoError(f.Insert(makeEntry("/A", nil, 5100)))
+	assert.Nil(f.Find(ndn.ParseName("/A")).Suppress)
+	assert.Nil(lpmSuppress("/A/B"))
+
+	entry := makeEntry("/A", nil, 5100)
+	entry.Suppress = &ndni.SuppressConfig{Max: nnduration.Nanoseconds(50 * time.Millisecond)}
+	require.NoError(f.Insert(entry))
+	if found := f.Find(ndn.ParseName("/A")); assert.NotNil(found.Suppress) {
+		assert.Equal(*entry.Suppress, *found.Suppress)
+	}
+	if sc := lpmSuppress("/A/B"); assert.NotNil(sc) {
+		assert.InDelta(float64(ndni.DefaultSuppressMin), float64(sc.Min), float64(time.Microsecond))
+		assert.InDelta(float64(50*time.Millisecond), float64(sc.Max), float64(time.Microsecond))
+		assert.Equal(ndni.DefaultSuppressMultiplier, sc.Multiplier)
+	}
+
+	entry.Suppress = &ndni.SuppressConfig{Min: nnduration.Nanoseconds(time.Second)}
+	assert.Error(f.Insert(entry)) // min exceeds default max
+
+	entry.Suppress = nil
+	require.NoError(f.Insert(entry))
+	assert.Nil(lpmSuppress("/A/B"))
+}
@@ -1,6 +1,9 @@
 package fibdef
 
 import (
+	"math"
+	"time"
+
 	binutils "github.com/jfoster/binary-utilities"
 	"github.com/usnistgov/ndn-dpdk/dpdk/mempool"
 	"github.com/zyedidia/generic"
@@ -15,6 +18,10 @@ const (
 	MinStartDepth     = 2
 	MaxStartDepth     = 17
 	DefaultStartDepth = 8
+
+	// MaxSuppress is the maximum per-entry PIT suppression duration.
+	// FibEntry stores suppression durations as 32-bit microseconds.
+	MaxSuppress = math.MaxUint32 * time.Microsecond
 )
 
 // Config contains FIB configuration.
@@ -9,6 +9,7 @@ import (
 	"github.com/suzuki-shunsuke/go-dataeq/dataeq"
 	"github.com/usnistgov/ndn-dpdk/iface"
 	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndni"
 )
 
 // EntryBody contains logical FIB entry contents except name.
@@ -16,6 +17,10 @@ type EntryBody struct {
 	Nexthops []iface.ID     `json:"nexthops"`
 	Strategy int            `json:"strategy"`
 	Params   map[string]any `json:"params"`
+
+	// Suppress overrides PIT suppression configuration for Interests forwarded via this entry.
+	// If nil, the forwarder-wide configuration applies.
+	Suppress *ndni.SuppressConfig `json:"suppress,omitempty"`
 }
 
 // HasNextHop determines whether a nexthop face exists.
@@ -36,6 +41,9 @@ func EntryBodyEquals(lhs, rhs EntryBody) bool {
 	if eq, e := dataeq.JSON.Equal(lhs.Params, rhs.Params); e != nil || !eq {
 		return false
 	}
+	if (lhs.Suppress == nil) != (rhs.Suppress == nil) || (lhs.Suppress != nil && *lhs.Suppress != *rhs.Suppress) {
+		return false
+	}
 	return true
 }
 
@@ -56,6 +64,14 @@ func (entry Entry) Validate() error {
 	if entry.Strategy == 0 {
 		return errors.New("missing strategy")
 	}
+	if entry.Suppress != nil {
+		if e := entry.Suppress.Validate(); e != nil {
+			return e
+		}
+		if entry.Suppress.Max.Duration() > MaxSuppress {
+			return fmt.Errorf("suppress.max must not exceed %s", MaxSuppress)
+		}
+	}
 	return nil
 }
 
@@ -14,14 +14,17 @@ import (
 	"runtime/cgo"
 	"strconv"
 	"strings"
+	"time"
 	"unsafe"
 
 	"github.com/usnistgov/ndn-dpdk/container/fib/fibdef"
 	"github.com/usnistgov/ndn-dpdk/container/strategycode"
 	"github.com/usnistgov/ndn-dpdk/core/cptr"
 	"github.com/usnistgov/ndn-dpdk/core/jsonhelper"
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
 	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
 	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndni"
 )
 
 // Entry represents a FIB entry.
@@ -66,6 +69,14 @@ func (entry *Entry) Read() (de fibdef.Entry) {
 	}
 
 	de.Strategy = int((*entry.ptrStrategy()).id)
+
+	if entry.suppress.maxUs != 0 {
+		de.Suppress = &ndni.SuppressConfig{
+			Min:        nnduration.Nanoseconds(time.Duration(entry.suppress.minUs) * time.Microsecond),
+			Max:        nnduration.Nanoseconds(time.Duration(entry.suppress.maxUs) * time.Microsecond),
+			Multiplier: float64(entry.suppress.multiplier),
+		}
+	}
 	return
 }
 
@@ -118,6 +129,17 @@ func (entry *Entry) assignReal(u *fibdef.RealUpdate, sgGlobals []unsafe.Pointer)
 		entry.nexthops[i] = C.FaceID(nh)
 	}
 
+	entry.suppress = C.PitSuppressConfigCompact{}
+	if u.Suppress != nil {
+		cfg := *u.Suppress
+		cfg.ApplyDefaults()
+		entry.suppress = C.PitSuppressConfigCompact{
+			minUs:      C.uint32_t(cfg.Min.Duration().Microseconds()),
+			maxUs:      C.uint32_t(max(1, cfg.Max.Duration().Microseconds())),
+			multiplier: C.float(cfg.Multiplier),
+		}
+	}
+
 	sc := strategycode.Get(u.Strategy)
 	*entry.ptrStrategy() = (*C.StrategyCode)(sc.Ptr())
 
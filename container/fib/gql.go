@@ -3,11 +3,14 @@ package fib
 import (
 	"errors"
 	"fmt"
+	"reflect"
 
 	"github.com/graphql-go/graphql"
 	"github.com/usnistgov/ndn-dpdk/container/fib/fibdef"
 	"github.com/usnistgov/ndn-dpdk/container/strategycode"
 	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
+	"github.com/usnistgov/ndn-dpdk/core/jsonhelper"
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
 	"github.com/usnistgov/ndn-dpdk/iface"
 	"github.com/usnistgov/ndn-dpdk/ndn"
 	"github.com/usnistgov/ndn-dpdk/ndni"
@@ -25,11 +28,27 @@ var (
 
 // GraphQL types.
 var (
-	GqlEntryCountersType graphql.Type
-	GqlEntryType         *gqlserver.NodeType[Entry]
+	GqlSuppressConfigType  *graphql.Object
+	GqlSuppressConfigInput *graphql.InputObject
+	GqlEntryCountersType   graphql.Type
+	GqlEntryType           *gqlserver.NodeType[Entry]
 )
 
 func init() {
+	suppressFieldTypes := gqlserver.FieldTypes{
+		reflect.TypeFor[nnduration.Nanoseconds](): nnduration.GqlNanoseconds,
+	}
+	GqlSuppressConfigType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "FibSuppressConfig",
+		Description: "PIT suppression configuration.",
+		Fields:      gqlserver.BindFields[ndni.SuppressConfig](suppressFieldTypes),
+	})
+	GqlSuppressConfigInput = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "FibSuppressConfigInput",
+		Description: "PIT suppression configuration.",
+		Fields:      gqlserver.BindInputFields[ndni.SuppressConfig](suppressFieldTypes),
+	})
+
 	GqlEntryCountersType = graphql.NewObject(graphql.ObjectConfig{
 		Name:   "FibEntryCounters",
 		Fields: gqlserver.BindFields[fibdef.EntryCounters](nil),
@@ -66,6 +85,17 @@ func init() {
 					return strategycode.Get(entry.Strategy), nil
 				},
 			},
+			"suppress": &graphql.Field{
+				Description: "PIT suppression configuration. null indicates forwarder-wide configuration.",
+				Type:        GqlSuppressConfigType,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					entry := p.Source.(Entry)
+					if entry.Suppress == nil {
+						return nil, nil
+					}
+					return *entry.Suppress, nil
+				},
+			},
 			"counters": &graphql.Field{
 				Description: "Entry counters.",
 				Type:        graphql.NewNonNull(GqlEntryCountersType),
@@ -190,6 +220,10 @@ func init() {
 				Description: "Forwarding strategy parameters.",
 				Type:        gqlserver.JSON,
 			},
+			"suppress": &graphql.ArgumentConfig{
+				Description: "PIT suppression configuration. Omit to use forwarder-wide configuration.",
+				Type:        GqlSuppressConfigInput,
+			},
 		},
 		Type: graphql.NewNonNull(GqlEntryType.Object),
 		Resolve: func(p graphql.ResolveParams) (any, error) {
@@ -220,6 +254,12 @@ func init() {
 				entry.Params = params
 			}
 
+			if suppress, ok := p.Args["suppress"].(map[string]any); ok {
+				if e := jsonhelper.Roundtrip(suppress, &entry.Suppress, jsonhelper.DisallowUnknownFields); e != nil {
+					return nil, fmt.Errorf("suppress: %w", e)
+				}
+			}
+
 			if e := GqlFib.Insert(entry); e != nil {
 				return nil, e
 			}
@@ -0,0 +1,203 @@
+package ndt
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/core/logging"
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
+	"go.uber.org/zap"
+)
+
+var logger = logging.New("ndt")
+
+// Balancer defaults.
+const (
+	DefaultBalanceThreshold = 0.2
+	DefaultBalanceMaxMoves  = 64
+)
+
+// BalanceConfig contains NDT load balancing configuration.
+type BalanceConfig struct {
+	// Interval is the interval between rounds of balancing.
+	// Hit counters are sampled at the end of each interval.
+	//
+	// If this value is zero, automatic load balancing is disabled.
+	Interval nnduration.Milliseconds `json:"interval,omitempty" gqldesc:"Interval between rounds of balancing, zero means disabled."`
+
+	// Threshold is the tolerated load imbalance.
+	// A value is overloaded if its load exceeds the average load by more than this ratio.
+	//
+	// If this value is not positive, it defaults to DefaultBalanceThreshold.
+	Threshold float64 `json:"threshold,omitempty" gqldesc:"Tolerated load imbalance ratio."`
+
+	// MaxMoves is the maximum number of entries updated in each round.
+	// Limiting this number reduces disruption to retransmitted Interests.
+	//
+	// If this value is not positive, it defaults to DefaultBalanceMaxMoves.
+	MaxMoves int `json:"maxMoves,omitempty" gqldesc:"Maximum number of entries updated in each round."`
+}
+
+// Enabled determines whether automatic load balancing is enabled.
+func (cfg BalanceConfig) Enabled() bool {
+	return cfg.Interval > 0
+}
+
+func (cfg *BalanceConfig) applyDefaults() {
+	if cfg.Threshold <= 0 {
+		cfg.Threshold = DefaultBalanceThreshold
+	}
+	if cfg.MaxMoves <= 0 {
+		cfg.MaxMoves = DefaultBalanceMaxMoves
+	}
+}
+
+// PlanBalance computes NDT entry updates that move load from overloaded values toward less loaded values.
+//
+// prev and curr should be results of Ndt.List() at the start and end of a sampling interval.
+// nValues is the number of possible values, i.e. forwarding threads.
+// Each returned entry has the new Value and the number of Hits during the interval.
+func PlanBalance(prev, curr []Entry, nValues int, cfg BalanceConfig) (updates []Entry) {
+	cfg.applyDefaults()
+	if nValues < 2 || len(prev) != len(curr) {
+		return nil
+	}
+
+	load := make([]uint64, nValues)
+	byValue := make([][]Entry, nValues)
+	var total uint64
+	for i, entry := range curr {
+		if int(entry.Value) >= nValues {
+			continue
+		}
+		entry.Hits -= prev[i].Hits // uint32 wraparound
+		if entry.Hits == 0 {
+			continue
+		}
+		load[entry.Value] += uint64(entry.Hits)
+		byValue[entry.Value] = append(byValue[entry.Value], entry)
+		total += uint64(entry.Hits)
+	}
+	if total == 0 {
+		return nil
+	}
+	for _, entries := range byValue {
+		slices.SortFunc(entries, func(a, b Entry) int { return cmp.Compare(b.Hits, a.Hits) })
+	}
+	limit := float64(total) / float64(nValues) * (1 + cfg.Threshold)
+
+	for len(updates) < cfg.MaxMoves {
+		src, dst := 0, 0
+		for v, l := range load {
+			if l > load[src] {
+				src = v
+			}
+			if l < load[dst] {
+				dst = v
+			}
+		}
+		if float64(load[src]) <= limit {
+			break
+		}
+
+		// Choose the heaviest entry whose move reduces the larger of the two loads.
+		gap := load[src] - load[dst]
+		j := slices.IndexFunc(byValue[src], func(entry Entry) bool { return uint64(entry.Hits) < gap })
+		if j < 0 {
+			break
+		}
+		entry := byValue[src][j]
+		byValue[src] = slices.Delete(byValue[src], j, j+1)
+
+		load[src] -= uint64(entry.Hits)
+		load[dst] += uint64(entry.Hits)
+		entry.Value = uint8(dst)
+		updates = append(updates, entry)
+	}
+	return updates
+}
+
+// BalancerCounters contains Balancer counters.
+type BalancerCounters struct {
+	NRounds  uint64 `json:"nRounds" gqldesc:"Completed rounds of balancing."`
+	NUpdates uint64 `json:"nUpdates" gqldesc:"Updated NDT entries."`
+}
+
+// Balancer is a maintenance thread that periodically reconfigures the NDT to balance load among forwarding threads.
+//
+// Remapping an NDT entry does not affect in-flight PIT state, because Data and Nacks are dispatched by PIT token.
+// An Interest retransmitted after the remapping is dispatched to the new forwarding thread, which creates a separate PIT entry.
+// This temporary misdispatch is tolerated, and its impact is limited by BalanceConfig.MaxMoves.
+type Balancer struct {
+	ndt     *Ndt
+	nValues int
+	cfg     BalanceConfig
+	quit    chan struct{}
+	wg      sync.WaitGroup
+
+	mutex sync.Mutex
+	cnt   BalancerCounters
+}
+
+// Counters returns counters.
+func (b *Balancer) Counters() BalancerCounters {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.cnt
+}
+
+// Close stops the balancer.
+func (b *Balancer) Close() error {
+	close(b.quit)
+	b.wg.Wait()
+	return nil
+}
+
+func (b *Balancer) loop() {
+	defer b.wg.Done()
+	ticker := time.NewTicker(b.cfg.Interval.Duration())
+	defer ticker.Stop()
+
+	prev := b.ndt.List()
+	for {
+		select {
+		case <-b.quit:
+			return
+		case <-ticker.C:
+		}
+
+		curr := b.ndt.List()
+		updates := PlanBalance(prev, curr, b.nValues, b.cfg)
+		for _, entry := range updates {
+			b.ndt.Update(entry.Index, entry.Value)
+		}
+		prev = curr
+
+		b.mutex.Lock()
+		b.cnt.NRounds++
+		b.cnt.NUpdates += uint64(len(updates))
+		b.mutex.Unlock()
+
+		if len(updates) > 0 {
+			logger.Debug("NDT rebalanced", zap.Int("updates", len(updates)))
+		}
+	}
+}
+
+// NewBalancer starts a Balancer.
+// nValues is the number of forwarding threads.
+// Caller must ensure cfg.Enabled() is true.
+func NewBalancer(ndt *Ndt, nValues int, cfg BalanceConfig) *Balancer {
+	cfg.applyDefaults()
+	b := &Balancer{
+		ndt:     ndt,
+		nValues: nValues,
+		cfg:     cfg,
+		quit:    make(chan struct{}),
+	}
+	b.wg.Add(1)
+	go b.loop()
+	return b
+}
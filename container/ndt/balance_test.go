@@ -0,0 +1,49 @@
+package ndt_test
+
+import (
+	"testing"
+
+	"github.com/usnistgov/ndn-dpdk/container/ndt"
+)
+
+func TestPlanBalance(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	prev := make([]ndt.Entry, 8)
+	curr := make([]ndt.Entry, 8)
+	for i := range curr {
+		prev[i] = ndt.Entry{Index: uint64(i), Value: 0, Hits: 0xFFFFFFF0}
+		curr[i] = ndt.Entry{Index: uint64(i), Value: 0, Hits: 0xFFFFFFF0}
+	}
+	// all load on value 0; hit counters wrap around
+	curr[0].Hits += 400
+	curr[1].Hits += 300
+	curr[2].Hits += 200
+	curr[3].Hits += 100
+	curr[4].Value, curr[4].Hits = 1, curr[4].Hits+10
+
+	updates := ndt.PlanBalance(prev, curr, 3, ndt.BalanceConfig{Threshold: 0.2})
+	load := []uint32{0, 10, 0}
+	for _, entry := range curr {
+		if entry.Value == 0 {
+			load[0] += entry.Hits - 0xFFFFFFF0
+		}
+	}
+	for _, u := range updates {
+		assert.NotEqualValues(0, u.Value)
+		load[0] -= u.Hits
+		load[u.Value] += u.Hits
+	}
+	for v, l := range load {
+		assert.LessOrEqual(float64(l), 1010.0/3*1.2, v)
+	}
+
+	limited := ndt.PlanBalance(prev, curr, 3, ndt.BalanceConfig{MaxMoves: 1})
+	if assert.Len(limited, 1) {
+		assert.EqualValues(0, limited[0].Index)
+		assert.EqualValues(400, limited[0].Hits)
+	}
+
+	assert.Empty(ndt.PlanBalance(prev, prev, 3, ndt.BalanceConfig{}))
+	assert.Empty(ndt.PlanBalance(prev, curr, 1, ndt.BalanceConfig{}))
+}
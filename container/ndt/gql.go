@@ -13,15 +13,20 @@ var (
 	// GqlNdt is the NDT instance accessible via GraphQL.
 	GqlNdt *Ndt
 
-	errNoGqlNdt = errors.New("NDT unavailable")
+	// GqlBalancer is the NDT balancer instance accessible via GraphQL.
+	GqlBalancer *Balancer
+
+	errNoGqlNdt      = errors.New("NDT unavailable")
+	errNoGqlBalancer = errors.New("NDT balancer unavailable")
 	//lint:ignore ST1005 'Index' is a field name
 	errNoIndex = errors.New("Index is unspecified")
 )
 
 // GraphQL types.
 var (
-	GqlConfigType *graphql.Object
-	GqlEntryType  *graphql.Object
+	GqlConfigType           *graphql.Object
+	GqlEntryType            *graphql.Object
+	GqlBalancerCountersType *graphql.Object
 )
 
 func init() {
@@ -72,6 +77,24 @@ func init() {
 		},
 	})
 
+	GqlBalancerCountersType = graphql.NewObject(graphql.ObjectConfig{
+		Name:   "NdtBalancerCounters",
+		Fields: gqlserver.BindFields[BalancerCounters](nil),
+	})
+
+	gqlserver.AddQuery(&graphql.Field{
+		Name:        "ndtBalancerCounters",
+		Description: "NDT balancer counters.",
+		Type:        graphql.NewNonNull(GqlBalancerCountersType),
+		Resolve: func(graphql.ResolveParams) (any, error) {
+			if GqlBalancer == nil {
+				return nil, errNoGqlBalancer
+			}
+
+			return GqlBalancer.Counters(), nil
+		},
+	})
+
 	gqlserver.AddMutation(&graphql.Field{
 		Name:        "updateNdt",
 		Description: "Update NDT entry.",
@@ -7,25 +7,21 @@ import "C"
 import (
 	"unsafe"
 
-	"github.com/usnistgov/ndn-dpdk/core/nnduration"
 	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/ndni"
 )
 
 // SuppressConfig contains PIT suppression configuration.
-type SuppressConfig struct {
-	Min        nnduration.Nanoseconds `json:"min,omitempty"`
-	Max        nnduration.Nanoseconds `json:"max,omitempty"`
-	Multiplier float64                `json:"multiplier,omitempty"`
-}
+// This is the forwarder-wide configuration, which can be overridden in each FIB entry.
+type SuppressConfig ndni.SuppressConfig
 
 // CopyToC copies this configuration to *C.PitSuppressConfig.
 func (sc SuppressConfig) CopyToC(ptr unsafe.Pointer) {
+	cfg := ndni.SuppressConfig(sc)
+	cfg.ApplyDefaults()
+
 	c := (*C.PitSuppressConfig)(ptr)
-	c.min = C.TscDuration(eal.ToTscDuration(sc.Min.DurationOr(10e6)))
-	c.max = C.TscDuration(eal.ToTscDuration(sc.Max.DurationOr(100e6)))
-	if sc.Multiplier >= 1.0 {
-		c.multiplier = C.double(sc.Multiplier)
-	} else {
-		c.multiplier = 2.0
-	}
+	c.min = C.TscDuration(eal.ToTscDuration(cfg.Min.Duration()))
+	c.max = C.TscDuration(eal.ToTscDuration(cfg.Max.Duration()))
+	c.multiplier = C.double(cfg.Multiplier)
 }
@@ -0,0 +1,239 @@
+package rib
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndni"
+)
+
+var (
+	// GqlRib is the RIB instance accessible via GraphQL.
+	GqlRib *RIB
+
+	errNoGqlRib = errors.New("RIB unavailable")
+)
+
+// GraphQL types.
+var (
+	GqlRouteType *gqlserver.NodeType[Route]
+)
+
+func init() {
+	GqlRouteType = gqlserver.NewNodeType(graphql.ObjectConfig{
+		Name: "RibRoute",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{
+				Description: "Route name.",
+				Type:        graphql.NewNonNull(ndni.GqlNameType),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					r := p.Source.(Route)
+					return r.Name, nil
+				},
+			},
+			"face": &graphql.Field{
+				Description: "Nexthop face. null indicates a deleted face.",
+				Type:        iface.GqlFaceType.Object,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					r := p.Source.(Route)
+					return iface.Get(r.Face), nil
+				},
+			},
+			"origin": &graphql.Field{
+				Description: "Route origin.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					r := p.Source.(Route)
+					return r.Origin, nil
+				},
+			},
+			"cost": &graphql.Field{
+				Description: "Route cost.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					r := p.Source.(Route)
+					return r.Cost, nil
+				},
+			},
+			"childInherit": &graphql.Field{
+				Description: "Whether longer prefixes inherit this route.",
+				Type:        gqlserver.NonNullBoolean,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					r := p.Source.(Route)
+					return r.Flags.Has(RouteChildInherit), nil
+				},
+			},
+			"capture": &graphql.Field{
+				Description: "Whether this prefix and longer prefixes are blocked from inheriting routes of shorter prefixes.",
+				Type:        gqlserver.NonNullBoolean,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					r := p.Source.(Route)
+					return r.Flags.Has(RouteCapture), nil
+				},
+			},
+			"expires": &graphql.Field{
+				Description: "Expiration time. null indicates the route does not expire.",
+				Type:        graphql.DateTime,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					r := p.Source.(Route)
+					if r.Expires.IsZero() {
+						return nil, nil
+					}
+					return r.Expires, nil
+				},
+			},
+		},
+	}, gqlserver.NodeConfig[Route]{
+		GetID: func(r Route) string {
+			nameV, _ := r.Name.MarshalBinary()
+			return fmt.Sprintf("%d,%d,%s", r.Face, r.Origin, nameV)
+		},
+		Retrieve: func(id string) (r Route) {
+			if GqlRib == nil {
+				return
+			}
+
+			tokens := strings.SplitN(id, ",", 3)
+			if len(tokens) != 3 {
+				return
+			}
+			var key RouteKey
+			face, e0 := strconv.ParseUint(tokens[0], 10, 16)
+			origin, e1 := strconv.Atoi(tokens[1])
+			e2 := key.Name.UnmarshalBinary([]byte(tokens[2]))
+			if e := errors.Join(e0, e1, e2); e != nil {
+				return
+			}
+			key.Face, key.Origin = iface.ID(face), origin
+
+			r, _ = GqlRib.Get(key)
+			return
+		},
+		Delete: func(r Route) error {
+			if GqlRib == nil {
+				return errNoGqlRib
+			}
+			return GqlRib.Erase(r.RouteKey)
+		},
+	})
+
+	gqlserver.AddQuery(&graphql.Field{
+		Name:        "rib",
+		Description: "List of RIB routes.",
+		Type:        gqlserver.NewListNonNullBoth(GqlRouteType.Object),
+		Args: graphql.FieldConfigArgument{
+			"name": &graphql.ArgumentConfig{
+				Description: "Filter by exact name.",
+				Type:        ndni.GqlNameType,
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			if GqlRib == nil {
+				return nil, errNoGqlRib
+			}
+
+			if name, ok := p.Args["name"].(ndn.Name); ok {
+				return GqlRib.Find(name), nil
+			}
+			return GqlRib.List(), nil
+		},
+	})
+
+	iface.GqlFaceType.Object.AddFieldConfig("ribRoutes", &graphql.Field{
+		Description: "RIB routes toward this face.",
+		Type:        gqlserver.NewListNonNullElem(GqlRouteType.Object),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			if GqlRib == nil {
+				return nil, nil
+			}
+			face := p.Source.(iface.Face)
+			faceID := face.ID()
+
+			var list []Route
+			for _, r := range GqlRib.List() {
+				if r.Face == faceID {
+					list = append(list, r)
+				}
+			}
+			return list, nil
+		},
+	})
+
+	gqlserver.AddMutation(&graphql.Field{
+		Name:        "insertRibRoute",
+		Description: "Insert or replace a RIB route.",
+		Args: graphql.FieldConfigArgument{
+			"name": &graphql.ArgumentConfig{
+				Description: "Route name.",
+				Type:        graphql.NewNonNull(ndni.GqlNameType),
+			},
+			"face": &graphql.ArgumentConfig{
+				Description: "Nexthop face.",
+				Type:        gqlserver.NonNullID,
+			},
+			"origin": &graphql.ArgumentConfig{
+				Description:  "Route origin.",
+				Type:         graphql.Int,
+				DefaultValue: OriginStatic,
+			},
+			"cost": &graphql.ArgumentConfig{
+				Description:  "Route cost.",
+				Type:         graphql.Int,
+				DefaultValue: 0,
+			},
+			"childInherit": &graphql.ArgumentConfig{
+				Description:  "Whether longer prefixes inherit this route.",
+				Type:         graphql.Boolean,
+				DefaultValue: true,
+			},
+			"capture": &graphql.ArgumentConfig{
+				Description:  "Whether this prefix and longer prefixes are blocked from inheriting routes of shorter prefixes.",
+				Type:         graphql.Boolean,
+				DefaultValue: false,
+			},
+			"expires": &graphql.ArgumentConfig{
+				Description: "Route lifetime. Omit for a route that does not expire.",
+				Type:        nnduration.GqlMilliseconds,
+			},
+		},
+		Type: graphql.NewNonNull(GqlRouteType.Object),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			if GqlRib == nil {
+				return nil, errNoGqlRib
+			}
+
+			var r Route
+			r.Name = p.Args["name"].(ndn.Name)
+			face := iface.GqlFaceType.Retrieve(p.Args["face"].(string))
+			if face == nil {
+				return nil, errors.New("face not found")
+			}
+			r.Face = face.ID()
+			r.Origin = p.Args["origin"].(int)
+			r.Cost = p.Args["cost"].(int)
+			if p.Args["childInherit"].(bool) {
+				r.Flags |= RouteChildInherit
+			}
+			if p.Args["capture"].(bool) {
+				r.Flags |= RouteCapture
+			}
+			if expires, ok := p.Args["expires"].(nnduration.Milliseconds); ok {
+				r.Expires = time.Now().Add(expires.Duration())
+			}
+
+			if e := GqlRib.Insert(r); e != nil {
+				return nil, e
+			}
+			r, _ = GqlRib.Get(r.RouteKey)
+			return r, nil
+		},
+	})
+}
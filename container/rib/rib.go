@@ -0,0 +1,360 @@
+// Package rib implements the Routing Information Base.
+package rib
+
+import (
+	"cmp"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/container/fib"
+	"github.com/usnistgov/ndn-dpdk/container/fib/fibdef"
+	"github.com/usnistgov/ndn-dpdk/core/logging"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"go.uber.org/zap"
+)
+
+var logger = logging.New("rib")
+
+// Errors.
+var (
+	ErrRouteNotFound = errors.New("route not found")
+	ErrFibConflict   = errors.New("FIB entry exists and was not installed by RIB")
+)
+
+// Fib represents the FIB updated by the RIB.
+// *fib.Fib implements this interface.
+type Fib interface {
+	Find(name ndn.Name) *fib.Entry
+	Insert(entry fibdef.Entry) error
+	Erase(name ndn.Name) error
+}
+
+// Config contains RIB configuration.
+type Config struct {
+	// Fib is the FIB that receives computed entries.
+	Fib Fib
+
+	// Strategy is the strategy ID of computed FIB entries.
+	Strategy int
+}
+
+// RIB is the Routing Information Base.
+//
+// Routes are keyed by name, face, and origin.
+// For each name with at least one route, the RIB computes a FIB entry whose nexthops are the faces of its own routes
+// and child-inherit routes of shorter prefixes, unless blocked by a capture route.
+// If the same face appears in multiple routes, the lowest cost applies.
+// Nexthops are ordered by increasing cost, and truncated to fibdef.MaxNexthops.
+//
+// The RIB owns the FIB entries it installed: their nexthops are updated when routes change, and they are
+// erased when the last route is removed. Strategy and other fields of an owned FIB entry are preserved.
+// The RIB does not take over FIB entries installed by other means: inserting a route at such a name
+// fails with ErrFibConflict, and such a name is skipped when recomputing descendants of a changed name.
+type RIB struct {
+	cfg Config
+
+	mutex     sync.Mutex
+	entries   map[string]*ribEntry // key is name TLV-VALUE
+	installed map[string]bool      // names with RIB-computed FIB entries
+
+	cancelFaceClosing func()
+}
+
+type ribEntry struct {
+	name   ndn.Name
+	routes map[faceOrigin]*routeRecord
+}
+
+type faceOrigin struct {
+	face   iface.ID
+	origin int
+}
+
+func (e *ribEntry) hasFlag(flag RouteFlags) bool {
+	for _, rr := range e.routes {
+		if rr.Flags.Has(flag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Close stops the RIB.
+// FIB entries are not erased.
+func (rib *RIB) Close() error {
+	rib.cancelFaceClosing()
+
+	rib.mutex.Lock()
+	defer rib.mutex.Unlock()
+	for _, entry := range rib.entries {
+		for _, rr := range entry.routes {
+			rr.stopTimer()
+		}
+	}
+	return nil
+}
+
+// List returns all routes.
+func (rib *RIB) List() (list []Route) {
+	rib.mutex.Lock()
+	defer rib.mutex.Unlock()
+	for _, entry := range rib.entries {
+		for _, rr := range entry.routes {
+			list = append(list, rr.Route)
+		}
+	}
+	sortRoutes(list)
+	return list
+}
+
+// Find returns routes at exactly the specified name.
+func (rib *RIB) Find(name ndn.Name) (list []Route) {
+	rib.mutex.Lock()
+	defer rib.mutex.Unlock()
+	if entry := rib.entries[nameKey(name)]; entry != nil {
+		for _, rr := range entry.routes {
+			list = append(list, rr.Route)
+		}
+	}
+	sortRoutes(list)
+	return list
+}
+
+// Get retrieves a route by key.
+func (rib *RIB) Get(key RouteKey) (r Route, ok bool) {
+	rib.mutex.Lock()
+	defer rib.mutex.Unlock()
+	if entry := rib.entries[nameKey(key.Name)]; entry != nil {
+		if rr := entry.routes[faceOrigin{key.Face, key.Origin}]; rr != nil {
+			return rr.Route, true
+		}
+	}
+	return Route{}, false
+}
+
+// Insert inserts or replaces a route.
+func (rib *RIB) Insert(r Route) error {
+	if e := r.Validate(); e != nil {
+		return e
+	}
+
+	rib.mutex.Lock()
+	defer rib.mutex.Unlock()
+
+	nk := nameKey(r.Name)
+	if !rib.installed[nk] && rib.cfg.Fib.Find(r.Name) != nil {
+		return ErrFibConflict
+	}
+
+	entry := rib.entries[nk]
+	if entry == nil {
+		entry = &ribEntry{
+			name:   r.Name,
+			routes: map[faceOrigin]*routeRecord{},
+		}
+		rib.entries[nk] = entry
+	}
+
+	fo := faceOrigin{r.Face, r.Origin}
+	if old := entry.routes[fo]; old != nil {
+		old.stopTimer()
+	}
+	rr := &routeRecord{Route: r}
+	entry.routes[fo] = rr
+	if !r.Expires.IsZero() {
+		rr.timer = time.AfterFunc(time.Until(r.Expires), func() { rib.expire(rr) })
+	}
+
+	logger.Debug("route inserted", zap.Stringer("route", r.RouteKey), zap.Int("cost", r.Cost), zap.Int("flags", int(r.Flags)))
+	return rib.updateFib(r.Name)
+}
+
+// Erase deletes a route.
+func (rib *RIB) Erase(key RouteKey) error {
+	rib.mutex.Lock()
+	defer rib.mutex.Unlock()
+
+	entry := rib.entries[nameKey(key.Name)]
+	if entry == nil {
+		return ErrRouteNotFound
+	}
+	rr := entry.routes[faceOrigin{key.Face, key.Origin}]
+	if rr == nil {
+		return ErrRouteNotFound
+	}
+	rib.remove(entry, rr)
+	return rib.updateFib(key.Name)
+}
+
+// EraseFace deletes all routes toward a face.
+func (rib *RIB) EraseFace(face iface.ID) error {
+	rib.mutex.Lock()
+	defer rib.mutex.Unlock()
+
+	var names []ndn.Name
+	for _, entry := range rib.entries {
+		for fo, rr := range entry.routes {
+			if fo.face == face {
+				rib.remove(entry, rr)
+				names = append(names, entry.name)
+			}
+		}
+	}
+	return rib.updateFib(names...)
+}
+
+func (rib *RIB) expire(rr *routeRecord) {
+	rib.mutex.Lock()
+	defer rib.mutex.Unlock()
+
+	entry := rib.entries[nameKey(rr.Name)]
+	if entry == nil || entry.routes[faceOrigin{rr.Face, rr.Origin}] != rr { // route was replaced or erased
+		return
+	}
+	logger.Debug("route expired", zap.Stringer("route", rr.RouteKey))
+	rib.remove(entry, rr)
+	if e := rib.updateFib(rr.Name); e != nil {
+		logger.Warn("FIB update error", zap.Stringer("route", rr.RouteKey), zap.Error(e))
+	}
+}
+
+func (rib *RIB) remove(entry *ribEntry, rr *routeRecord) {
+	rr.stopTimer()
+	delete(entry.routes, faceOrigin{rr.Face, rr.Origin})
+	if len(entry.routes) == 0 {
+		delete(rib.entries, nameKey(entry.name))
+	}
+}
+
+// updateFib recomputes FIB entries at changed names and their descendants.
+func (rib *RIB) updateFib(changed ...ndn.Name) (e error) {
+	affected := map[string]ndn.Name{}
+	for _, name := range changed {
+		affected[nameKey(name)] = name
+		for nk, entry := range rib.entries {
+			if name.IsPrefixOf(entry.name) {
+				affected[nk] = entry.name
+			}
+		}
+	}
+
+	for _, nk := range slices.Sorted(maps.Keys(affected)) {
+		name := affected[nk]
+		nexthops := rib.computeNexthops(name)
+		var ue error
+		switch {
+		case len(nexthops) > 0:
+			fe := fibdef.Entry{Name: name}
+			fe.Strategy = rib.cfg.Strategy
+			if old := rib.cfg.Fib.Find(name); old != nil {
+				if !rib.installed[nk] {
+					logger.Info("FIB entry not installed by RIB, skipping", zap.Stringer("name", name))
+					continue
+				}
+				fe.EntryBody = old.EntryBody
+			}
+			fe.Nexthops = nexthops
+			if ue = rib.cfg.Fib.Insert(fe); ue == nil {
+				rib.installed[nk] = true
+			}
+		case rib.installed[nk]:
+			if ue = rib.cfg.Fib.Erase(name); ue == nil {
+				delete(rib.installed, nk)
+			}
+		}
+		if ue != nil {
+			logger.Warn("FIB update error", zap.Stringer("name", name), zap.Error(ue))
+			e = errors.Join(e, ue)
+		}
+	}
+	return e
+}
+
+// NexthopCost returns the cost of a nexthop in the FIB entry computed at a name.
+// ok is false if the name has no routes or the face is not a nexthop.
+func (rib *RIB) NexthopCost(name ndn.Name, face iface.ID) (cost int, ok bool) {
+	rib.mutex.Lock()
+	defer rib.mutex.Unlock()
+	cost, ok = rib.computeCosts(name)[face]
+	return
+}
+
+// computeNexthops determines FIB nexthops at a name.
+func (rib *RIB) computeNexthops(name ndn.Name) []iface.ID {
+	costs := rib.computeCosts(name)
+	nexthops := slices.Collect(maps.Keys(costs))
+	slices.SortFunc(nexthops, func(a, b iface.ID) int {
+		return cmp.Or(cmp.Compare(costs[a], costs[b]), cmp.Compare(a, b))
+	})
+	if len(nexthops) > fibdef.MaxNexthops {
+		nexthops = nexthops[:fibdef.MaxNexthops]
+	}
+	return nexthops
+}
+
+// computeCosts determines the cost of each face at a name, including inherited routes.
+func (rib *RIB) computeCosts(name ndn.Name) map[iface.ID]int {
+	entry := rib.entries[nameKey(name)]
+	if entry == nil {
+		return nil
+	}
+
+	costs := map[iface.ID]int{}
+	for _, rr := range entry.routes {
+		if cost, ok := costs[rr.Face]; !ok || rr.Cost < cost {
+			costs[rr.Face] = rr.Cost
+		}
+	}
+
+	capture := entry.hasFlag(RouteCapture)
+	for i := len(name) - 1; i >= 0 && !capture; i-- {
+		ancestor := rib.entries[nameKey(name[:i])]
+		if ancestor == nil {
+			continue
+		}
+		inherited := map[iface.ID]int{}
+		for _, rr := range ancestor.routes {
+			if _, ok := costs[rr.Face]; !ok && rr.Flags.Has(RouteChildInherit) {
+				if cost, ok := inherited[rr.Face]; !ok || rr.Cost < cost {
+					inherited[rr.Face] = rr.Cost
+				}
+			}
+		}
+		maps.Copy(costs, inherited)
+		capture = ancestor.hasFlag(RouteCapture)
+	}
+	return costs
+}
+
+// New creates a RIB.
+func New(cfg Config) *RIB {
+	rib := &RIB{
+		cfg:       cfg,
+		entries:   map[string]*ribEntry{},
+		installed: map[string]bool{},
+	}
+	rib.cancelFaceClosing = iface.OnFaceClosing(func(id iface.ID) {
+		// face closing event is emitted on the main thread, while FIB updates must wait for the main thread
+		go rib.EraseFace(id)
+	})
+	return rib
+}
+
+func nameKey(name ndn.Name) string {
+	value, _ := name.MarshalBinary()
+	return string(value)
+}
+
+func sortRoutes(list []Route) {
+	slices.SortFunc(list, func(a, b Route) int {
+		return cmp.Or(
+			cmp.Compare(a.Name.String(), b.Name.String()),
+			cmp.Compare(a.Face, b.Face),
+			cmp.Compare(a.Origin, b.Origin),
+		)
+	})
+}
@@ -0,0 +1,119 @@
+package rib_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/container/fib/fibdef"
+	"github.com/usnistgov/ndn-dpdk/container/rib"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+)
+
+func makeRoute(name string, face iface.ID, origin, cost int, flags rib.RouteFlags) (r rib.Route) {
+	r.Name = ndn.ParseName(name)
+	r.Face, r.Origin, r.Cost, r.Flags = face, origin, cost, flags
+	return
+}
+
+func TestInherit(t *testing.T) {
+	assert, require := makeAR(t)
+	fib := newFakeFib()
+	r := rib.New(rib.Config{Fib: fib, Strategy: 1})
+	defer r.Close()
+
+	require.NoError(r.Insert(makeRoute("/A", 1001, rib.OriginStatic, 10, rib.RouteChildInherit)))
+	require.NoError(r.Insert(makeRoute("/A/B", 1002, rib.OriginApp, 0, 0)))
+	require.NoError(r.Insert(makeRoute("/A/B/C", 1003, rib.OriginNLSR, 20, rib.RouteChildInherit)))
+	assert.Equal([]iface.ID{1001}, fib.Nexthops("/A"))
+	assert.Equal([]iface.ID{1002, 1001}, fib.Nexthops("/A/B"))
+	assert.Equal([]iface.ID{1001, 1003}, fib.Nexthops("/A/B/C"))
+
+	// same face, lower cost at longer prefix
+	require.NoError(r.Insert(makeRoute("/A/B/C", 1001, rib.OriginNLSR, 5, 0)))
+	assert.Equal([]iface.ID{1001, 1003}, fib.Nexthops("/A/B/C"))
+	// same face, multiple origins: lowest cost applies
+	require.NoError(r.Insert(makeRoute("/A/B/C", 1003, rib.OriginStatic, 1, 0)))
+	assert.Equal([]iface.ID{1003, 1001}, fib.Nexthops("/A/B/C"))
+	assert.Len(r.Find(ndn.ParseName("/A/B/C")), 3)
+
+	// capture blocks inheritance at this prefix and longer prefixes
+	require.NoError(r.Insert(makeRoute("/A/B", 1004, rib.OriginStatic, 0, rib.RouteCapture)))
+	assert.ElementsMatch([]iface.ID{1002, 1004}, fib.Nexthops("/A/B"))
+	assert.Equal([]iface.ID{1003, 1001}, fib.Nexthops("/A/B/C"))
+	require.NoError(r.Erase(rib.RouteKey{Name: ndn.ParseName("/A/B/C"), Face: 1001, Origin: rib.OriginNLSR}))
+	assert.Equal([]iface.ID{1003}, fib.Nexthops("/A/B/C"))
+
+	// erasing ancestor route recomputes descendants
+	require.NoError(r.Erase(rib.RouteKey{Name: ndn.ParseName("/A/B"), Face: 1004, Origin: rib.OriginStatic}))
+	assert.Equal([]iface.ID{1002, 1001}, fib.Nexthops("/A/B"))
+	assert.Equal([]iface.ID{1003, 1001}, fib.Nexthops("/A/B/C"))
+	require.NoError(r.Erase(rib.RouteKey{Name: ndn.ParseName("/A"), Face: 1001, Origin: rib.OriginStatic}))
+	assert.Nil(fib.Nexthops("/A"))
+	assert.Equal([]iface.ID{1002}, fib.Nexthops("/A/B"))
+	assert.Equal([]iface.ID{1003}, fib.Nexthops("/A/B/C"))
+
+	assert.ErrorIs(r.Erase(rib.RouteKey{Name: ndn.ParseName("/A"), Face: 1001, Origin: rib.OriginStatic}), rib.ErrRouteNotFound)
+	assert.Error(r.Insert(makeRoute("/A", 0, rib.OriginStatic, 0, 0)))
+	assert.Error(r.Insert(makeRoute("/A", 1001, rib.OriginStatic, -1, 0)))
+}
+
+func TestExpireEraseFace(t *testing.T) {
+	assert, require := makeAR(t)
+	fib := newFakeFib()
+	r := rib.New(rib.Config{Fib: fib, Strategy: 1})
+	defer r.Close()
+
+	route := makeRoute("/E", 1001, rib.OriginClient, 0, rib.RouteChildInherit)
+	route.Expires = time.Now().Add(100 * time.Millisecond)
+	require.NoError(r.Insert(route))
+	require.NoError(r.Insert(makeRoute("/E/F", 1002, rib.OriginClient, 0, 0)))
+	require.NoError(r.Insert(makeRoute("/G", 1002, rib.OriginClient, 0, 0)))
+	assert.Equal([]iface.ID{1002, 1001}, fib.Nexthops("/E/F"))
+	assert.Len(r.List(), 3)
+
+	time.Sleep(300 * time.Millisecond)
+	assert.Nil(fib.Nexthops("/E"))
+	assert.Equal([]iface.ID{1002}, fib.Nexthops("/E/F"))
+	_, ok := r.Get(route.RouteKey)
+	assert.False(ok)
+
+	require.NoError(r.EraseFace(1002))
+	assert.Nil(fib.Nexthops("/E/F"))
+	assert.Nil(fib.Nexthops("/G"))
+	assert.Len(r.List(), 0)
+}
+
+func TestFibOwnership(t *testing.T) {
+	assert, require := makeAR(t)
+	fib := newFakeFib()
+	r := rib.New(rib.Config{Fib: fib, Strategy: 1})
+	defer r.Close()
+
+	foreign := fibdef.Entry{Name: ndn.ParseName("/F/G")}
+	foreign.Nexthops, foreign.Strategy = []iface.ID{1009}, 2
+	require.NoError(fib.Insert(foreign))
+
+	// RIB does not take over a FIB entry it did not install
+	assert.ErrorIs(r.Insert(makeRoute("/F/G", 1001, rib.OriginStatic, 0, 0)), rib.ErrFibConflict)
+	assert.Len(r.Find(ndn.ParseName("/F/G")), 0)
+	require.NoError(r.Insert(makeRoute("/F", 1001, rib.OriginStatic, 0, rib.RouteChildInherit)))
+	assert.Equal([]iface.ID{1001}, fib.Nexthops("/F"))
+	assert.Equal([]iface.ID{1009}, fib.Nexthops("/F/G"))
+
+	// RIB preserves strategy and parameters of an owned FIB entry
+	owned := *fib.Find(ndn.ParseName("/F"))
+	owned.Strategy, owned.Params = 3, map[string]any{"k": 1}
+	require.NoError(fib.Insert(owned.Entry))
+	require.NoError(r.Insert(makeRoute("/F", 1002, rib.OriginStatic, 1, 0)))
+	if updated := fib.Find(ndn.ParseName("/F")); assert.NotNil(updated) {
+		assert.Equal([]iface.ID{1001, 1002}, updated.Nexthops)
+		assert.Equal(3, updated.Strategy)
+		assert.Equal(owned.Params, updated.Params)
+	}
+
+	require.NoError(r.Erase(rib.RouteKey{Name: ndn.ParseName("/F"), Face: 1001, Origin: rib.OriginStatic}))
+	require.NoError(r.Erase(rib.RouteKey{Name: ndn.ParseName("/F"), Face: 1002, Origin: rib.OriginStatic}))
+	assert.Nil(fib.Nexthops("/F"))
+	assert.Equal([]iface.ID{1009}, fib.Nexthops("/F/G"))
+}
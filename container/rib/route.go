@@ -0,0 +1,90 @@
+package rib
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/container/fib/fibdef"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+)
+
+// Route origin values, as assigned in NFD management protocol.
+const (
+	OriginApp       = 0
+	OriginAutoreg   = 64
+	OriginClient    = 65
+	OriginAutoconf  = 66
+	OriginNLSR      = 128
+	OriginPrefixAnn = 129
+	OriginStatic    = 255
+)
+
+// RouteFlags contains route inheritance flags.
+type RouteFlags int
+
+// RouteFlags bits, as assigned in NFD management protocol.
+const (
+	// RouteChildInherit allows longer prefixes to inherit this route.
+	RouteChildInherit RouteFlags = 1 << iota
+
+	// RouteCapture prevents this prefix and longer prefixes from inheriting routes of shorter prefixes.
+	RouteCapture
+)
+
+// Has determines whether flag bits are set.
+func (flags RouteFlags) Has(bits RouteFlags) bool {
+	return flags&bits == bits
+}
+
+// RouteKey identifies a route.
+type RouteKey struct {
+	Name   ndn.Name `json:"name"`
+	Face   iface.ID `json:"face"`
+	Origin int      `json:"origin"`
+}
+
+func (key RouteKey) String() string {
+	return fmt.Sprintf("%s@%d/%d", key.Name, key.Face, key.Origin)
+}
+
+// Route represents a route in the RIB.
+type Route struct {
+	RouteKey
+	Cost  int        `json:"cost"`
+	Flags RouteFlags `json:"flags"`
+
+	// Expires is the route expiration time.
+	// Zero value means the route does not expire.
+	Expires time.Time `json:"expires"`
+}
+
+// Validate checks route fields.
+func (r Route) Validate() error {
+	if r.Name.Length() > fibdef.MaxNameLength {
+		return errors.New("route name too long")
+	}
+	if r.Face == 0 {
+		return errors.New("missing face")
+	}
+	if r.Origin < 0 || r.Origin > 0xFFFF {
+		return errors.New("origin out of range")
+	}
+	if r.Cost < 0 {
+		return errors.New("cost cannot be negative")
+	}
+	return nil
+}
+
+type routeRecord struct {
+	Route
+	timer *time.Timer
+}
+
+func (rr *routeRecord) stopTimer() {
+	if rr.timer != nil {
+		rr.timer.Stop()
+		rr.timer = nil
+	}
+}
@@ -0,0 +1,62 @@
+package rib_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/usnistgov/ndn-dpdk/container/fib"
+	"github.com/usnistgov/ndn-dpdk/container/fib/fibdef"
+	"github.com/usnistgov/ndn-dpdk/core/testenv"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+)
+
+func TestMain(m *testing.M) {
+	testenv.Exit(m.Run())
+}
+
+var makeAR = testenv.MakeAR
+
+type fakeFib struct {
+	mutex   sync.Mutex
+	entries map[string]fibdef.Entry
+}
+
+func (f *fakeFib) Find(name ndn.Name) *fib.Entry {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if entry, ok := f.entries[name.String()]; ok {
+		return &fib.Entry{Entry: entry}
+	}
+	return nil
+}
+
+func (f *fakeFib) Insert(entry fibdef.Entry) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.entries[entry.Name.String()] = entry
+	return nil
+}
+
+func (f *fakeFib) Erase(name ndn.Name) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	delete(f.entries, name.String())
+	return nil
+}
+
+// Nexthops returns FIB nexthops at a name, or nil if FIB entry does not exist.
+func (f *fakeFib) Nexthops(name string) []iface.ID {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if entry, ok := f.entries[ndn.ParseName(name).String()]; ok {
+		return entry.Nexthops
+	}
+	return nil
+}
+
+func newFakeFib() *fakeFib {
+	return &fakeFib{
+		entries: map[string]fibdef.Entry{},
+	}
+}
@@ -0,0 +1,241 @@
+// Package metrics exports counters in Prometheus text exposition format.
+// It is a singleton initialized via init() functions.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// Namespace is the prefix of all metric names.
+const Namespace = "ndndpdk"
+
+// Collector writes metrics of a subsystem.
+// It is invoked on every scrape.
+type Collector func(w *Writer)
+
+var (
+	collectorsMutex sync.RWMutex
+	collectors      []Collector
+)
+
+// AddCollector registers a Collector.
+func AddCollector(c Collector) {
+	collectorsMutex.Lock()
+	defer collectorsMutex.Unlock()
+	collectors = append(collectors, c)
+}
+
+// Label is a metric label.
+type Label struct {
+	Key   string
+	Value string
+}
+
+// L constructs a Label.
+// value is formatted with fmt.Sprint.
+func L(key string, value any) Label {
+	return Label{key, fmt.Sprint(value)}
+}
+
+// Metric types.
+const (
+	TypeCounter = "counter"
+	TypeGauge   = "gauge"
+)
+
+type family struct {
+	help    string
+	typ     string
+	samples []string
+}
+
+// Writer collects metrics during a scrape.
+// Samples are grouped by metric family in the output.
+type Writer struct {
+	order    []string
+	families map[string]*family
+}
+
+func (w *Writer) add(name, help, typ string, value float64, labels []Label) {
+	f := w.families[name]
+	if f == nil {
+		f = &family{help: help, typ: typ}
+		w.families[name] = f
+		w.order = append(w.order, name)
+	}
+
+	var b strings.Builder
+	b.WriteString(name)
+	if len(labels) > 0 {
+		b.WriteByte('{')
+		for i, l := range labels {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(l.Key)
+			b.WriteString(`="`)
+			b.WriteString(labelValueEscaper.Replace(l.Value))
+			b.WriteByte('"')
+		}
+		b.WriteByte('}')
+	}
+	b.WriteByte(' ')
+	b.WriteString(strconv.FormatFloat(value, 'g', -1, 64))
+	f.samples = append(f.samples, b.String())
+}
+
+// Counter writes a counter sample.
+// name is appended to Namespace, and "_total" suffix is appended.
+func (w *Writer) Counter(name, help string, value uint64, labels ...Label) {
+	w.add(Namespace+"_"+name+"_total", help, TypeCounter, float64(value), labels)
+}
+
+// Gauge writes a gauge sample.
+// name is appended to Namespace.
+func (w *Writer) Gauge(name, help string, value float64, labels ...Label) {
+	w.add(Namespace+"_"+name, help, TypeGauge, value, labels)
+}
+
+// Struct writes numeric fields of a struct.
+//
+// Metric name is subsystem followed by the JSON field name converted to snake case.
+// Metric help is taken from `gqldesc` tag.
+// A uint64 field is a counter, unless it has `subtract:"-"` tag that indicates a non-cumulative value.
+// Other numeric fields are gauges; time.Duration is converted to seconds.
+// Embedded structs are flattened; other struct and pointer fields are visited recursively with their names as
+// additional prefix.
+// Slices, maps, and fields with `json:"-"` tag are skipped.
+func (w *Writer) Struct(subsystem string, v any, labels ...Label) {
+	w.structV(subsystem, reflect.ValueOf(v), labels)
+}
+
+func (w *Writer) structV(prefix string, val reflect.Value, labels []Label) {
+	for val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return
+	}
+
+	typ := val.Type()
+	for i := range typ.NumField() {
+		field, fv := typ.Field(i), val.Field(i)
+		switch {
+		case field.Anonymous:
+			w.structV(prefix, fv, labels)
+			continue
+		case !field.IsExported():
+			continue
+		}
+
+		jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if jsonName == "-" {
+			continue
+		}
+		if jsonName == "" {
+			jsonName = field.Name
+		}
+		name, help := prefix+"_"+toSnakeCase(jsonName), field.Tag.Get("gqldesc")
+
+		for fv.Kind() == reflect.Pointer && !fv.IsNil() && fv.Elem().Kind() != reflect.Struct {
+			fv = fv.Elem()
+		}
+		switch {
+		case fv.Type() == reflect.TypeFor[time.Duration]():
+			w.Gauge(name+"_seconds", help, time.Duration(fv.Int()).Seconds(), labels...)
+		case fv.Kind() == reflect.Uint64 && field.Type.Kind() == reflect.Uint64 && field.Tag.Get("subtract") != "-":
+			w.Counter(name, help, fv.Uint(), labels...)
+		case fv.CanUint():
+			w.Gauge(name, help, float64(fv.Uint()), labels...)
+		case fv.CanInt():
+			w.Gauge(name, help, float64(fv.Int()), labels...)
+		case fv.CanFloat():
+			w.Gauge(name, help, fv.Float(), labels...)
+		case fv.Kind() == reflect.Struct, fv.Kind() == reflect.Pointer:
+			w.structV(name, fv, labels)
+		}
+	}
+}
+
+// countingWriter counts octets written to the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (n int, e error) {
+	n, e = cw.w.Write(p)
+	cw.n += int64(n)
+	return n, e
+}
+
+// WriteTo writes collected metrics in text exposition format.
+func (w *Writer) WriteTo(o io.Writer) (n int64, e error) {
+	cw := &countingWriter{w: o}
+	bw := bufio.NewWriter(cw)
+	for _, name := range w.order {
+		f := w.families[name]
+		if f.help != "" {
+			fmt.Fprintf(bw, "# HELP %s %s\n", name, helpEscaper.Replace(f.help))
+		}
+		fmt.Fprintf(bw, "# TYPE %s %s\n", name, f.typ)
+		for _, sample := range f.samples {
+			bw.WriteString(sample)
+			bw.WriteByte('\n')
+		}
+	}
+	e = bw.Flush()
+	return cw.n, e
+}
+
+// Collect invokes all registered collectors.
+func Collect() *Writer {
+	w := &Writer{
+		families: map[string]*family{},
+	}
+	collectorsMutex.RLock()
+	defer collectorsMutex.RUnlock()
+	for _, c := range collectors {
+		c(w)
+	}
+	return w
+}
+
+// Handler is an HTTP handler that serves collected metrics.
+var Handler = http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+	rw.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	Collect().WriteTo(rw)
+})
+
+var (
+	labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	helpEscaper       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func toSnakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// start a new word at lower-to-upper transition, or at the last upper letter of an acronym
+			if i > 0 && (!unicode.IsUpper(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,87 @@
+package metrics_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/core/metrics"
+	"github.com/usnistgov/ndn-dpdk/core/testenv"
+)
+
+var makeAR = testenv.MakeAR
+
+type testInner struct {
+	NHits uint64 `json:"nHits" gqldesc:"Hits."`
+}
+
+type testCounters struct {
+	testInner
+	NEntries  uint64         `json:"nEntries" gqldesc:"Current entries." subtract:"-"`
+	Cwnd      int            `json:"cwnd"`
+	SRtt      time.Duration  `json:"sRtt"`
+	Finished  *time.Duration `json:"finished"`
+	Min       *uint64        `json:"min"`
+	Nested    testInner      `json:"nested"`
+	PerThread []uint64       `json:"perThread"`
+	Skipped   uint64         `json:"-"`
+	RxAcks    uint64         `json:"rxAcks"`
+}
+
+func TestWriter(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	min := uint64(4)
+	metrics.AddCollector(func(w *metrics.Writer) {
+		w.Struct("test", testCounters{
+			testInner: testInner{NHits: 1},
+			NEntries:  2,
+			Cwnd:      3,
+			SRtt:      1500 * time.Millisecond,
+			Min:       &min,
+			PerThread: []uint64{5},
+			Skipped:   6,
+			RxAcks:    7,
+		}, metrics.L("face", 4097))
+		w.Counter("test_n_hits", "Hits.", 8, metrics.L("face", "a\"b"))
+	})
+
+	var b bytes.Buffer
+	n, e := metrics.Collect().WriteTo(&b)
+	assert.NoError(e)
+	assert.EqualValues(b.Len(), n)
+	assert.Equal(`# HELP ndndpdk_test_n_hits_total Hits.
+# TYPE ndndpdk_test_n_hits_total counter
+ndndpdk_test_n_hits_total{face="4097"} 1
+ndndpdk_test_n_hits_total{face="a\"b"} 8
+# HELP ndndpdk_test_n_entries Current entries.
+# TYPE ndndpdk_test_n_entries gauge
+ndndpdk_test_n_entries{face="4097"} 2
+# TYPE ndndpdk_test_cwnd gauge
+ndndpdk_test_cwnd{face="4097"} 3
+# TYPE ndndpdk_test_s_rtt_seconds gauge
+ndndpdk_test_s_rtt_seconds{face="4097"} 1.5
+# TYPE ndndpdk_test_min gauge
+ndndpdk_test_min{face="4097"} 4
+# HELP ndndpdk_test_nested_n_hits_total Hits.
+# TYPE ndndpdk_test_nested_n_hits_total counter
+ndndpdk_test_nested_n_hits_total{face="4097"} 0
+# TYPE ndndpdk_test_rx_acks_total counter
+ndndpdk_test_rx_acks_total{face="4097"} 7
+`, b.String())
+}
+
+func TestWriteToLarge(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	w := metrics.Collect()
+	for i := range 1000 {
+		w.Gauge("test_large", "", float64(i), metrics.L("index", i))
+	}
+
+	var b bytes.Buffer
+	n, e := w.WriteTo(&b)
+	assert.NoError(e)
+	assert.Greater(b.Len(), 4096) // exceeds bufio default buffer size
+	assert.EqualValues(b.Len(), n)
+}
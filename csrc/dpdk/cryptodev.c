@@ -6,3 +6,17 @@ struct rte_crypto_sym_xform CryptoDev_Sha256Xform = {
   .auth.algo = RTE_CRYPTO_AUTH_SHA256,
   .auth.digest_length = 32,
 };
+
+void*
+CryptoDev_CreateHmacSha256Session(uint8_t dev, struct rte_mempool* mp, const uint8_t* key,
+                                  uint16_t keyLen) {
+  struct rte_crypto_sym_xform xform = {
+    .type = RTE_CRYPTO_SYM_XFORM_AUTH,
+    .auth.op = RTE_CRYPTO_AUTH_OP_GENERATE,
+    .auth.algo = RTE_CRYPTO_AUTH_SHA256_HMAC,
+    .auth.key.data = key,
+    .auth.key.length = keyLen,
+    .auth.digest_length = 32,
+  };
+  return rte_cryptodev_sym_session_create(dev, &xform, mp);
+}
@@ -16,8 +16,17 @@ typedef struct CryptoQueuePair {
 } CryptoQueuePair;
 
 /**
- * @brief Reset and prepare a crypto operation for SHA256 digest.
- * @param cqp crypto queue pair where this operation is to be submitted.
+ * @brief Create a symmetric session for HMAC-SHA256 generation.
+ * @param key HMAC secret key; the crypto driver copies it into the session.
+ * @return session, or NULL upon failure.
+ */
+__attribute__((nonnull)) void*
+CryptoDev_CreateHmacSha256Session(uint8_t dev, struct rte_mempool* mp, const uint8_t* key,
+                                  uint16_t keyLen);
+
+/**
+ * @brief Reset and prepare a crypto operation for authentication.
+ * @param sess symmetric session that generates a 32-octet digest.
  * @param[inout] op crypto operation, must have room for rte_crypto_sym_op.
  * @param m input mbuf.
  * @param offset offset within input mbuf.
@@ -25,15 +34,30 @@ typedef struct CryptoQueuePair {
  * @param output output buffer, must have 32 octets.
  */
 __attribute__((nonnull)) static inline void
-CryptoQueuePair_PrepareSha256(CryptoQueuePair* cqp, struct rte_crypto_op* op, struct rte_mbuf* m,
-                              uint32_t offset, uint32_t length, uint8_t* output) {
+CryptoOp_PrepareAuth(void* sess, struct rte_crypto_op* op, struct rte_mbuf* m, uint32_t offset,
+                     uint32_t length, uint8_t* output) {
   __rte_crypto_op_reset(op, RTE_CRYPTO_OP_TYPE_SYMMETRIC);
   op->sym->m_src = m;
   op->sym->auth.data.offset = offset;
   op->sym->auth.data.length = length;
   op->sym->auth.digest.data = output;
-  int res = rte_crypto_op_attach_sym_session(op, cqp->sha256sess);
+  int res = rte_crypto_op_attach_sym_session(op, sess);
   NDNDPDK_ASSERT(res == 0);
 }
 
+/**
+ * @brief Reset and prepare a crypto operation for SHA256 digest.
+ * @param cqp crypto queue pair where this operation is to be submitted.
+ * @param[inout] op crypto operation, must have room for rte_crypto_sym_op.
+ * @param m input mbuf.
+ * @param offset offset within input mbuf.
+ * @param length length of input.
+ * @param output output buffer, must have 32 octets.
+ */
+__attribute__((nonnull)) static inline void
+CryptoQueuePair_PrepareSha256(CryptoQueuePair* cqp, struct rte_crypto_op* op, struct rte_mbuf* m,
+                              uint32_t offset, uint32_t length, uint8_t* output) {
+  CryptoOp_PrepareAuth(cqp->sha256sess, op, m, offset, length, output);
+}
+
 #endif // NDNDPDK_DPDK_CRYPTODEV_H
@@ -134,4 +134,10 @@ EthFace_TxBurst(Face* face, struct rte_mbuf** pkts, uint16_t nPkts) {
   return rte_eth_tx_burst(priv->port, 0, pkts, nPkts);
 }
 
+int
+EthFace_TxQueueCount(Face* face) {
+  EthFacePriv* priv = Face_GetPriv(face);
+  return rte_eth_tx_queue_count(priv->port, 0);
+}
+
 STATIC_ASSERT_FUNC_TYPE(Face_TxBurstFunc, EthFace_TxBurst);
@@ -42,4 +42,7 @@ EthFace_SetupRxMemif(EthFacePriv* priv, const EthLocator* loc);
 __attribute__((nonnull)) uint16_t
 EthFace_TxBurst(Face* face, struct rte_mbuf** pkts, uint16_t nPkts);
 
+__attribute__((nonnull)) int
+EthFace_TxQueueCount(Face* face);
+
 #endif // NDNDPDK_ETHFACE_FACE_H
@@ -1,4 +1,5 @@
 #include "rxtable.h"
+#include "../ndni/an.h"
 #include "face.h"
 
 void
@@ -25,10 +26,41 @@ EthRxTable_Accept(EthRxTable* rxt, struct rte_mbuf* m) {
   return false;
 }
 
+/**
+ * @brief Determine whether an unmatched frame may trigger on-demand face creation.
+ *
+ * This accepts unicast NDN, IPv4, and IPv6 frames, with optional VLAN header.
+ * Further checks are performed in Go.
+ */
+__attribute__((nonnull)) static inline bool
+EthRxTable_IsOnDemandCandidate(const struct rte_mbuf* m) {
+  const struct rte_ether_hdr* eth = rte_pktmbuf_mtod(m, const struct rte_ether_hdr*);
+  if (unlikely(m->data_len < sizeof(*eth) + sizeof(struct rte_vlan_hdr)) ||
+      !rte_is_unicast_ether_addr(&eth->dst_addr)) {
+    return false;
+  }
+
+  rte_be16_t etherType = eth->ether_type;
+  if (etherType == rte_cpu_to_be_16(RTE_ETHER_TYPE_VLAN)) {
+    const struct rte_vlan_hdr* vlan = RTE_PTR_ADD(eth, sizeof(*eth));
+    etherType = vlan->eth_proto;
+  }
+  return etherType == rte_cpu_to_be_16(EtherTypeNDN) ||
+         etherType == rte_cpu_to_be_16(RTE_ETHER_TYPE_IPV4) ||
+         etherType == rte_cpu_to_be_16(RTE_ETHER_TYPE_IPV6);
+}
+
 void
 EthRxTable_RxBurst(RxGroup* rxg, RxGroupBurstCtx* ctx) {
   EthRxTable* rxt = container_of(rxg, EthRxTable, base);
-  ctx->nRx = rte_eth_rx_burst(rxt->port, rxt->queue, ctx->pkts, RTE_DIM(ctx->pkts));
+  // frames that triggered on-demand face creation are received again, to be matched to new faces
+  uint16_t nReinject = 0;
+  if (rxt->onDemandReinject != NULL) {
+    nReinject = rte_ring_dequeue_burst(rxt->onDemandReinject, (void**)ctx->pkts,
+                                       RTE_DIM(ctx->pkts), NULL);
+  }
+  ctx->nRx = nReinject + rte_eth_rx_burst(rxt->port, rxt->queue, &ctx->pkts[nReinject],
+                                          RTE_DIM(ctx->pkts) - nReinject);
   uint64_t now = rte_get_tsc_cycles();
 
   PdumpEthPortUnmatchedCtx unmatch;
@@ -44,6 +76,9 @@ EthRxTable_RxBurst(RxGroup* rxg, RxGroupBurstCtx* ctx) {
       RxGroupBurstCtx_Drop(ctx, i);
       if (PdumpEthPortUnmatchedCtx_Append(&unmatch, m)) {
         ctx->pkts[i] = NULL;
+      } else if (rxt->onDemandQueue != NULL && EthRxTable_IsOnDemandCandidate(m) &&
+                 rte_ring_enqueue(rxt->onDemandQueue, m) == 0) {
+        ctx->pkts[i] = NULL;
       } else if (rxt->copyTo != NULL) {
         // free bounce bufs locally instead of via RxLoop, because rte_pktmbuf_free_bulk is most
         // efficient when consecutive mbufs are from the same mempool such as the main mempool
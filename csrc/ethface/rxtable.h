@@ -12,6 +12,8 @@ typedef struct EthRxTable {
   RxGroup base;
   struct cds_list_head head;
   struct rte_mempool* copyTo;
+  struct rte_ring* onDemandQueue;    ///< unmatched frames for on-demand face creation
+  struct rte_ring* onDemandReinject; ///< frames that triggered on-demand face creation
   uint16_t port;
   uint16_t queue;
 } EthRxTable;
@@ -0,0 +1,25 @@
+#include "aimd.h"
+
+#define AIMD_IW 2.0
+
+void
+Aimd_Init(Aimd* ca, double beta) {
+  ca->cwnd = AIMD_IW;
+  ca->ssthresh = DBL_MAX;
+  ca->beta = beta;
+}
+
+void
+Aimd_Increase(Aimd* ca) {
+  if (ca->cwnd < ca->ssthresh) { // slow start
+    ca->cwnd += 1.0;
+    return;
+  }
+  ca->cwnd += 1.0 / ca->cwnd; // congestion avoidance
+}
+
+void
+Aimd_Decrease(Aimd* ca) {
+  ca->cwnd = RTE_MAX(ca->cwnd * ca->beta, 1.0);
+  ca->ssthresh = RTE_MAX(ca->cwnd, 2.0);
+}
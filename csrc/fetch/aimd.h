@@ -0,0 +1,42 @@
+#ifndef NDNDPDK_FETCH_AIMD_H
+#define NDNDPDK_FETCH_AIMD_H
+
+/** @file */
+
+#include "../dpdk/tsc.h"
+
+/**
+ * @brief Additive increase multiplicative decrease algorithm with slow start.
+ * @sa https://tools.ietf.org/html/rfc5681
+ */
+typedef struct Aimd {
+  double cwnd;
+  double ssthresh;
+  double beta; ///< window multiplier upon decrease
+} Aimd;
+
+/**
+ * @brief Initialize AIMD.
+ * @param beta window multiplier upon decrease, between 0 and 1.
+ */
+__attribute__((nonnull)) void
+Aimd_Init(Aimd* ca, double beta);
+
+__attribute__((nonnull)) static inline uint32_t
+Aimd_GetCwnd(Aimd* ca) {
+  return RTE_MAX((uint32_t)ca->cwnd, 1);
+}
+
+/** @brief Window increase. */
+__attribute__((nonnull)) void
+Aimd_Increase(Aimd* ca);
+
+/**
+ * @brief Window decrease.
+ *
+ * Caller must ensure this is invoked no more than once per RTT.
+ */
+__attribute__((nonnull)) void
+Aimd_Decrease(Aimd* ca);
+
+#endif // NDNDPDK_FETCH_AIMD_H
@@ -0,0 +1,95 @@
+#include "bbr.h"
+
+#define BBR_IW 2.0
+#define BBR_MIN_CWND 4.0
+#define BBR_HIGH_GAIN 2.885
+#define BBR_FULL_BW_GROWTH 1.25
+#define BBR_FULL_BW_ROUNDS 3
+#define BBR_MINRTT_WINDOW_SECONDS 10
+
+static const double BbrProbeBwGains[BbrCycleLength] = {1.25, 0.75, 1.0, 1.0, 1.0, 1.0, 1.0, 1.0};
+
+void
+Bbr_Init(Bbr* ca) {
+  *ca = (Bbr){
+    .cwnd = BBR_IW,
+    .phase = BbrStartup,
+  };
+}
+
+__attribute__((nonnull)) static void
+Bbr_EndRound(Bbr* ca, TscTime now) {
+  ca->bwSamples[ca->bwIndex] = (double)ca->roundDelivered / (double)(now - ca->roundStart);
+  ca->bwIndex = (ca->bwIndex + 1) % BbrBwWindow;
+  ca->btlBw = 0.0;
+  for (int i = 0; i < BbrBwWindow; ++i) {
+    ca->btlBw = RTE_MAX(ca->btlBw, ca->bwSamples[i]);
+  }
+  ca->roundStart = now;
+  ca->roundDelivered = 0;
+
+  switch (ca->phase) {
+    case BbrStartup:
+      if (ca->btlBw >= ca->fullBw * BBR_FULL_BW_GROWTH) {
+        ca->fullBw = ca->btlBw;
+        ca->fullBwRounds = 0;
+      } else if (++ca->fullBwRounds >= BBR_FULL_BW_ROUNDS) {
+        ca->phase = BbrDrain;
+      }
+      break;
+    case BbrDrain:
+      ca->phase = BbrProbeBw;
+      ca->cycleIndex = 0;
+      break;
+    case BbrProbeBw:
+      ca->cycleIndex = (ca->cycleIndex + 1) % BbrCycleLength;
+      break;
+  }
+}
+
+__attribute__((nonnull)) static inline double
+Bbr_GetGain(Bbr* ca) {
+  switch (ca->phase) {
+    case BbrStartup:
+      return BBR_HIGH_GAIN;
+    case BbrDrain:
+      return 1.0 / BBR_HIGH_GAIN;
+    case BbrProbeBw:
+      return BbrProbeBwGains[ca->cycleIndex];
+  }
+  return 1.0;
+}
+
+void
+Bbr_Increase(Bbr* ca, TscTime now, TscDuration rtt, double sRtt) {
+  if (rtt > 0 && (ca->minRtt == 0 || rtt <= ca->minRtt ||
+                  now - ca->minRttStamp > BBR_MINRTT_WINDOW_SECONDS * TscHz)) {
+    ca->minRtt = rtt;
+    ca->minRttStamp = now;
+  }
+
+  ++ca->roundDelivered;
+  if (ca->roundStart == 0) {
+    ca->roundStart = now;
+  } else if (sRtt > 0 && now - ca->roundStart >= sRtt) {
+    Bbr_EndRound(ca, now);
+  }
+
+  if (ca->btlBw == 0.0 || ca->minRtt == 0) { // no bandwidth estimate yet, use slow start
+    ca->cwnd += 1.0;
+    return;
+  }
+
+  double bdp = ca->btlBw * ca->minRtt;
+  ca->cwnd = RTE_MAX(Bbr_GetGain(ca) * bdp, BBR_MIN_CWND);
+}
+
+void
+Bbr_Decrease(Bbr* ca) {
+  if (ca->phase == BbrStartup) {
+    ca->phase = BbrDrain;
+  }
+
+  double bdp = ca->btlBw * ca->minRtt;
+  ca->cwnd = RTE_MAX(RTE_MIN(ca->cwnd, bdp), BBR_MIN_CWND);
+}
@@ -0,0 +1,70 @@
+#ifndef NDNDPDK_FETCH_BBR_H
+#define NDNDPDK_FETCH_BBR_H
+
+/** @file */
+
+#include "../dpdk/tsc.h"
+
+enum {
+  /** @brief Bottleneck bandwidth filter window, in rounds. */
+  BbrBwWindow = 10,
+  /** @brief Number of ProbeBW gain cycle phases. */
+  BbrCycleLength = 8,
+};
+
+/** @brief BBR state machine phase. */
+typedef enum BbrPhase {
+  BbrStartup,
+  BbrDrain,
+  BbrProbeBw,
+} BbrPhase;
+
+/**
+ * @brief BBR-like rate-based congestion control algorithm.
+ * @sa https://datatracker.ietf.org/doc/html/draft-cardwell-iccrg-bbr-congestion-control
+ *
+ * This is a window-only approximation of BBR: the fetcher has no pacing, so that the congestion
+ * window is set to a gain multiplied by the estimated bandwidth-delay product.
+ * A round is one smoothed RTT, as determined by the fetcher's RTT estimator.
+ * Delivery rate is sampled once per round as the number of Data packets received in the round.
+ */
+typedef struct Bbr {
+  double cwnd;
+  double btlBw;                   ///< bottleneck bandwidth, Data per TSC unit
+  double bwSamples[BbrBwWindow];  ///< per-round delivery rate samples
+  double fullBw;                  ///< btlBw when Startup last saw significant growth
+  TscTime roundStart;             ///< start of current round
+  TscTime minRttStamp;            ///< when minRtt was last updated
+  TscDuration minRtt;             ///< minimum RTT, 0 if unknown
+  uint32_t roundDelivered;        ///< Data received in current round
+  uint8_t bwIndex;                ///< next slot in bwSamples
+  uint8_t fullBwRounds;           ///< rounds without significant growth in Startup
+  uint8_t cycleIndex;             ///< ProbeBW gain cycle phase
+  BbrPhase phase;
+} Bbr;
+
+__attribute__((nonnull)) void
+Bbr_Init(Bbr* ca);
+
+__attribute__((nonnull)) static inline uint32_t
+Bbr_GetCwnd(Bbr* ca) {
+  return RTE_MAX((uint32_t)ca->cwnd, 1);
+}
+
+/**
+ * @brief Notify Data arrival without congestion mark.
+ * @param rtt RTT sample, or 0 if unavailable.
+ * @param sRtt smoothed RTT.
+ */
+__attribute__((nonnull)) void
+Bbr_Increase(Bbr* ca, TscTime now, TscDuration rtt, double sRtt);
+
+/**
+ * @brief Notify congestion mark or timeout.
+ *
+ * Caller must ensure this is invoked no more than once per RTT.
+ */
+__attribute__((nonnull)) void
+Bbr_Decrease(Bbr* ca);
+
+#endif // NDNDPDK_FETCH_BBR_H
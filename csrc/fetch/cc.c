@@ -0,0 +1,44 @@
+#include "cc.h"
+
+void
+FetchCc_Init(FetchCc* cc, const FetchCcConfig* cfg) {
+  cc->algo = cfg->algo;
+  cc->fixedCwnd = RTE_MAX(cfg->fixedCwnd, 1);
+  TcpCubic_Init(&cc->cubic);
+  Aimd_Init(&cc->aimd, cfg->aimdBeta);
+  Bbr_Init(&cc->bbr);
+}
+
+void
+FetchCc_Increase(FetchCc* cc, TscTime now, const RttEst* rtte) {
+  switch (cc->algo) {
+    case FetchCcCubic:
+      TcpCubic_Increase(&cc->cubic, now, rtte->rttv.sRtt);
+      break;
+    case FetchCcAimd:
+      Aimd_Increase(&cc->aimd);
+      break;
+    case FetchCcBbr:
+      Bbr_Increase(&cc->bbr, now, rtte->last, rtte->rttv.sRtt);
+      break;
+    case FetchCcFixed:
+      break;
+  }
+}
+
+void
+FetchCc_Decrease(FetchCc* cc, TscTime now) {
+  switch (cc->algo) {
+    case FetchCcCubic:
+      TcpCubic_Decrease(&cc->cubic, now);
+      break;
+    case FetchCcAimd:
+      Aimd_Decrease(&cc->aimd);
+      break;
+    case FetchCcBbr:
+      Bbr_Decrease(&cc->bbr);
+      break;
+    case FetchCcFixed:
+      break;
+  }
+}
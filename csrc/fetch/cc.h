@@ -0,0 +1,69 @@
+#ifndef NDNDPDK_FETCH_CC_H
+#define NDNDPDK_FETCH_CC_H
+
+/** @file */
+
+#include "../core/rttest.h"
+#include "aimd.h"
+#include "bbr.h"
+#include "tcpcubic.h"
+
+/** @brief Congestion control algorithm. */
+typedef enum FetchCcAlgo {
+  FetchCcCubic,
+  FetchCcAimd,
+  FetchCcBbr,
+  FetchCcFixed,
+} FetchCcAlgo;
+
+/** @brief Congestion control configuration. */
+typedef struct FetchCcConfig {
+  FetchCcAlgo algo;
+  double aimdBeta;    ///< AIMD window multiplier upon decrease
+  uint32_t fixedCwnd; ///< fixed congestion window
+} FetchCcConfig;
+
+/**
+ * @brief Congestion control algorithm selector.
+ *
+ * Only the state of the selected algorithm is meaningful.
+ */
+typedef struct FetchCc {
+  FetchCcAlgo algo;
+  uint32_t fixedCwnd;
+  TcpCubic cubic;
+  Aimd aimd;
+  Bbr bbr;
+} FetchCc;
+
+__attribute__((nonnull)) void
+FetchCc_Init(FetchCc* cc, const FetchCcConfig* cfg);
+
+__attribute__((nonnull)) static inline uint32_t
+FetchCc_GetCwnd(FetchCc* cc) {
+  switch (cc->algo) {
+    case FetchCcCubic:
+      return TcpCubic_GetCwnd(&cc->cubic);
+    case FetchCcAimd:
+      return Aimd_GetCwnd(&cc->aimd);
+    case FetchCcBbr:
+      return Bbr_GetCwnd(&cc->bbr);
+    case FetchCcFixed:
+      return cc->fixedCwnd;
+  }
+  return 1;
+}
+
+/** @brief Notify Data arrival without congestion mark. */
+__attribute__((nonnull)) void
+FetchCc_Increase(FetchCc* cc, TscTime now, const RttEst* rtte);
+
+/**
+ * @brief Notify congestion mark or timeout.
+ *
+ * Caller must ensure this is invoked no more than once per RTT.
+ */
+__attribute__((nonnull)) void
+FetchCc_Decrease(FetchCc* cc, TscTime now);
+
+#endif // NDNDPDK_FETCH_CC_H
@@ -3,10 +3,13 @@
 #include "../core/logger.h"
 #include "../ndni/tlv-decoder.h"
 
+#include <sys/eventfd.h>
+
 N_LOG_INIT(FetchTask);
 
 __attribute__((nonnull)) static inline void
-FetchTask_EncodeInterest(FetchTask* fp, FetchThread* fth, struct rte_mbuf* pkt, uint64_t segNum) {
+FetchTask_EncodeInterest(FetchThread* fth, const InterestTemplate* tpl, struct rte_mbuf* pkt,
+                         uint64_t segNum, uint8_t tokenLength, const uint8_t* token) {
   uint8_t suffix[10];
   LName nameSuffix = {
     .length = Nni_EncodeNameComponent(suffix, TtSegmentNameComponent, segNum),
@@ -14,8 +17,28 @@ FetchTask_EncodeInterest(FetchTask* fp, FetchThread* fth, struct rte_mbuf* pkt,
   };
 
   uint32_t nonce = pcg32_random_r(&fth->nonceRng);
-  Packet* npkt = InterestTemplate_Encode(&fp->tpl, pkt, nameSuffix, nonce);
-  LpPitToken_Set(&Packet_GetLpL3Hdr(npkt)->pitToken, sizeof(fp->index), &fp->index);
+  Packet* npkt = InterestTemplate_Encode(tpl, pkt, nameSuffix, nonce);
+  LpPitToken_Set(&Packet_GetLpL3Hdr(npkt)->pitToken, tokenLength, token);
+}
+
+/**
+ * @brief Encode Interest for a logical segment number of a multi-file fetch task.
+ *
+ * PIT token contains task slot index and file record index.
+ */
+__attribute__((nonnull)) static inline void
+FetchTask_EncodeFileInterest(FetchTask* fp, FetchThread* fth, struct rte_mbuf* pkt,
+                             uint64_t segNum) {
+  FetchFiles* ff = fp->files;
+  for (uint32_t i = ff->nDone; i != ff->nSeen; ++i) {
+    const FetchFile* file = &ff->file[i % FetchMaxFiles];
+    if (segNum < file->segEnd) {
+      uint8_t token[2] = {fp->index, i % FetchMaxFiles};
+      FetchTask_EncodeInterest(fth, &file->tpl, pkt, segNum - file->segBegin, sizeof(token), token);
+      return;
+    }
+  }
+  NDNDPDK_ASSERT(false);
 }
 
 __attribute__((nonnull)) static inline uint32_t
@@ -35,46 +58,86 @@ FetchTask_TxBurst(FetchTask* fp, FetchThread* fth) {
   }
 
   for (size_t i = 0; i < count; ++i) {
-    FetchTask_EncodeInterest(fp, fth, pkts[i], segNums[i]);
+    if (fp->files == NULL) {
+      FetchTask_EncodeInterest(fth, &fp->tpl, pkts[i], segNums[i], sizeof(fp->index), &fp->index);
+    } else {
+      FetchTask_EncodeFileInterest(fp, fth, pkts[i], segNums[i]);
+    }
   }
   Face_TxBurst(fth->face, (Packet**)pkts, count);
   return count;
 }
 
 __attribute__((nonnull)) static inline bool
-FetchTask_DecodeData(FetchTask* fp, Packet* npkt, FetchLogicRxData* lpkt) {
+FetchTask_DecodeData(const InterestTemplate* tpl, Packet* npkt, FetchLogicRxData* lpkt) {
   LpL3* lpl3 = Packet_GetLpL3Hdr(npkt);
   lpkt->congMark = lpl3->congMark;
 
   const PData* data = Packet_GetDataHdr(npkt);
   lpkt->isFinalBlock = data->isFinalBlock;
 
-  const uint8_t* seqNumComp = RTE_PTR_ADD(data->name.value, fp->tpl.prefixL);
-  return data->name.length > fp->tpl.prefixL + 1 &&
+  const uint8_t* seqNumComp = RTE_PTR_ADD(data->name.value, tpl->prefixL);
+  return data->name.length > tpl->prefixL + 1 &&
          // this memcmp checks for SegmentNameComponent TLV-TYPE also
-         memcmp(data->name.value, fp->tpl.prefixV, fp->tpl.prefixL + 1) == 0 &&
+         memcmp(data->name.value, tpl->prefixV, tpl->prefixL + 1) == 0 &&
          Nni_Decode(seqNumComp[1], RTE_PTR_ADD(seqNumComp, 2), &lpkt->segNum);
 }
 
+/**
+ * @brief Decode Data of a multi-file fetch task.
+ * @param[out] lpkt Data fields, with logical segment number.
+ * @return file record, or NULL if Data does not belong to a file being fetched.
+ */
+__attribute__((nonnull)) static inline const FetchFile*
+FetchTask_DecodeFileData(FetchTask* fp, Packet* npkt, FetchLogicRxData* lpkt) {
+  FetchFiles* ff = fp->files;
+  const LpPitToken* token = &Packet_GetLpL3Hdr(npkt)->pitToken;
+  if (unlikely(token->length != 2)) {
+    return NULL;
+  }
+
+  // file record must be in use, otherwise the control thread may be writing to it
+  uint32_t i = ff->nDone + ((token->value[1] - ff->nDone) % FetchMaxFiles);
+  if (unlikely(i - ff->nDone >= ff->nSeen - ff->nDone)) {
+    return NULL;
+  }
+  const FetchFile* file = &ff->file[i % FetchMaxFiles];
+
+  if (unlikely(!FetchTask_DecodeData(&file->tpl, npkt, lpkt) ||
+               lpkt->segNum >= file->segEnd - file->segBegin)) {
+    return NULL;
+  }
+  lpkt->segNum += file->segBegin;
+  // file boundaries are determined by the control thread
+  lpkt->isFinalBlock = false;
+
+  // below loSegNum, the file may have been closed by the control thread
+  if (unlikely(lpkt->segNum < fp->logic.win.loSegNum)) {
+    return NULL;
+  }
+  return file;
+}
+
 __attribute__((nonnull)) static inline bool
-FetchTask_WriteData(FetchThread* fth, FetchTask* fp, Packet* npkt, FetchLogicRxData* lpkt) {
+FetchTask_WriteData(FetchThread* fth, FetchTask* fp, Packet* npkt, int fd, uint64_t segNum,
+                    uint32_t segmentLen) {
   const PData* data = Packet_GetDataHdr(npkt);
   struct rte_mbuf* pkt = Packet_ToMbuf(npkt);
   struct iovec* iov = (struct iovec*)data->helperScratch;
   if (unlikely(pkt->nb_segs > sizeof(data->helperScratch) / sizeof(iov[0]))) {
-    N_LOGW("%p WriteData seg=%" PRIu64 " frags=%" PRIu16 N_LOG_ERROR_STR, fp, lpkt->segNum,
-           pkt->nb_segs, "too-many-frags");
+    N_LOGW("%p WriteData seg=%" PRIu64 " frags=%" PRIu16 N_LOG_ERROR_STR, fp, segNum, pkt->nb_segs,
+           "too-many-frags");
     return false;
   }
 
   struct io_uring_sqe* sqe = Uring_GetSqe(&fth->ur);
   if (unlikely(sqe == NULL)) {
-    N_LOGW("%p WriteData seg=%" PRIu64 N_LOG_ERROR_STR, fp, lpkt->segNum, "no-SQE");
+    N_LOGW("%p WriteData seg=%" PRIu64 N_LOG_ERROR_STR, fp, segNum, "no-SQE");
     return false;
   }
 
   int iovcnt = Mbuf_AsIovec(pkt, iov, data->contentOffset, data->contentL);
-  io_uring_prep_writev(sqe, fp->fd, iov, iovcnt, lpkt->segNum * fp->segmentLen);
+  io_uring_prep_writev(sqe, fd, iov, iovcnt, segNum * segmentLen);
   io_uring_sqe_set_data(sqe, pkt);
   return true;
 }
@@ -92,9 +155,9 @@ FetchTask_RxBurst(FetchThread* fth, FetchTask* fp, bool wantWrite) {
   for (uint16_t i = 0; i < nRx; ++i) {
     Packet* npkt = npkts[i];
     FetchLogicRxData* lpkt = &lpkts[count];
-    bool ok = FetchTask_DecodeData(fp, npkt, lpkt);
+    bool ok = FetchTask_DecodeData(&fp->tpl, npkt, lpkt);
     if (wantWrite && likely(ok)) {
-      ok = FetchTask_WriteData(fth, fp, npkt, lpkt);
+      ok = FetchTask_WriteData(fth, fp, npkt, fp->fd, lpkt->segNum, fp->segmentLen);
     }
 
     if (unlikely(!ok)) {
@@ -131,6 +194,92 @@ const FetchTask_RxBurstFunc FetchTask_RxBurstJmp[] = {
   [true] = FetchTask_RxBurst_Write,
 };
 
+__attribute__((nonnull)) static uint32_t
+FetchTask_RxBurst_Files(FetchThread* fth, FetchTask* fp) {
+  TscTime now = rte_get_tsc_cycles();
+  Packet* npkts[MaxBurstSize];
+  uint32_t nRx = PktQueue_Pop(&fp->queueD, (struct rte_mbuf**)npkts, MaxBurstSize, now).count;
+  Packet* discards[MaxBurstSize];
+  uint32_t nDiscards = 0;
+
+  FetchLogicRxData lpkts[MaxBurstSize];
+  uint32_t count = 0;
+  for (uint16_t i = 0; i < nRx; ++i) {
+    Packet* npkt = npkts[i];
+    FetchLogicRxData* lpkt = &lpkts[count];
+    const FetchFile* file = FetchTask_DecodeFileData(fp, npkt, lpkt);
+    if (unlikely(file == NULL ||
+                 !FetchTask_WriteData(fth, fp, npkt, file->fd, lpkt->segNum - file->segBegin,
+                                      file->segmentLen))) {
+      discards[nDiscards++] = npkt;
+      continue;
+    }
+    ++count;
+  }
+  FetchLogic_RxDataBurst(&fp->logic, lpkts, count, now);
+
+  if (unlikely(nDiscards > 0)) {
+    rte_pktmbuf_free_bulk((struct rte_mbuf**)discards, nDiscards);
+  }
+  return nRx;
+}
+
+__attribute__((nonnull)) static inline void
+FetchFiles_Notify(FetchFiles* ff) {
+  int res = eventfd_write(ff->eventFd, 1);
+  if (unlikely(res != 0)) {
+    N_LOGW("%p eventfd_write" N_LOG_ERROR_ERRNO, ff, errno);
+  }
+}
+
+/**
+ * @brief Process a multi-file fetch task.
+ *
+ * Before the first file is added, the control thread owns queueD, and this function only signals
+ * the control thread when it is waiting for packets in queueD.
+ */
+__attribute__((nonnull)) static inline uint32_t
+FetchTask_RunFiles(FetchTask* fp, FetchThread* fth) {
+  FetchFiles* ff = fp->files;
+  uint32_t nAdded = __atomic_load_n(&ff->nAdded, __ATOMIC_ACQUIRE);
+  if (nAdded == 0) {
+    if (__atomic_load_n(&ff->rxArmed, __ATOMIC_ACQUIRE) && rte_ring_count(fp->queueD.ring) > 0) {
+      __atomic_store_n(&ff->rxArmed, false, __ATOMIC_RELAXED);
+      FetchFiles_Notify(ff);
+    }
+    return 0;
+  }
+
+  if (nAdded != ff->nSeen) {
+    ff->nSeen = nAdded;
+    fp->logic.segmentEnd = ff->file[(nAdded - 1) % FetchMaxFiles].segEnd;
+    fp->logic.finishTime = 0;
+  }
+
+  MinSched_Trigger(fp->logic.sched);
+  return FetchTask_TxBurst(fp, fth) + FetchTask_RxBurst_Files(fth, fp);
+}
+
+/**
+ * @brief Signal completed files of a multi-file fetch task.
+ *
+ * This should be invoked after submitting writes, so that the control thread can close a
+ * completed file.
+ */
+__attribute__((nonnull)) static inline void
+FetchTask_FinishFiles(FetchTask* fp) {
+  FetchFiles* ff = fp->files;
+  uint32_t nDone = ff->nDone;
+  while (nDone != ff->nSeen && fp->logic.win.loSegNum >= ff->file[nDone % FetchMaxFiles].segEnd) {
+    ++nDone;
+  }
+  if (nDone == ff->nDone) {
+    return;
+  }
+  __atomic_store_n(&ff->nDone, nDone, __ATOMIC_RELEASE);
+  FetchFiles_Notify(ff);
+}
+
 __attribute__((nonnull)) static inline uint32_t
 FetchThread_CqBurst(FetchThread* fth) {
   struct io_uring_cqe* cqes[MaxBurstSize];
@@ -166,7 +315,13 @@ FetchThread_Run(FetchThread* fth) {
     rcu_read_lock();
     FetchTask* fp;
     struct cds_hlist_node* pos;
+    bool hasFiles = false;
     cds_hlist_for_each_entry_rcu (fp, pos, &fth->tasksHead, fthNode) {
+      if (fp->files != NULL) {
+        hasFiles = true;
+        nProcessed += FetchTask_RunFiles(fp, fth);
+        continue;
+      }
       MinSched_Trigger(fp->logic.sched);
       nProcessed += FetchTask_TxBurst(fp, fth);
       nProcessed += FetchTask_RxBurstJmp[fp->fd >= 0](fth, fp);
@@ -174,6 +329,13 @@ FetchThread_Run(FetchThread* fth) {
 
     Uring_Submit(&fth->ur, fth->uringWaitLbound, MaxBurstSize);
     nProcessed += FetchThread_CqBurst(fth);
+    if (hasFiles && fth->ur.nQueued == 0) {
+      cds_hlist_for_each_entry_rcu (fp, pos, &fth->tasksHead, fthNode) {
+        if (fp->files != NULL) {
+          FetchTask_FinishFiles(fp);
+        }
+      }
+    }
     rcu_read_unlock();
   }
 
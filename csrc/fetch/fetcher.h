@@ -9,11 +9,75 @@
 #include "../iface/pktqueue.h"
 #include "logic.h"
 
+enum {
+  FetchMaxFiles = 16, ///< maximum number of concurrently fetched files in a FetchFiles
+};
+
+/**
+ * @brief File in a multi-file fetch task.
+ *
+ * Each file occupies a range of logical segment numbers in FetchLogic, so that segments of
+ * several files are scheduled against one congestion window.
+ */
+typedef struct FetchFile {
+  uint64_t segBegin;   ///< first logical segment number
+  uint64_t segEnd;     ///< last logical segment number plus one
+  uint32_t segmentLen; ///< expected segment length
+  int fd;              ///< output file descriptor
+
+  /**
+   * @brief Name prefix and Interest template.
+   *
+   * prefixV[prefixL]==TtSegmentNameComponent
+   */
+  InterestTemplate tpl;
+} FetchFile;
+
+/**
+ * @brief Files of a multi-file fetch task.
+ *
+ * File number @c i is stored in @c file[i%FetchMaxFiles] .
+ * The control thread fills a file record and then increments @c nAdded .
+ * The worker increments @c nDone when the window has advanced past a file, after all writes to
+ * that file have been submitted, and then signals @c eventFd .
+ * The control thread may then close the file and reuse its record.
+ *
+ * Before the first file is added, the control thread owns FetchTask.queueD.
+ * The worker signals @c eventFd when @c rxArmed is set and queueD is not empty.
+ */
+typedef struct FetchFiles {
+  uint32_t nAdded; ///< number of added files, written by control thread
+  uint32_t nDone;  ///< number of completed files, written by worker
+  uint32_t nSeen;  ///< nAdded as last seen by worker
+  int eventFd;     ///< eventfd for notifying control thread
+  bool rxArmed;    ///< whether control thread is waiting for queueD
+  FetchFile file[FetchMaxFiles];
+} FetchFiles;
+
+/** @brief Publish files added by the control thread. */
+__attribute__((nonnull)) static inline void
+FetchFiles_SetAdded(FetchFiles* ff, uint32_t nAdded) {
+  __atomic_store_n(&ff->nAdded, nAdded, __ATOMIC_RELEASE);
+}
+
+/** @brief Retrieve number of completed files. */
+__attribute__((nonnull)) static inline uint32_t
+FetchFiles_GetDone(FetchFiles* ff) {
+  return __atomic_load_n(&ff->nDone, __ATOMIC_ACQUIRE);
+}
+
+/** @brief Request a signal when queueD is not empty. */
+__attribute__((nonnull)) static inline void
+FetchFiles_ArmRx(FetchFiles* ff) {
+  __atomic_store_n(&ff->rxArmed, true, __ATOMIC_RELEASE);
+}
+
 /** @brief Fetch task that fetches from one prefix. */
 typedef struct FetchTask {
   struct cds_hlist_node fthNode; ///< FetchThread.head node
   PktQueue queueD;
   FetchLogic logic;
+  FetchFiles* files; ///< if not NULL, fetch multiple files instead of using tpl and fd
   uint32_t segmentLen; ///< expected segment length, used for writing to file
   int fd;              ///< if non-negative, write content to file
   uint8_t index;       ///< task slot index, used as PIT token
@@ -38,7 +38,7 @@ FetchLogic_TxInterest(FetchLogic* fl, int* nNew, int* nRetx) {
 
 size_t
 FetchLogic_TxInterestBurst(FetchLogic* fl, uint64_t* segNums, size_t limit, TscTime now) {
-  uint32_t cwnd = TcpCubic_GetCwnd(&fl->ca);
+  uint32_t cwnd = FetchCc_GetCwnd(&fl->cc);
   size_t count = 0;
   int nNew = 0, nRetx = 0;
 
@@ -71,13 +71,13 @@ FetchLogic_DecreaseCwnd(FetchLogic* fl, const char* caller, uint64_t segNum, Tsc
   if (unlikely(now < fl->nextCwndDec)) {
     return false;
   }
-  TcpCubic_Decrease(&fl->ca, now);
+  FetchCc_Decrease(&fl->cc, now);
   fl->nextCwndDec = now + fl->rtte.rto;
 
   N_LOGD("%s fl=%p seg=%" PRIu64 " win=[%" PRIu64 ",%" PRIu64 ") rto=%" PRId64 " cwnd=%" PRIu32
          " nInFlight=%" PRIu32 "",
          caller, fl, segNum, fl->win.loSegNum, fl->win.hiSegNum, TscDuration_ToMillis(fl->rtte.rto),
-         TcpCubic_GetCwnd(&fl->ca), fl->nInFlight);
+         FetchCc_GetCwnd(&fl->cc), fl->nInFlight);
   return true;
 }
 
@@ -107,7 +107,7 @@ FetchLogic_RxData(FetchLogic* fl, TscTime now, uint64_t segNum, bool hasCongMark
   if (unlikely(hasCongMark)) {
     FetchLogic_DecreaseCwnd(fl, "RxDataCongMark", segNum, now);
   } else {
-    TcpCubic_Increase(&fl->ca, now, fl->rtte.rttv.sRtt);
+    FetchCc_Increase(&fl->cc, now, &fl->rtte);
   }
 
   if (unlikely(isFinalBlock)) {
@@ -155,7 +155,7 @@ FetchLogic_Init(FetchLogic* fl, uint32_t winCapacity, int numaSocket) {
   fl->sched = MinSched_New(16, TscHz / 1000, FetchLogic_RtoTimeout, (uintptr_t)fl);
   NDNDPDK_ASSERT(MinSched_GetMaxDelay(fl->sched) >= RttEstTscMaxRto);
 
-  FetchLogic_Reset(fl, 0, UINT64_MAX);
+  FetchLogic_Reset(fl, 0, UINT64_MAX, &(const FetchCcConfig){.algo = FetchCcCubic});
 }
 
 void
@@ -165,10 +165,11 @@ FetchLogic_Free(FetchLogic* fl) {
 }
 
 void
-FetchLogic_Reset(FetchLogic* fl, uint64_t segmentBegin, uint64_t segmentEnd) {
+FetchLogic_Reset(FetchLogic* fl, uint64_t segmentBegin, uint64_t segmentEnd,
+                 const FetchCcConfig* cc) {
   FetchWindow_Reset(&fl->win, segmentBegin);
   RttEst_Init(&fl->rtte);
-  TcpCubic_Init(&fl->ca);
+  FetchCc_Init(&fl->cc, cc);
   MinSched_Clear(fl->sched);
 
   CDS_INIT_LIST_HEAD(&fl->retxQ);
@@ -4,7 +4,7 @@
 /** @file */
 
 #include "../core/rttest.h"
-#include "tcpcubic.h"
+#include "cc.h"
 #include "window.h"
 
 typedef TAILQ_HEAD(FetchRetxQueue, FetchSeg) FetchRetxQueue;
@@ -13,7 +13,7 @@ typedef TAILQ_HEAD(FetchRetxQueue, FetchSeg) FetchRetxQueue;
 typedef struct FetchLogic {
   FetchWindow win;
   RttEst rtte;
-  TcpCubic ca;
+  FetchCc cc;
   struct cds_list_head retxQ;
   MinSched* sched;
   uint64_t segmentEnd; ///< last segnum desired plus one
@@ -32,7 +32,8 @@ __attribute__((nonnull)) void
 FetchLogic_Free(FetchLogic* fl);
 
 __attribute__((nonnull)) void
-FetchLogic_Reset(FetchLogic* fl, uint64_t segmentBegin, uint64_t segmentEnd);
+FetchLogic_Reset(FetchLogic* fl, uint64_t segmentBegin, uint64_t segmentEnd,
+                 const FetchCcConfig* cc);
 
 /**
  * @brief Request to transmit a burst of Interests.
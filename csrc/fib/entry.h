@@ -6,6 +6,7 @@
 #include "../core/rttest.h"
 #include "../core/urcu.h"
 #include "../iface/faceid.h"
+#include "../pcct/pit-suppress-config.h"
 #include "../strategycode/strategy-code.h"
 #include "enum.h"
 #include <urcu/rculfhash.h>
@@ -60,7 +61,15 @@ struct FibEntry {
 
   FaceID nexthops[FibMaxNexthops];
 
-  char b_[16];
+  /**
+   * @brief PIT suppression configuration.
+   * @pre height == 0
+   *
+   * If all fields are zero, the forwarding thread's configuration applies.
+   */
+  PitSuppressConfigCompact suppress;
+
+  char b_[4];
   struct rcu_head rcuhead;
   RTE_MARKER cachelineB_;
   FibEntryDyn dyn[];
@@ -1,6 +1,8 @@
 #include "crypto.h"
 
 #include "../core/logger.h"
+#include "../core/urcu.h"
+#include "../hrlog/entry.h"
 
 N_LOG_INIT(FwCrypto);
 
@@ -15,10 +17,17 @@ FwCrypto_Input(FwCrypto* fwc) {
   }
 
   struct rte_crypto_op* ops[FW_CRYPTO_BURST_SIZE];
+  HrlogEntry hrl[FW_CRYPTO_BURST_SIZE];
+  struct rte_ring* hrlRing = HrlogRing_Get();
+  TscTime now = rte_get_tsc_cycles();
   for (uint16_t i = 0; i < nDeq; ++i) {
     Packet* npkt = npkts[i];
+    hrl[i] = HrlogEntry_New(HRLOG_CQ, now - Mbuf_GetTimestamp(Packet_ToMbuf(npkt)));
     ops[i] = DataDigest_Prepare(&fwc->cqp, npkt);
   }
+  if (hrlRing != NULL) {
+    HrlogRing_Post(hrlRing, hrl, nDeq);
+  }
 
   uint16_t nRej = DataDigest_Enqueue(&fwc->cqp, ops, nDeq);
   if (unlikely(nRej > 0)) {
@@ -64,9 +73,12 @@ void
 FwCrypto_Run(FwCrypto* fwc) {
   N_LOGI("Run fwc=%p input=%p cryptodev=%" PRIu8 "-%" PRIu16, fwc, fwc->input, fwc->cqp.dev,
          fwc->cqp.qp);
+  rcu_register_thread();
   uint16_t nProcessed = 0;
   while (ThreadCtrl_Continue(fwc->ctrl, nProcessed)) {
+    rcu_quiescent_state();
     nProcessed += FwCrypto_Output(fwc, fwc->cqp);
     nProcessed += FwCrypto_Input(fwc);
   }
+  rcu_unregister_thread();
 }
@@ -30,15 +30,19 @@ FwFwd_DataNeedDigest(FwFwd* fwd, FwFwdCtx* ctx) {
 
 __attribute__((nonnull)) static void
 FwFwd_DataSeekFib(FwFwd* fwd, FwFwdCtx* ctx) {
+  PitUp* up = PitEntry_FindUp(ctx->pitEntry, ctx->rxFace);
+  bool hasRtt = likely(up != NULL) && likely(up->nTx == 1);
+  if (likely(hasRtt)) {
+    RttValue_Push(&FwFwd_GetFaceStat(fwd, ctx->rxFace)->rtt, ctx->rxTime - up->lastTx);
+  }
+
   FwFwdCtx_SetFibEntry(ctx, PitEntry_FindFibEntry(ctx->pitEntry, fwd->fib));
   if (unlikely(ctx->fibEntryDyn == NULL)) {
     return;
   }
 
   ++ctx->fibEntryDyn->nRxData;
-  PitUp* up = PitEntry_FindUp(ctx->pitEntry, ctx->rxFace);
-  if (likely(up != NULL) && likely(up->nTx == 1) &&
-      likely(up->nexthopIndex < ctx->fibEntry->nNexthops) &&
+  if (likely(hasRtt) && likely(up->nexthopIndex < ctx->fibEntry->nNexthops) &&
       likely(ctx->fibEntry->nexthops[up->nexthopIndex] == ctx->rxFace)) {
     RttValue_Push(&ctx->fibEntryDyn->rtt[up->nexthopIndex], ctx->rxTime - up->lastTx);
   }
@@ -98,6 +102,12 @@ FwFwd_RxData(FwFwd* fwd, FwFwdCtx* ctx) {
     return;
   }
 
+  if (unlikely(Packet_GetLpL3Hdr(ctx->npkt)->congMark != 0)) {
+    FwFaceStat* faceStat = FwFwd_GetFaceStat(fwd, ctx->rxFace);
+    faceStat->lastCongMark = ctx->rxTime;
+    ++faceStat->nCongMarks;
+  }
+
   PitFindResult pitFound = Pit_FindByData(fwd->pit, ctx->npkt, FwToken_GetPccToken(&ctx->rxToken));
   if (PitFindResult_Is(pitFound, PIT_FIND_NONE)) {
     FwFwd_DataUnsolicited(fwd, ctx);
@@ -128,6 +138,8 @@ FwFwd_RxData(FwFwd* fwd, FwFwdCtx* ctx) {
   NULLize(ctx->fibEntryDyn);
   rcu_read_unlock();
 
+  // CS does not retain congestion mark; a cache hit carries the congestion mark of the Interest
+  Packet_GetLpL3Hdr(ctx->npkt)->congMark = 0;
   Cs_Insert(fwd->cs, ctx->npkt, pitFound);
   NULLize(ctx->npkt);     // npkt is owned by CS
   NULLize(ctx->pitEntry); // pitEntry is replaced by csEntry
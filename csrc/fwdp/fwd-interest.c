@@ -4,6 +4,7 @@
 
 #include "../core/logger.h"
 #include "../disk/store.h"
+#include "../hrlog/entry.h"
 
 N_LOG_INIT(FwFwd);
 
@@ -77,6 +78,7 @@ FwFwd_InterestForward(FwFwd* fwd, FwFwdCtx* ctx) {
     return;
   }
   NULLize(ctx->npkt); // npkt is owned and possibly freed by pitEntry
+  ctx->dnCongMark = dn->congMark;
   N_LOGD("^ pit-entry=%p(%s)", ctx->pitEntry, PitEntry_ToDebugString(ctx->pitEntry));
 
   uint64_t res = SgInvoke(ctx->fibEntry->strategy, ctx);
@@ -87,14 +89,18 @@ FwFwd_InterestForward(FwFwd* fwd, FwFwdCtx* ctx) {
   }
 }
 
+/**
+ * @brief Reply Data from in-memory CS entry.
+ * @param fromDisk whether the CS entry has just been loaded from disk.
+ */
 __attribute__((nonnull)) static void
-FwFwd_InterestHitCsMemory(FwFwd* fwd, FwFwdCtx* ctx, CsEntry* csEntry) {
+FwFwd_InterestHitCsMemory(FwFwd* fwd, FwFwdCtx* ctx, CsEntry* csEntry, bool fromDisk) {
   Packet* outNpkt = Packet_Clone(csEntry->data, &fwd->mp, Face_PacketTxAlign(ctx->rxFace));
   N_LOGD("^ cs-entry-memory=%p data-to=%" PRI_FaceID " npkt=%p dn-token=%s", csEntry, ctx->rxFace,
          outNpkt, LpPitToken_ToString(&ctx->rxToken));
   if (likely(outNpkt != NULL)) {
     struct rte_mbuf* outPkt = Packet_ToMbuf(outNpkt);
-    outPkt->port = RTE_MBUF_PORT_INVALID;
+    outPkt->port = fromDisk ? HRLOG_PORT_CS_DISK : HRLOG_PORT_CS_MEMORY;
     Mbuf_SetTimestamp(outPkt, ctx->rxTime);
     LpL3* lpl3 = Packet_GetLpL3Hdr(outNpkt);
     lpl3->pitToken = ctx->rxToken;
@@ -148,7 +154,8 @@ FwFwd_RxInterest(FwFwd* fwd, FwFwdCtx* ctx) {
          ctx->fibEntry->nComps, ctx->fibEntry->strategy->id);
   ++ctx->fibEntryDyn->nRxInterests;
 
-  // lookup PIT-CS
+  // lookup PIT-CS; diskSlot is cleared if the Interest is returning from DiskStore_GetData
+  bool fromDisk = interest->diskSlot != 0;
   PitInsertResult pitIns = Pit_Insert(fwd->pit, ctx->npkt, ctx->fibEntry);
   switch (pitIns.kind) {
     case PIT_INSERT_PIT: {
@@ -159,7 +166,7 @@ FwFwd_RxInterest(FwFwd* fwd, FwFwdCtx* ctx) {
     case PIT_INSERT_CS: {
       switch (pitIns.csEntry->kind) {
         case CsEntryMemory:
-          FwFwd_InterestHitCsMemory(fwd, ctx, pitIns.csEntry);
+          FwFwd_InterestHitCsMemory(fwd, ctx, pitIns.csEntry, fromDisk);
           break;
         case CsEntryDisk:
           FwFwd_InterestHitCsDisk(fwd, ctx, pitIns.csEntry);
@@ -226,8 +233,13 @@ SgForwardInterest(SgCtx* ctx0, FaceID nh) {
     return SGFWDI_ALLOCERR;
   }
 
-  LpPitToken* outToken = &Packet_GetLpL3Hdr(outNpkt)->pitToken;
+  LpL3* outL3 = Packet_GetLpL3Hdr(outNpkt);
+  LpPitToken* outToken = &outL3->pitToken;
   FwToken_Set(outToken, fwd->id, PitEntry_GetToken(ctx->pitEntry));
+  outL3->congMark = ctx->dnCongMark;
+  if (unlikely(Face_Get(nh)->txInFaceID) && ctx->eventKind == SGEVT_INTEREST) {
+    outL3->inFaceID = ctx->rxFace;
+  }
   Mbuf_SetTimestamp(Packet_ToMbuf(outNpkt), ctx->rxTime); // for latency stats
 
   N_LOGD("^ interest-to=%" PRI_FaceID " npkt=%p " PRI_InterestGuiders " up-token=%s", nh, outNpkt,
@@ -235,7 +247,9 @@ SgForwardInterest(SgCtx* ctx0, FaceID nh) {
   Face_Tx(nh, outNpkt);
   ++ctx->fibEntryDyn->nTxInterests;
 
-  PitUp_RecordTx(up, ctx->pitEntry, now, guiders.nonce, &fwd->suppressCfg);
+  PitSuppressConfig suppressBuf;
+  PitUp_RecordTx(up, ctx->pitEntry, now, guiders.nonce,
+                 FwFwdCtx_GetSuppressConfig(ctx, &suppressBuf));
   ++ctx->nForwarded;
   return SGFWDI_OK;
 }
@@ -9,7 +9,7 @@ N_LOG_INIT(FwFwd);
 
 __attribute__((nonnull)) static void
 FwFwd_TxNacks(FwFwd* fwd, PitEntry* pitEntry, TscTime now, NackReason reason,
-              uint8_t nackHopLimit) {
+              uint8_t nackHopLimit, uint8_t upCongMark) {
   PitDn_Each (it, pitEntry, false) {
     PitDn* dn = it.dn;
     if (dn->face == 0) {
@@ -38,7 +38,9 @@ FwFwd_TxNacks(FwFwd* fwd, PitEntry* pitEntry, TscTime now, NackReason reason,
     NDNDPDK_ASSERT(output !=
                    NULL); // cannot fail because Interest_ModifyGuiders result is already aligned
 
-    Packet_GetLpL3Hdr(output)->pitToken = dn->token;
+    LpL3* lpl3 = Packet_GetLpL3Hdr(output);
+    lpl3->pitToken = dn->token;
+    lpl3->congMark = RTE_MAX(dn->congMark, upCongMark);
     N_LOGD("^ nack-to=%" PRI_FaceID " reason=%s npkt=%p nonce=%08" PRIx32 " dn-token=%s", dn->face,
            NackReason_ToString(reason), output, dn->nonce, LpPitToken_ToString(&dn->token));
     Face_Tx(dn->face, output);
@@ -50,7 +52,7 @@ SgReturnNacks(SgCtx* ctx0, NackReason reason) {
   FwFwdCtx* ctx = (FwFwdCtx*)ctx0;
   NDNDPDK_ASSERT(ctx->eventKind == SGEVT_INTEREST);
 
-  FwFwd_TxNacks(ctx->fwd, ctx->pitEntry, rte_get_tsc_cycles(), reason, 1);
+  FwFwd_TxNacks(ctx->fwd, ctx->pitEntry, rte_get_tsc_cycles(), reason, 1, 0);
 }
 
 __attribute__((nonnull)) static bool
@@ -87,7 +89,9 @@ FwFwd_RxNackDuplicate(FwFwd* fwd, FwFwdCtx* ctx) {
     ++ctx->fibEntryDyn->nTxInterests;
   }
 
-  PitUp_RecordTx(up, ctx->pitEntry, now, guiders.nonce, &fwd->suppressCfg);
+  PitSuppressConfig suppressBuf;
+  PitUp_RecordTx(up, ctx->pitEntry, now, guiders.nonce,
+                 FwFwdCtx_GetSuppressConfig(ctx, &suppressBuf));
   return true;
 }
 
@@ -96,6 +100,7 @@ FwFwd_ProcessNack(FwFwd* fwd, FwFwdCtx* ctx) {
   PNack* nack = Packet_GetNackHdr(ctx->npkt);
   NackReason reason = nack->lpl3.nackReason;
   uint8_t nackHopLimit = nack->interest.hopLimit;
+  uint8_t upCongMark = nack->lpl3.congMark;
 
   N_LOGD("RxNack nack-from=%" PRI_FaceID " npkt=%p up-token=%s reason=%" PRIu8, ctx->rxFace,
          ctx->npkt, LpPitToken_ToString(&ctx->rxToken), reason);
@@ -104,6 +109,14 @@ FwFwd_ProcessNack(FwFwd* fwd, FwFwdCtx* ctx) {
     return;
   }
 
+  FwFaceStat* faceStat = FwFwd_GetFaceStat(fwd, ctx->rxFace);
+  faceStat->lastNack = ctx->rxTime;
+  ++faceStat->nNacks;
+  if (upCongMark != 0 || reason == NackCongestion) {
+    faceStat->lastCongMark = ctx->rxTime;
+    ++faceStat->nCongMarks;
+  }
+
   // find PIT entry
   ctx->pitEntry = Pit_FindByNack(fwd->pit, ctx->npkt, FwToken_GetPccToken(&ctx->rxToken));
   if (unlikely(ctx->pitEntry == NULL)) {
@@ -176,7 +189,7 @@ FwFwd_ProcessNack(FwFwd* fwd, FwFwdCtx* ctx) {
   }
 
   // return Nacks to downstream and erase PIT entry
-  FwFwd_TxNacks(fwd, ctx->pitEntry, ctx->rxTime, leastSevere, nackHopLimit);
+  FwFwd_TxNacks(fwd, ctx->pitEntry, ctx->rxTime, leastSevere, nackHopLimit, upCongMark);
   Pit_Erase(fwd->pit, ctx->pitEntry);
   NULLize(ctx->pitEntry);
 }
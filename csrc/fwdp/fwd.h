@@ -15,6 +15,21 @@
 
 typedef struct FwFwdCtx FwFwdCtx;
 
+/**
+ * @brief Per-face status maintained by a forwarding thread.
+ *
+ * It is only written by the forwarding thread.
+ * To reset it, the control thread increments the generation number in @c FwFwd.faceGen .
+ */
+typedef struct FwFaceStat {
+  RttValue rtt;         ///< face-wide RTT estimate
+  TscTime lastCongMark; ///< when Data/Nack with congestion mark was last received
+  TscTime lastNack;     ///< when Nack was last received
+  uint32_t nCongMarks;  ///< Data/Nack with congestion mark
+  uint32_t nNacks;      ///< Nacks
+  uint32_t gen;         ///< generation number when this record was reset
+} FwFaceStat;
+
 /** @brief Forwarding thread. */
 typedef struct FwFwd {
   SgGlobal sgGlobal;
@@ -42,6 +57,9 @@ typedef struct FwFwd {
 
   struct rte_ring* cryptoHelper; ///< queue to crypto helper
 
+  FwFaceStat* faceStat; ///< per-face status, indexed by FaceID minus FaceMinID
+  uint32_t* faceGen;    ///< per-face generation number, indexed by FaceID minus FaceMinID
+
   /** @brief Statistics of latency from packet arrival to start processing. */
   RunningStat latencyStat;
 } FwFwd;
@@ -87,10 +105,39 @@ struct FwFwdCtx {
   PitUp* pitUp;       // N
   LpPitToken rxToken; // F,I,D,N
   uint32_t dnNonce;   // I
+  uint8_t dnCongMark; // I
   int nForwarded;     // T,I,N
   FaceID rxFace;      // F,I,D
 };
 
+/**
+ * @brief Access per-face status.
+ *
+ * If the face has been closed since last access, the record is reset.
+ */
+__attribute__((nonnull, returns_nonnull)) static inline FwFaceStat*
+FwFwd_GetFaceStat(FwFwd* fwd, FaceID face) {
+  NDNDPDK_ASSERT(face >= FaceMinID && face <= FaceMaxID);
+  FwFaceStat* faceStat = &fwd->faceStat[face - FaceMinID];
+  uint32_t gen = __atomic_load_n(&fwd->faceGen[face - FaceMinID], __ATOMIC_RELAXED);
+  if (unlikely(faceStat->gen != gen)) {
+    *faceStat = (FwFaceStat){.gen = gen};
+  }
+  return faceStat;
+}
+
+/**
+ * @brief Request per-face status to be reset.
+ *
+ * This is called by the control thread when a face is closed.
+ * The forwarding thread resets the record upon next access.
+ */
+__attribute__((nonnull)) static inline void
+FwFwd_ResetFaceStat(FwFwd* fwd, FaceID face) {
+  NDNDPDK_ASSERT(face >= FaceMinID && face <= FaceMaxID);
+  __atomic_fetch_add(&fwd->faceGen[face - FaceMinID], 1, __ATOMIC_RELAXED);
+}
+
 /** @brief Free the current @c npkt . */
 __attribute__((nonnull)) static inline void
 FwFwdCtx_FreePkt(FwFwdCtx* ctx) {
@@ -109,4 +156,18 @@ FwFwdCtx_SetFibEntry(FwFwdCtx* ctx, FibEntry* fibEntry) {
   }
 }
 
+/**
+ * @brief Determine PIT suppression configuration.
+ * @param buf buffer for expanded per-entry configuration.
+ * @return per-entry configuration of @c fibEntry if set, otherwise forwarder-wide configuration.
+ */
+__attribute__((nonnull, returns_nonnull)) static inline const PitSuppressConfig*
+FwFwdCtx_GetSuppressConfig(FwFwdCtx* ctx, PitSuppressConfig* buf) {
+  if (ctx->fibEntry != NULL && ctx->fibEntry->suppress.maxUs != 0) {
+    *buf = PitSuppressConfigCompact_Expand(&ctx->fibEntry->suppress);
+    return buf;
+  }
+  return &ctx->fwd->suppressCfg;
+}
+
 #endif // NDNDPDK_FWDP_FWD_H
@@ -47,6 +47,37 @@ SgSetTimer(SgCtx* ctx0, TscDuration after) {
   return ok;
 }
 
+bool
+SgGetFaceStatus(SgCtx* ctx0, FaceID face, SgFaceStatus* dst) {
+  FwFwdCtx* ctx = (FwFwdCtx*)ctx0;
+  if (unlikely(face < FaceMinID || face > FaceMaxID)) {
+    *dst = (SgFaceStatus){.txDevCount = -1, .isDown = true};
+    return false;
+  }
+
+  const FwFaceStat* faceStat = FwFwd_GetFaceStat(ctx->fwd, face);
+  *dst = (SgFaceStatus){
+    .rtt = faceStat->rtt,
+    .lastCongMark = faceStat->lastCongMark,
+    .lastNack = faceStat->lastNack,
+    .nCongMarks = faceStat->nCongMarks,
+    .nNacks = faceStat->nNacks,
+    .txDevCount = -1,
+    .isDown = true,
+  };
+
+  const Face* f = Face_Get(face);
+  struct rte_ring* outputQueue = f->outputQueue;
+  if (unlikely(f->state != FaceStateUp) || unlikely(outputQueue == NULL)) {
+    return false;
+  }
+  dst->isDown = false;
+  dst->txQueueCount = rte_ring_count(outputQueue);
+  dst->txQueueCapacity = rte_ring_get_capacity(outputQueue);
+  dst->txDevCount = f->impl->txQueueCount == NULL ? -1 : f->impl->txQueueCount(f);
+  return true;
+}
+
 const struct rte_bpf_xsym*
 SgGetXsyms(uint32_t* nXsyms) {
   static const struct rte_bpf_xsym xsyms[] = {
@@ -95,6 +126,22 @@ SgGetXsyms(uint32_t* nXsyms) {
           .ret = {.type = RTE_BPF_ARG_RAW},
         },
     },
+    {
+      .name = "SgGetFaceStatus",
+      .type = RTE_BPF_XTYPE_FUNC,
+      .func =
+        {
+          .val = (void*)SgGetFaceStatus,
+          .nb_args = 3,
+          .args =
+            {
+              {.type = RTE_BPF_ARG_PTR, .size = sizeof(SgCtx)},
+              {.type = RTE_BPF_ARG_RAW},
+              {.type = RTE_BPF_ARG_PTR, .size = sizeof(SgFaceStatus)},
+            },
+          .ret = {.type = RTE_BPF_ARG_RAW},
+        },
+    },
     {
       .name = "SgReturnNacks",
       .type = RTE_BPF_XTYPE_FUNC,
@@ -12,9 +12,19 @@
 typedef enum HrlogAction {
   HRLOG_OI = 1, // Interest TX since RX
   HRLOG_OD = 2, // retrieved Data TX since RX
-  HRLOG_OC = 4, // cached Data TX since Interest RX
+  HRLOG_ON = 3, // Nack TX since Interest or Nack RX
+  HRLOG_OC = 4, // in-memory cached Data TX since Interest RX
+  HRLOG_OS = 5, // disk cached Data TX since Interest RX, including disk read
+  HRLOG_PE = 6, // PIT entry expiry since Interest RX
+  HRLOG_CQ = 7, // Data dequeued by crypto helper since RX
 } HrlogAction;
 
+/** @brief mbuf->port value on Data TX from in-memory CS. */
+#define HRLOG_PORT_CS_MEMORY RTE_MBUF_PORT_INVALID
+
+/** @brief mbuf->port value on Data TX from disk CS. */
+#define HRLOG_PORT_CS_DISK (RTE_MBUF_PORT_INVALID - 1)
+
 /** @brief A high resolution log entry. */
 typedef uint64_t HrlogEntry;
 static_assert(sizeof(HrlogEntry) == sizeof(void*), "");
@@ -34,7 +44,7 @@ typedef struct HrlogHeader {
 static_assert(sizeof(HrlogHeader) == 16, "");
 
 #define HRLOG_HEADER_MAGIC 0x35f0498a
-#define HRLOG_HEADER_VERSION 2
+#define HRLOG_HEADER_VERSION 3
 
 /** @brief RCU-protected pointer to hrlog collector queue. */
 typedef struct HrlogRingRef {
@@ -10,6 +10,13 @@ FaceRx_Input(Face* face, int rxThread, struct rte_mbuf* pkt) {
   FaceRxThread* rxt = &face->impl->rx[rxThread];
   rxt->nFrames[FaceRxThread_cntNOctets] += pkt->pkt_len;
 
+  LpReliability* rel = face->impl->rel;
+  if (rel != NULL && !LpReliability_Rx(rel, pkt, &rxt->nAcks)) {
+    // IDLE packet carrying Acks only
+    rte_pktmbuf_free(pkt);
+    return NULL;
+  }
+
   Packet* npkt = Packet_FromMbuf(pkt);
   if (unlikely(!Packet_Parse(npkt, face->impl->rxParseFor))) {
     ++rxt->nDecodeErr;
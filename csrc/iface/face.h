@@ -5,6 +5,7 @@
 
 #include "input-demux.h"
 #include "reassembler.h"
+#include "reliability.h"
 
 #include "../core/urcu.h"
 #include "../pdump/source.h"
@@ -19,6 +20,7 @@ enum {
 typedef struct FaceRxThread {
   uint64_t nFrames[PktMax]; ///< nOctets or accepted L3 packets
   uint64_t nDecodeErr;      ///< decode errors
+  uint64_t nAcks;           ///< received NDNLPv2 Ack fields
   Reassembler reass;
 } __rte_cache_aligned FaceRxThread;
 
@@ -29,6 +31,7 @@ typedef struct FaceTxThread {
   uint64_t nL3Fragmented; ///< L3 packets that required fragmentation
   uint64_t nL3OverLength; ///< dropped L3 packets due to over length
   uint64_t nAllocFails;   ///< dropped L3 packets due to allocation failure
+  uint64_t nCongMarks;    ///< L3 packets with congestion mark added due to output queue occupancy
 
   uint64_t nFrames[PktMax]; ///< sent+dropped L2 frames and L3 packets
   uint64_t nOctets;         ///< sent+dropped L2 octets (including LpHeader)
@@ -60,6 +63,14 @@ typedef uint16_t (*Face_TxLoopFunc)(Face* face, int txThread);
  */
 typedef uint16_t (*Face_TxBurstFunc)(Face* face, struct rte_mbuf** pkts, uint16_t nPkts);
 
+/**
+ * @brief Retrieve number of frames pending in the device TX queue.
+ * @return number of frames, or negative if unknown.
+ *
+ * This may be invoked from any thread, and the result is approximate.
+ */
+typedef int (*Face_TxQueueCountFunc)(Face* face);
+
 /**
  * @brief Face details.
  *
@@ -80,7 +91,10 @@ typedef struct FaceImpl {
   PacketMempools txMempools; ///< mempools for fragmentation
   Face_TxLoopFunc txLoop;
   Face_TxBurstFunc txBurst;
+  Face_TxQueueCountFunc txQueueCount; ///< optional, may be invoked from forwarding threads
   PdumpSourceRef txPdump;
+  uint32_t txCongMarkThreshold; ///< output queue occupancy to add congestion mark, 0 disables
+  LpReliability* rel;           ///< NDNLPv2 link reliability, NULL if disabled
 
   uint8_t priv[] __rte_cache_aligned;
 } FaceImpl;
@@ -93,6 +107,7 @@ struct Face {
   PacketTxAlign txAlign;
   FaceID id;
   FaceState state;
+  bool txInFaceID; ///< whether forwarded Interests should carry IncomingFaceId
 };
 static_assert(sizeof(Face) <= RTE_CACHE_LINE_SIZE, "");
 
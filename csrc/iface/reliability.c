@@ -0,0 +1,266 @@
+#include "reliability.h"
+
+#include "../core/logger.h"
+#include "../ndni/tlv-decoder.h"
+#include "../ndni/tlv-encoder.h"
+
+N_LOG_INIT(LpReliability);
+
+// rings carry uint64_t TxSequence numbers in place of pointers
+static_assert(sizeof(void*) == sizeof(uint64_t), "");
+
+enum {
+  LpReliability_AckBurst = 64,
+};
+
+/** @brief Encoded TxSequence or Ack field. */
+typedef struct LpRelF {
+  unaligned_uint32_t tl;
+  unaligned_uint64_t v;
+} __rte_packed LpRelF;
+
+bool
+LpReliability_Rx(LpReliability* rel, struct rte_mbuf* pkt, uint64_t* nAcks) {
+  TlvDecoder d = TlvDecoder_Init(pkt);
+  uint32_t length0, type0 = TlvDecoder_ReadTL(&d, &length0);
+  if (type0 != TtLpPacket) {
+    return true;
+  }
+  d.length = length0;
+
+  uint64_t acks[LpReliability_AckBurst];
+  uint32_t nBurst = 0;
+  bool hasPayload = true; // malformed packet is passed to LpHeader_Parse for error handling
+  TlvDecoder_EachTL (&d, type, length) {
+    switch (type) {
+      case TtLpPayload:
+        goto FINISH;
+      case TtLpTxSequence: {
+        uint64_t txSeq = 0;
+        if (unlikely(length != 8 || !TlvDecoder_ReadNniTo(&d, length, &txSeq))) {
+          goto FINISH;
+        }
+        rte_ring_enqueue(rel->pendingAcks, (void*)(uintptr_t)txSeq);
+        break;
+      }
+      case TtLpAck: {
+        if (unlikely(length != 8 || !TlvDecoder_ReadNniTo(&d, length, &acks[nBurst]))) {
+          goto FINISH;
+        }
+        if (++nBurst == RTE_DIM(acks)) {
+          *nAcks += nBurst;
+          rte_ring_enqueue_burst(rel->rxAcks, (void* const*)acks, nBurst, NULL);
+          nBurst = 0;
+        }
+        break;
+      }
+      default:
+        TlvDecoder_Skip(&d, length);
+        break;
+    }
+  }
+  // no payload i.e. IDLE packet
+  hasPayload = false;
+
+FINISH:
+  if (nBurst > 0) {
+    *nAcks += nBurst;
+    rte_ring_enqueue_burst(rel->rxAcks, (void* const*)acks, nBurst, NULL);
+  }
+  return hasPayload;
+}
+
+__attribute__((nonnull)) static inline void
+LpReliability_Release_(LpReliabilityFrame* slot) {
+  rte_pktmbuf_free(slot->frame);
+  slot->frame = NULL;
+}
+
+/**
+ * @brief Assign TxSequence to a frame and retain it.
+ * @param txSeqOffset offset of TxSequence TLV-VALUE, which is overwritten.
+ *
+ * Reference count of every segment is incremented, so that the frame survives after the driver
+ * frees it.
+ */
+__attribute__((nonnull)) static void
+LpReliability_Assign_(LpReliability* rel, TscTime now, struct rte_mbuf* frame,
+                      uint16_t txSeqOffset, uint8_t nRetx) {
+  uint64_t txSeq = rel->nextTxSeq++;
+  if (unlikely(txSeq - rel->oldestTxSeq >= LpReliabilityWindow)) {
+    LpReliabilityFrame* evict = &rel->window[rel->oldestTxSeq % LpReliabilityWindow];
+    if (evict->frame != NULL) {
+      ++rel->nLost;
+      LpReliability_Release_(evict);
+    }
+    ++rel->oldestTxSeq;
+  }
+
+  *rte_pktmbuf_mtod_offset(frame, unaligned_uint64_t*, txSeqOffset) = rte_cpu_to_be_64(txSeq);
+  rel->window[txSeq % LpReliabilityWindow] = (LpReliabilityFrame){
+    .frame = frame,
+    .sendTime = now,
+    .txSeq = txSeq,
+    .pktLen = frame->pkt_len,
+    .dataOff = frame->data_off,
+    .dataLen = frame->data_len,
+    .txSeqOffset = txSeqOffset,
+    .nRetx = nRetx,
+  };
+  for (struct rte_mbuf* m = frame; m != NULL; m = m->next) {
+    rte_mbuf_refcnt_update(m, 1);
+  }
+}
+
+uint16_t
+LpReliability_Poll(LpReliability* rel, TscTime now, struct rte_mbuf** frames, uint16_t maxFrames) {
+  uint64_t acks[LpReliability_AckBurst];
+  uint32_t nAcks = 0;
+  while ((nAcks = rte_ring_dequeue_burst(rel->rxAcks, (void**)acks, RTE_DIM(acks), NULL)) > 0) {
+    for (uint32_t i = 0; i < nAcks; ++i) {
+      LpReliabilityFrame* slot = &rel->window[acks[i] % LpReliabilityWindow];
+      if (slot->frame != NULL && slot->txSeq == acks[i]) {
+        LpReliability_Release_(slot);
+      }
+    }
+  }
+
+  uint16_t nFrames = 0;
+  for (uint64_t txSeq = rel->oldestTxSeq, end = rel->nextTxSeq; txSeq != end && nFrames < maxFrames;
+       ++txSeq) {
+    LpReliabilityFrame* slot = &rel->window[txSeq % LpReliabilityWindow];
+    if (slot->frame == NULL || slot->txSeq != txSeq) { // acknowledged or evicted
+      goto NEXT;
+    }
+    if ((TscDuration)(now - slot->sendTime) < rel->rto ||
+        rte_mbuf_refcnt_read(slot->frame) > 1) { // not expired, or still held by the driver
+      break;
+    }
+    if (slot->nRetx >= rel->maxRetx) {
+      ++rel->nLost;
+      LpReliability_Release_(slot);
+      goto NEXT;
+    }
+
+    struct rte_mbuf* frame = slot->frame;
+    slot->frame = NULL;
+    frame->data_off = slot->dataOff;
+    frame->data_len = slot->dataLen;
+    frame->pkt_len = slot->pktLen;
+    N_LOGV("retx txSeq=%016" PRIx64 " nRetx=%" PRIu8, txSeq, slot->nRetx);
+    LpReliability_Assign_(rel, now, frame, slot->txSeqOffset, slot->nRetx + 1);
+    ++rel->nRetx;
+    frames[nFrames++] = frame;
+
+  NEXT:
+    if (txSeq == rel->oldestTxSeq) {
+      ++rel->oldestTxSeq;
+    }
+  }
+  return nFrames;
+}
+
+/**
+ * @brief Insert Ack and TxSequence fields before LpPayload.
+ * @return offset of TxSequence TLV-VALUE.
+ *
+ * NDNLPv2 requires header fields to appear in the order of their TLV-TYPE numbers.
+ * Ack and TxSequence fields are placed after other fields prepended by @c LpHeader_Prepend ,
+ * by moving those fields toward the headroom.
+ *
+ * @pre LpPacket TL and header fields are in the first segment, which is always the case after
+ *      @c LpHeader_Prepend ; LpPayload TLV-VALUE may span multiple segments.
+ */
+__attribute__((nonnull)) static uint16_t
+LpReliability_Insert_(struct rte_mbuf* frame, const uint64_t* acks, uint16_t nAcks) {
+  TlvDecoder d = TlvDecoder_Init(frame);
+  uint32_t length0, type0 = TlvDecoder_ReadTL(&d, &length0);
+  NDNDPDK_ASSERT(type0 == TtLpPacket);
+  uint16_t sizeofTL = frame->pkt_len - d.length;
+  uint16_t sizeofFields = 0;
+  TlvDecoder_EachTL (&d, type, length) {
+    if (type == TtLpPayload) {
+      sizeofFields =
+        length0 - (TlvEncoder_SizeofVarNum(type) + TlvEncoder_SizeofVarNum(length) + length);
+      break;
+    }
+    TlvDecoder_Skip(&d, length);
+  }
+  NDNDPDK_ASSERT(sizeofTL + sizeofFields <= frame->data_len);
+
+  rte_pktmbuf_adj(frame, sizeofTL);
+  uint16_t sizeofRel = sizeof(LpRelF) * (nAcks + 1);
+  uint8_t* room = (uint8_t*)rte_pktmbuf_prepend(frame, sizeofRel);
+  NDNDPDK_ASSERT(room != NULL);
+  memmove(room, RTE_PTR_ADD(room, sizeofRel), sizeofFields);
+
+  LpRelF* f = RTE_PTR_ADD(room, sizeofFields);
+  for (uint16_t i = 0; i < nAcks; ++i) {
+    f[i].tl = TlvEncoder_ConstTL3(TtLpAck, sizeof(f->v));
+    f[i].v = rte_cpu_to_be_64(acks[i]);
+  }
+  f[nAcks].tl = TlvEncoder_ConstTL3(TtLpTxSequence, sizeof(f->v));
+  f[nAcks].v = 0;
+
+  TlvEncoder_PrependTL(frame, TtLpPacket, frame->pkt_len);
+  uint16_t sizeofNewTL = frame->pkt_len - length0 - sizeofRel;
+  return sizeofNewTL + sizeofFields + sizeof(LpRelF) * nAcks + sizeof(f->tl);
+}
+
+void
+LpReliability_Tx(LpReliability* rel, TscTime now, struct rte_mbuf** frames, uint16_t count) {
+  for (uint16_t i = 0; i < count; ++i) {
+    uint64_t acks[LpMaxAcks];
+    uint16_t nAcks = rte_ring_dequeue_burst(rel->pendingAcks, (void**)acks, LpMaxAcks, NULL);
+    rel->nTxAcks += nAcks;
+    uint16_t txSeqOffset = LpReliability_Insert_(frames[i], acks, nAcks);
+    LpReliability_Assign_(rel, now, frames[i], txSeqOffset, 0);
+  }
+}
+
+uint16_t
+LpReliability_MakeIdle(LpReliability* rel, TscTime now, struct rte_mempool* mp,
+                       struct rte_mbuf** frames, uint16_t maxFrames) {
+  if (now < rel->nextIdleAck || rte_ring_empty(rel->pendingAcks)) {
+    return 0;
+  }
+  rel->nextIdleAck = now + rel->rto / 4;
+
+  uint16_t nFrames = 0;
+  while (nFrames < maxFrames) {
+    uint64_t acks[LpMaxAcks];
+    uint16_t nAcks = rte_ring_dequeue_burst(rel->pendingAcks, (void**)acks, LpMaxAcks, NULL);
+    if (nAcks == 0) {
+      break;
+    }
+
+    struct rte_mbuf* frame = rte_pktmbuf_alloc(mp);
+    if (unlikely(frame == NULL)) {
+      // dequeued Acks are lost, causing the peer to retransmit
+      break;
+    }
+    frame->data_off = RTE_PKTMBUF_HEADROOM + LpHeaderHeadroom;
+    LpRelF* f = (LpRelF*)rte_pktmbuf_prepend(frame, sizeof(LpRelF) * nAcks);
+    for (uint16_t i = 0; i < nAcks; ++i) {
+      f[i].tl = TlvEncoder_ConstTL3(TtLpAck, sizeof(f->v));
+      f[i].v = rte_cpu_to_be_64(acks[i]);
+    }
+    TlvEncoder_PrependTL(frame, TtLpPacket, frame->pkt_len);
+    Mbuf_SetTimestamp(frame, now);
+    Packet_SetType(Packet_FromMbuf(frame), PktFragment);
+
+    rel->nTxAcks += nAcks;
+    frames[nFrames++] = frame;
+  }
+  return nFrames;
+}
+
+void
+LpReliability_Close(LpReliability* rel) {
+  for (uint32_t i = 0; i < RTE_DIM(rel->window); ++i) {
+    LpReliabilityFrame* slot = &rel->window[i];
+    if (slot->frame != NULL) {
+      LpReliability_Release_(slot);
+    }
+  }
+}
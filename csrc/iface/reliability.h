@@ -0,0 +1,91 @@
+#ifndef NDNDPDK_IFACE_RELIABILITY_H
+#define NDNDPDK_IFACE_RELIABILITY_H
+
+/** @file */
+
+#include "common.h"
+
+/** @brief Frame retained by NDNLPv2 link reliability until it is acknowledged. */
+typedef struct LpReliabilityFrame {
+  struct rte_mbuf* frame; ///< retained frame, NULL if slot is empty
+  TscTime sendTime;       ///< last transmission time
+  uint64_t txSeq;         ///< current TxSequence
+  uint32_t pktLen;        ///< saved pkt_len
+  uint16_t dataOff;       ///< saved data_off
+  uint16_t dataLen;       ///< saved data_len
+  uint16_t txSeqOffset;   ///< offset of TxSequence TLV-VALUE
+  uint8_t nRetx;          ///< number of retransmissions
+} LpReliabilityFrame;
+
+/**
+ * @brief NDNLPv2 link reliability state.
+ *
+ * RX threads parse TxSequence and Ack fields of incoming frames, and pass them to the TX thread
+ * via @c rxAcks and @c pendingAcks rings. The TX thread assigns TxSequence to outgoing frames,
+ * piggybacks pending Acks, retains frames until acknowledged, and retransmits them upon timeout.
+ * Each frame is retained by incrementing its mbuf reference counts. A frame is retransmitted
+ * only after the driver has released it.
+ */
+typedef struct LpReliability {
+  struct rte_ring* rxAcks;      ///< received Acks, from RX threads to TX thread
+  struct rte_ring* pendingAcks; ///< received TxSequences to be acknowledged
+  TscDuration rto;              ///< retransmission timeout
+  TscTime nextIdleAck;          ///< when to send Acks in IDLE packets
+  uint64_t nextTxSeq;           ///< next TxSequence
+  uint64_t oldestTxSeq;         ///< lowest TxSequence that may be retained
+  uint8_t maxRetx;              ///< maximum number of retransmissions
+
+  uint64_t nTxAcks; ///< transmitted Acks
+  uint64_t nRetx;   ///< retransmitted frames
+  uint64_t nLost;   ///< frames given up after maxRetx retransmissions or window overflow
+
+  LpReliabilityFrame window[LpReliabilityWindow];
+} LpReliability;
+
+/**
+ * @brief Process TxSequence and Ack fields of an incoming frame.
+ * @param[inout] nAcks incremented by number of Ack fields.
+ * @return whether the frame carries LpPayload and should be parsed further.
+ *
+ * This is called by RX threads. The frame is not modified.
+ */
+__attribute__((nonnull)) bool
+LpReliability_Rx(LpReliability* rel, struct rte_mbuf* pkt, uint64_t* nAcks);
+
+/**
+ * @brief Process received Acks and collect frames that need retransmission.
+ * @param[out] frames frames to be retransmitted.
+ * @return number of frames to be retransmitted, no more than @p maxFrames .
+ *
+ * This is called by the TX thread.
+ */
+__attribute__((nonnull)) uint16_t
+LpReliability_Poll(LpReliability* rel, TscTime now, struct rte_mbuf** frames, uint16_t maxFrames);
+
+/**
+ * @brief Assign TxSequence and piggyback Acks on outgoing frames, and retain them.
+ * @param frames LpPacket frames created by @c LpHeader_Prepend .
+ *
+ * This is called by the TX thread.
+ */
+__attribute__((nonnull)) void
+LpReliability_Tx(LpReliability* rel, TscTime now, struct rte_mbuf** frames, uint16_t count);
+
+/**
+ * @brief Create IDLE packets that carry pending Acks.
+ * @param mp mempool for IDLE packets, with at least @c RTE_PKTMBUF_HEADROOM+LpHeaderHeadroom
+ *           dataroom.
+ * @param[out] frames IDLE packets.
+ * @return number of IDLE packets, no more than @p maxFrames .
+ *
+ * This is called by the TX thread. IDLE packets are created at most once every quarter of RTO.
+ */
+__attribute__((nonnull)) uint16_t
+LpReliability_MakeIdle(LpReliability* rel, TscTime now, struct rte_mempool* mp,
+                       struct rte_mbuf** frames, uint16_t maxFrames);
+
+/** @brief Release retained frames. */
+__attribute__((nonnull)) void
+LpReliability_Close(LpReliability* rel);
+
+#endif // NDNDPDK_IFACE_RELIABILITY_H
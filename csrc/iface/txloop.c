@@ -31,11 +31,34 @@ TxLoop_TxFrames(Face* face, int txThread, struct rte_mbuf** frames, uint16_t cou
   }
 }
 
+/** @brief Apply NDNLPv2 link reliability, if enabled, and submit frames. */
+__attribute__((nonnull)) static __rte_always_inline void
+TxLoop_TxDataFrames(Face* face, int txThread, struct rte_mbuf** frames, uint16_t count,
+                    TscTime now) {
+  LpReliability* rel = face->impl->rel;
+  if (rel != NULL) {
+    LpReliability_Tx(rel, now, frames, count);
+  }
+  TxLoop_TxFrames(face, txThread, frames, count);
+}
+
 __attribute__((nonnull)) static __rte_always_inline uint16_t
 TxLoop_Transfer(Face* face, int txThread, FaceTx_OutputFunc txOne, FaceTx_OutputFunc txFrag) {
   FaceTxThread* txt = &face->impl->tx[txThread];
   Packet* npkts[MaxBurstSize];
-  uint16_t count = rte_ring_dequeue_burst(face->outputQueue, (void**)npkts, MaxBurstSize, NULL);
+  unsigned nRemaining = 0;
+  uint16_t count =
+    rte_ring_dequeue_burst(face->outputQueue, (void**)npkts, MaxBurstSize, &nRemaining);
+
+  // egress congestion marking: mark at most one packet per burst if output queue is congested
+  uint32_t congMarkThreshold = face->impl->txCongMarkThreshold;
+  if (unlikely(congMarkThreshold > 0) && count > 0 && count + nRemaining >= congMarkThreshold) {
+    LpL3* l3 = Packet_GetLpL3Hdr(npkts[0]);
+    if (l3->congMark == 0) {
+      l3->congMark = 1;
+      ++txt->nCongMarks;
+    }
+  }
 
   struct rte_mbuf* frames[MaxBurstSize + LpMaxFragments];
   uint16_t nFrames = 0;
@@ -44,6 +67,15 @@ TxLoop_Transfer(Face* face, int txThread, FaceTx_OutputFunc txOne, FaceTx_Output
   uint16_t nHrls = 0;
 
   TscTime now = rte_get_tsc_cycles();
+  LpReliability* rel = face->impl->rel;
+  if (rel != NULL) {
+    nFrames = LpReliability_Poll(rel, now, frames, MaxBurstSize);
+    if (nFrames > 0) {
+      TxLoop_TxFrames(face, txThread, frames, nFrames);
+      nFrames = 0;
+    }
+  }
+
   for (uint16_t i = 0; i < count; ++i) {
     Packet* npkt = npkts[i];
     PktType framePktType = PktType_ToFull(Packet_GetType(npkt));
@@ -58,10 +90,20 @@ TxLoop_Transfer(Face* face, int txThread, FaceTx_OutputFunc txOne, FaceTx_Output
           hrl[nHrls++] = HrlogEntry_New(HRLOG_OI, latency);
           break;
         case PktData:
-          hrl[nHrls++] =
-            HrlogEntry_New(pkt->port == RTE_MBUF_PORT_INVALID ? HRLOG_OC : HRLOG_OD, latency);
+          switch (pkt->port) {
+            case HRLOG_PORT_CS_MEMORY:
+              hrl[nHrls++] = HrlogEntry_New(HRLOG_OC, latency);
+              break;
+            case HRLOG_PORT_CS_DISK:
+              hrl[nHrls++] = HrlogEntry_New(HRLOG_OS, latency);
+              break;
+            default:
+              hrl[nHrls++] = HrlogEntry_New(HRLOG_OD, latency);
+              break;
+          }
           break;
         case PktNack:
+          hrl[nHrls++] = HrlogEntry_New(HRLOG_ON, latency);
           break;
         default:
           NDNDPDK_ASSERT(false);
@@ -72,13 +114,19 @@ TxLoop_Transfer(Face* face, int txThread, FaceTx_OutputFunc txOne, FaceTx_Output
     bool isOneFragment = pkt->pkt_len <= face->txAlign.fragmentPayloadSize;
     nFrames += (isOneFragment ? txOne : txFrag)(face, txThread, npkt, &frames[nFrames]);
     if (unlikely(nFrames >= MaxBurstSize)) {
-      TxLoop_TxFrames(face, txThread, frames, nFrames);
+      TxLoop_TxDataFrames(face, txThread, frames, nFrames, now);
       nFrames = 0;
     }
   }
 
   if (likely(nFrames > 0)) {
-    TxLoop_TxFrames(face, txThread, frames, nFrames);
+    TxLoop_TxDataFrames(face, txThread, frames, nFrames, now);
+  }
+  if (rel != NULL) {
+    nFrames = LpReliability_MakeIdle(rel, now, face->impl->txMempools.header, frames, MaxBurstSize);
+    if (nFrames > 0) {
+      TxLoop_TxFrames(face, txThread, frames, nFrames);
+    }
   }
   if (hrlRing != NULL) {
     HrlogRing_Post(hrlRing, hrl, nHrls);
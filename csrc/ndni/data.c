@@ -262,21 +262,28 @@ DataEnc_EncodeLinear(LName prefix, LName suffix, const uint8_t* meta, uint32_t r
 
 __attribute__((nonnull)) static struct rte_mbuf*
 DataEnc_EncodeChained(LName prefix, LName suffix, const uint8_t* meta, struct rte_mbuf* tplV,
-                      PacketMempools* mp) {
+                      uint32_t contentL, PacketMempools* mp) {
   struct iovec iov[LpMaxFragments];
   int iovcnt = RTE_DIM(iov);
   struct rte_mbuf* pkt =
-    DataEnc_EncodeCommon(prefix, suffix, meta, tplV->pkt_len, false, iov, &iovcnt, mp, 0);
+    DataEnc_EncodeCommon(prefix, suffix, meta, contentL, false, iov, &iovcnt, mp, 0);
   if (unlikely(pkt == NULL)) {
     return NULL;
   }
   NDNDPDK_ASSERT(iovcnt == 0);
+  if (unlikely(contentL == 0)) {
+    return pkt;
+  }
 
   struct rte_mbuf* content = rte_pktmbuf_clone(tplV, mp->indirect);
   if (unlikely(content == NULL)) {
     rte_pktmbuf_free(pkt);
     return NULL;
   }
+  if (contentL < tplV->pkt_len) {
+    NDNDPDK_ASSERT(content->nb_segs == 1);
+    rte_pktmbuf_trim(content, tplV->pkt_len - contentL);
+  }
 
   int res = rte_pktmbuf_chain(pkt, content);
   if (unlikely(res != 0)) {
@@ -291,20 +298,26 @@ DataEnc_EncodeChained(LName prefix, LName suffix, const uint8_t* meta, struct rt
 struct rte_mbuf*
 DataEnc_EncodeTpl(LName prefix, LName suffix, const uint8_t* meta, struct rte_mbuf* tplV,
                   struct iovec* tplIov, int tplIovcnt, PacketMempools* mp, PacketTxAlign align) {
+  uint32_t contentL = 0;
+  for (int i = 0; i < tplIovcnt; ++i) {
+    contentL += tplIov[i].iov_len;
+  }
+  NDNDPDK_ASSERT(contentL <= tplV->pkt_len);
+
   if (!align.linearize) {
-    return DataEnc_EncodeChained(prefix, suffix, meta, tplV, mp);
+    return DataEnc_EncodeChained(prefix, suffix, meta, tplV, contentL, mp);
   }
 
   struct iovec roomIov[LpMaxFragments];
   int roomIovcnt = 0;
-  struct rte_mbuf* pkt = DataEnc_EncodeLinear(prefix, suffix, meta, tplV->pkt_len, roomIov,
+  struct rte_mbuf* pkt = DataEnc_EncodeLinear(prefix, suffix, meta, contentL, roomIov,
                                               &roomIovcnt, mp, align.fragmentPayloadSize);
   if (unlikely(pkt == NULL)) {
     return NULL;
   }
 
   size_t nCopiedOctets = spdk_iovcpy(tplIov, tplIovcnt, roomIov, roomIovcnt);
-  if (unlikely(nCopiedOctets != tplV->pkt_len)) {
+  if (unlikely(nCopiedOctets != contentL)) {
     rte_pktmbuf_free(pkt);
     return NULL;
   }
@@ -324,7 +337,7 @@ DataEnc_EncodeRoom(LName prefix, LName suffix, const uint8_t* meta, uint32_t roo
 }
 
 __attribute__((nonnull)) static inline struct rte_mbuf*
-DataEnc_SignChain(struct rte_mbuf* pkt, struct rte_mbuf* tail, PacketMempools* mp) {
+DataEnc_AppendChain(struct rte_mbuf* pkt, struct rte_mbuf* tail, PacketMempools* mp) {
   struct rte_mbuf* sigSeg = rte_pktmbuf_alloc(mp->packet);
   if (unlikely(sigSeg == NULL)) {
     rte_pktmbuf_free(pkt);
@@ -339,33 +352,47 @@ DataEnc_SignChain(struct rte_mbuf* pkt, struct rte_mbuf* tail, PacketMempools* m
 }
 
 __attribute__((nonnull)) static inline struct rte_mbuf*
-DataEnc_SignDirect(struct rte_mbuf* pkt, struct rte_mbuf* tail, PacketMempools* mp,
-                   uint16_t fragmentPayloadSize) {
-  if (unlikely(tail->data_len + DataEncNullSigLen > fragmentPayloadSize ||
-               rte_pktmbuf_tailroom(tail) < DataEncNullSigLen)) {
-    return DataEnc_SignChain(pkt, tail, mp);
+DataEnc_AppendDirect(struct rte_mbuf* pkt, struct rte_mbuf* tail, uint16_t length,
+                     PacketMempools* mp, uint16_t fragmentPayloadSize) {
+  if (unlikely(tail->data_len + length > fragmentPayloadSize ||
+               rte_pktmbuf_tailroom(tail) < length)) {
+    return DataEnc_AppendChain(pkt, tail, mp);
   }
   return tail;
 }
 
-Packet*
-DataEnc_Sign(struct rte_mbuf* pkt, PacketMempools* mp, PacketTxAlign align) {
+bool
+DataEnc_Append(struct rte_mbuf* pkt, const uint8_t* value, uint16_t length, PacketMempools* mp,
+               PacketTxAlign align) {
   struct rte_mbuf* tail = rte_pktmbuf_lastseg(pkt);
   if (align.linearize) {
     NDNDPDK_ASSERT(RTE_MBUF_DIRECT(tail) && rte_mbuf_refcnt_read(tail) == 1);
-    tail = DataEnc_SignDirect(pkt, tail, mp, align.fragmentPayloadSize);
+    tail = DataEnc_AppendDirect(pkt, tail, length, mp, align.fragmentPayloadSize);
   } else if (RTE_MBUF_DIRECT(tail) && rte_mbuf_refcnt_read(tail) == 1) {
-    tail = DataEnc_SignDirect(pkt, tail, mp, UINT16_MAX);
+    tail = DataEnc_AppendDirect(pkt, tail, length, mp, UINT16_MAX);
   } else {
-    tail = DataEnc_SignChain(pkt, tail, mp);
+    tail = DataEnc_AppendChain(pkt, tail, mp);
   }
 
   if (unlikely(tail == NULL)) {
-    return NULL;
+    return false;
   }
 
-  rte_memcpy(rte_pktmbuf_mtod_offset(tail, void*, tail->data_len), &NullSig, DataEncNullSigLen);
-  tail->data_len += DataEncNullSigLen;
-  pkt->pkt_len += DataEncNullSigLen;
+  rte_memcpy(rte_pktmbuf_mtod_offset(tail, void*, tail->data_len), value, length);
+  tail->data_len += length;
+  pkt->pkt_len += length;
+  return true;
+}
+
+Packet*
+DataEnc_Finish(struct rte_mbuf* pkt) {
   return Packet_EncodeFinish_(pkt, TtData, PktSData);
 }
+
+Packet*
+DataEnc_Sign(struct rte_mbuf* pkt, PacketMempools* mp, PacketTxAlign align) {
+  if (unlikely(!DataEnc_Append(pkt, (const uint8_t*)&NullSig, DataEncNullSigLen, mp, align))) {
+    return NULL;
+  }
+  return DataEnc_Finish(pkt);
+}
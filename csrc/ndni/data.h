@@ -5,6 +5,8 @@
 
 #include "name.h"
 
+#include <rte_random.h>
+
 /** @brief Parsed Data packet. */
 typedef struct PData {
   PName name;
@@ -91,7 +93,8 @@ DataEnc_SizeofMetaInfo(const uint8_t* meta) {
  * @param suffix name suffix.
  * @param meta prepared MetaInfo buffer.
  * @param tplV Content template.
- * @param tplIov Content iov, must match @p tplV .
+ * @param tplIov Content iov, must match @p tplV or a prefix of @p tplV .
+ *               Content TLV-LENGTH is the total length of @p tplIov .
  * @return encoded packet, or NULL upon failure.
  */
 __attribute__((nonnull)) struct rte_mbuf*
@@ -112,23 +115,101 @@ __attribute__((nonnull)) struct rte_mbuf*
 DataEnc_EncodeRoom(LName prefix, LName suffix, const uint8_t* meta, uint32_t roomL,
                    struct iovec* roomIov, int* roomIovcnt, PacketMempools* mp, PacketTxAlign align);
 
+/**
+ * @brief Append signature fields to Data.
+ * @param pkt result of @c DataEnc_EncodeTpl or @c DataEnc_EncodeRoom , possibly with other fields
+ *            appended by this function.
+ * @param value encoded TLV elements, such as SignatureInfo or SignatureValue.
+ * @return whether success.
+ * @post If failure, @p pkt is freed.
+ *
+ * If @c align.linearize is true, @p value is appended to the last segment if it fits within
+ * @c align.fragmentPayloadSize , otherwise it is placed in a new segment.
+ */
+__attribute__((nonnull)) bool
+DataEnc_Append(struct rte_mbuf* pkt, const uint8_t* value, uint16_t length, PacketMempools* mp,
+               PacketTxAlign align);
+
+/**
+ * @brief Finish Data encoding after SignatureInfo and SignatureValue are appended.
+ * @param pkt result of @c DataEnc_Append .
+ * @return encoded packet, which has zero LpL3 fields.
+ */
+__attribute__((nonnull, returns_nonnull)) Packet*
+DataEnc_Finish(struct rte_mbuf* pkt);
+
 /**
  * @brief Append Null signature to Data.
  * @param pkt result of @c DataEnc_EncodeTpl or @c DataEnc_EncodeRoom .
- * @return encoded packet, or NUL upon failure.
+ * @return encoded packet, or NULL upon failure.
  * @post If failure, @p pkt is freed.
  */
 __attribute__((nonnull)) Packet*
 DataEnc_Sign(struct rte_mbuf* pkt, PacketMempools* mp, PacketTxAlign align);
 
+/** @brief Bin of Content length distribution in DataGen. */
+typedef struct DataGenLenBin {
+  uint32_t cdf;  ///< cumulative probability up to this bin, scaled to UINT32_MAX
+  uint16_t min;  ///< minimum Content length in this bin
+  uint16_t span; ///< maximum Content length minus minimum Content length
+} DataGenLenBin;
+
 /** @brief Data encoder optimized for traffic generator. */
 typedef struct DataGen {
   struct rte_mbuf* tpl;
   LName suffix;
   const uint8_t* meta;
   struct iovec contentIov[1];
+  /**
+   * @brief Content length distribution.
+   *
+   * If NULL, Content length is always @c contentIov[0].iov_len .
+   * Otherwise, a bin is selected according to its probability, and then Content length is drawn
+   * uniformly within the bin; Content is a prefix of the template.
+   */
+  const DataGenLenBin* lenBins;
+  uint32_t nLenBins;
 } DataGen;
 
+/** @brief Draw Content length from DataGen length distribution. */
+__attribute__((nonnull)) static inline uint32_t
+DataGen_DrawLength(DataGen* gen) {
+  if (likely(gen->lenBins == NULL)) {
+    return gen->contentIov[0].iov_len;
+  }
+
+  uint32_t r = (uint32_t)rte_rand();
+  uint32_t left = 0, right = gen->nLenBins - 1;
+  while (left < right) {
+    uint32_t mid = (left + right) / 2;
+    if (gen->lenBins[mid].cdf < r) {
+      left = mid + 1;
+    } else {
+      right = mid;
+    }
+  }
+
+  const DataGenLenBin* bin = &gen->lenBins[left];
+  if (bin->span == 0) {
+    return bin->min;
+  }
+  return bin->min + (uint32_t)rte_rand_max((uint64_t)bin->span + 1);
+}
+
+/**
+ * @brief Encode Data with DataGen template, without signature.
+ * @return encoded packet, to be passed to @c DataEnc_Sign or @c DataEnc_Append .
+ * @retval NULL allocation failure.
+ */
+__attribute__((nonnull)) static inline struct rte_mbuf*
+DataGen_EncodeUnsigned(DataGen* gen, LName prefix, PacketMempools* mp, PacketTxAlign align) {
+  struct iovec contentIov[1] = {{
+    .iov_base = gen->contentIov[0].iov_base,
+    .iov_len = DataGen_DrawLength(gen),
+  }};
+  return DataEnc_EncodeTpl(prefix, gen->suffix, gen->meta, gen->tpl, contentIov, 1, mp, align);
+}
+
 /**
  * @brief Encode Data with DataGen template.
  * @return encoded packet.
@@ -143,8 +224,7 @@ typedef struct DataGen {
  */
 __attribute__((nonnull)) static inline Packet*
 DataGen_Encode(DataGen* gen, LName prefix, PacketMempools* mp, PacketTxAlign align) {
-  struct rte_mbuf* pkt =
-    DataEnc_EncodeTpl(prefix, gen->suffix, gen->meta, gen->tpl, gen->contentIov, 1, mp, align);
+  struct rte_mbuf* pkt = DataGen_EncodeUnsigned(gen, prefix, mp, align);
   if (unlikely(pkt == NULL)) {
     return NULL;
   }
@@ -149,6 +149,17 @@ LpHeader_Prepend(struct rte_mbuf* pkt, const LpL3* l3, const LpL2* l2) {
   TlvEncoder_PrependTL(pkt, TtLpPayload, pkt->pkt_len);
 
   if (likely(l2->fragIndex == 0)) {
+    if (unlikely(l3->inFaceID != 0)) {
+      typedef struct InFaceIDF {
+        unaligned_uint32_t inFaceIDTL;
+        unaligned_uint16_t inFaceIDV;
+      } __rte_packed InFaceIDF;
+
+      InFaceIDF* f = (InFaceIDF*)rte_pktmbuf_prepend(pkt, sizeof(InFaceIDF));
+      f->inFaceIDTL = TlvEncoder_ConstTL3(TtIncomingFaceID, sizeof(f->inFaceIDV));
+      f->inFaceIDV = rte_cpu_to_be_16(l3->inFaceID);
+    }
+
     if (unlikely(l3->congMark != 0)) {
       typedef struct CongMarkF {
         unaligned_uint32_t congMarkTL;
@@ -56,6 +56,7 @@ LpPitToken_ToString(const LpPitToken* token);
 typedef struct LpL3 {
   uint8_t nackReason;
   uint8_t congMark;
+  uint16_t inFaceID; ///< IncomingFaceId, 0 if absent; not parsed on RX
   LpPitToken pitToken;
 } LpL3;
 
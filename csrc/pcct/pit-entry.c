@@ -1,6 +1,7 @@
 #include "pit-entry.h"
 #include "../core/base16.h"
 #include "../core/logger.h"
+#include "../hrlog/entry.h"
 #include "pit-iterator.h"
 #include "pit.h"
 
@@ -117,6 +118,9 @@ PitEntry_Timeout_(MinTmr* tmr, uintptr_t pitPtr) {
   } else {
     N_LOGD("Timeout(expiry) pit=%p pit-entry=%p", pit, entry);
     ++pit->nExpired;
+    HrlogEntry hrl = HrlogEntry_New(
+      HRLOG_PE, rte_get_tsc_cycles() - Mbuf_GetTimestamp(Packet_ToMbuf(entry->npkt)));
+    Hrlog_Post(&hrl, 1);
     Pit_Erase(pit, entry);
   }
 }
@@ -22,4 +22,26 @@ PitSuppressConfig_Compute(const PitSuppressConfig* cfg, TscDuration d) {
   return CLAMP(d, cfg->min, cfg->max);
 }
 
+/**
+ * @brief Compact Interest suppression configuration.
+ *
+ * This is stored in FibEntry. If all fields are zero, it is unset.
+ */
+typedef struct PitSuppressConfigCompact {
+  uint32_t minUs;   ///< initial/minimum suppression duration in microseconds
+  uint32_t maxUs;   ///< maximum suppression duration in microseconds
+  float multiplier; ///< multiplier on each transmission
+} PitSuppressConfigCompact;
+
+/** @brief Expand compact suppression configuration. */
+__attribute__((nonnull)) static inline PitSuppressConfig
+PitSuppressConfigCompact_Expand(const PitSuppressConfigCompact* c) {
+  double tscPerUs = TscGHz * 1000;
+  return (PitSuppressConfig){
+    .min = c->minUs * tscPerUs,
+    .max = c->maxUs * tscPerUs,
+    .multiplier = c->multiplier,
+  };
+}
+
 #endif // NDNDPDK_PCCT_PIT_SUPPRESS_CONFIG_H
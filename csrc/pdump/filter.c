@@ -0,0 +1,181 @@
+#include "filter.h"
+
+void
+PdumpPacketInfo_ParseL3(PdumpPacketInfo* info, TlvDecoder* d, bool isNack) {
+  uint32_t length0, type0 = TlvDecoder_ReadTL_MaybeTruncated(d, &length0);
+  switch (type0) {
+    case TtInterest:
+      info->l3 = isNack ? PktNack : PktInterest;
+      break;
+    case TtData:
+      info->l3 = PktData;
+      break;
+    default:
+      return;
+  }
+
+  uint32_t length1, type1 = TlvDecoder_ReadTL_MaybeTruncated(d, &length1);
+  if (unlikely(type1 != TtName)) {
+    return;
+  }
+  info->name = (LName){
+    .value = rte_pktmbuf_mtod_offset(d->m, const uint8_t*, d->offset),
+    .length = RTE_MIN(length1, d->m->data_len - d->offset),
+  };
+  if (info->l3 == PktData || length1 > d->length) {
+    return;
+  }
+
+  TlvDecoder_Skip(d, length1);
+  TlvDecoder_EachTL (d, type, length) {
+    if (type == TtHopLimit) {
+      info->hasHopLimit = length == 1 && TlvDecoder_ReadNniTo(d, length, &info->hopLimit);
+      return;
+    }
+    TlvDecoder_Skip(d, length);
+  }
+}
+
+void
+PdumpPacketInfo_Parse(PdumpPacketInfo* info, struct rte_mbuf* pkt) {
+  *info = (PdumpPacketInfo){.l3 = PktFragment};
+  TlvDecoder d = TlvDecoder_Init(pkt);
+  TlvDecoder l3 = d;
+  uint32_t length0, type0 = TlvDecoder_ReadTL(&d, &length0);
+  switch (type0) {
+    case TtInterest:
+    case TtData:
+      PdumpPacketInfo_ParseL3(info, &l3, false);
+      return;
+    case TtLpPacket:
+      break;
+    default:
+      return;
+  }
+
+  bool isNack = false;
+  TlvDecoder_EachTL (&d, type1, length1) {
+    switch (type1) {
+      case TtFragIndex: {
+        uint8_t fragIndex = 0;
+        if (unlikely(!TlvDecoder_ReadNniTo(&d, length1, &fragIndex)) || fragIndex > 0) {
+          return;
+        }
+        break;
+      }
+      case TtPitToken:
+        if (unlikely(length1 > sizeof(info->pitToken.value))) {
+          TlvDecoder_Skip(&d, length1);
+          break;
+        }
+        info->pitToken.length = length1;
+        TlvDecoder_Copy(&d, info->pitToken.value, length1);
+        break;
+      case TtNack: {
+        isNack = true;
+        info->nackReason = NackUnspecified;
+        TlvDecoder vd = TlvDecoder_MakeValueDecoder(&d, length1);
+        TlvDecoder_EachTL (&vd, type2, length2) {
+          if (type2 == TtNackReason && TlvDecoder_ReadNniTo(&vd, length2, &info->nackReason)) {
+            break;
+          }
+          TlvDecoder_Skip(&vd, length2);
+        }
+        break;
+      }
+      case TtCongestionMark:
+        if (unlikely(!TlvDecoder_ReadNniTo(&d, length1, &info->congMark))) {
+          return;
+        }
+        break;
+      case TtLpPayload:
+        PdumpPacketInfo_ParseL3(info, &d, isNack);
+        return;
+      default:
+        TlvDecoder_Skip(&d, length1);
+        break;
+    }
+  }
+}
+
+__attribute__((nonnull)) static inline bool
+PdumpFilter_Compare(const PdumpFilterInsn* insn, uint32_t actual) {
+  switch (insn->cmp) {
+    case PdumpFilterCmpEq:
+      return actual == insn->value;
+    case PdumpFilterCmpNe:
+      return actual != insn->value;
+    case PdumpFilterCmpLt:
+      return actual < insn->value;
+    case PdumpFilterCmpLe:
+      return actual <= insn->value;
+    case PdumpFilterCmpGt:
+      return actual > insn->value;
+    case PdumpFilterCmpGe:
+      return actual >= insn->value;
+  }
+  return false;
+}
+
+__attribute__((nonnull)) static inline uint32_t
+PdumpFilter_CountComponents(LName name) {
+  uint32_t nComps = 0;
+  uint16_t pos = 0, type = 0, length = 0;
+  while (likely(LName_Component(name, &pos, &type, &length))) {
+    ++nComps;
+    pos += length;
+  }
+  return nComps;
+}
+
+bool
+PdumpFilter_Eval(const PdumpFilter* filter, const PdumpPacketInfo* info) {
+  if (filter->nInsns == 0) {
+    return true;
+  }
+
+  bool stack[PdumpFilterMaxInsns];
+  int top = -1;
+  for (uint8_t i = 0; i < filter->nInsns; ++i) {
+    const PdumpFilterInsn* insn = &filter->insns[i];
+    const uint8_t* operand = RTE_PTR_ADD(filter->operands, insn->value);
+    switch (insn->op) {
+      case PdumpFilterOpAnd:
+        --top;
+        stack[top] = stack[top] && stack[top + 1];
+        break;
+      case PdumpFilterOpOr:
+        --top;
+        stack[top] = stack[top] || stack[top + 1];
+        break;
+      case PdumpFilterOpNot:
+        stack[top] = !stack[top];
+        break;
+      case PdumpFilterOpL3Type:
+        stack[++top] = PdumpFilter_Compare(insn, info->l3);
+        break;
+      case PdumpFilterOpNackReason:
+        stack[++top] = info->l3 == PktNack && PdumpFilter_Compare(insn, info->nackReason);
+        break;
+      case PdumpFilterOpCongMark:
+        stack[++top] = PdumpFilter_Compare(insn, info->congMark);
+        break;
+      case PdumpFilterOpHopLimit:
+        stack[++top] = info->hasHopLimit && PdumpFilter_Compare(insn, info->hopLimit);
+        break;
+      case PdumpFilterOpNameLen:
+        stack[++top] =
+          info->name.length > 0 && PdumpFilter_Compare(insn, PdumpFilter_CountComponents(info->name));
+        break;
+      case PdumpFilterOpNamePrefix:
+        stack[++top] = info->name.length > 0 &&
+                       LName_IsPrefix((LName){.value = operand, .length = insn->len}, info->name) >= 0;
+        break;
+      case PdumpFilterOpPitTokenPrefix:
+        stack[++top] =
+          insn->len <= info->pitToken.length && memcmp(operand, info->pitToken.value, insn->len) == 0;
+        break;
+    }
+  }
+  return stack[0];
+}
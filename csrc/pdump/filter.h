@@ -0,0 +1,62 @@
+#ifndef NDNDPDK_PDUMP_FILTER_H
+#define NDNDPDK_PDUMP_FILTER_H
+
+/** @file */
+
+#include "../ndni/lp.h"
+#include "../ndni/name.h"
+#include "enum.h"
+
+/** @brief Packet fields available to filter expressions. */
+typedef struct PdumpPacketInfo {
+  LName name;         ///< Interest/Data name, possibly truncated
+  LpPitToken pitToken;
+  PktType l3;         ///< PktFragment if L3 type is unknown
+  uint8_t nackReason; ///< valid if l3==PktNack
+  uint8_t congMark;
+  uint8_t hopLimit;   ///< valid if hasHopLimit
+  bool hasHopLimit;
+} PdumpPacketInfo;
+
+/**
+ * @brief Parse L3 fields.
+ * @param d decoder positioned at Interest or Data TLV; TLV-VALUE may be truncated.
+ * @param isNack whether NDNLPv2 header contains Nack field.
+ */
+__attribute__((nonnull)) void
+PdumpPacketInfo_ParseL3(PdumpPacketInfo* info, TlvDecoder* d, bool isNack);
+
+/**
+ * @brief Parse NDNLPv2 and L3 fields.
+ * @param pkt packet positioned at NDNLPv2 header or L3 header.
+ *
+ * L3 fields are available only if @p pkt is a full packet or the first fragment.
+ */
+__attribute__((nonnull)) void
+PdumpPacketInfo_Parse(PdumpPacketInfo* info, struct rte_mbuf* pkt);
+
+/** @brief Filter program instruction. */
+typedef struct PdumpFilterInsn {
+  PdumpFilterOp op;
+  PdumpFilterCmp cmp;
+  uint16_t len;   ///< operand length in PdumpFilter.operands
+  uint32_t value; ///< comparison value, or operand offset in PdumpFilter.operands
+} PdumpFilterInsn;
+
+/**
+ * @brief Compiled filter expression.
+ *
+ * The program is in postfix order, evaluated with a stack of booleans.
+ * It is validated in Go code so that the stack never underflows or overflows.
+ */
+typedef struct PdumpFilter {
+  uint8_t nInsns; ///< 0 means accept all
+  PdumpFilterInsn insns[PdumpFilterMaxInsns];
+  uint8_t operands[PdumpFilterMaxOperands];
+} PdumpFilter;
+
+/** @brief Evaluate filter program on packet fields. */
+__attribute__((nonnull)) bool
+PdumpFilter_Eval(const PdumpFilter* filter, const PdumpPacketInfo* info);
+
+#endif // NDNDPDK_PDUMP_FILTER_H
@@ -70,4 +70,55 @@ NO_MATCH:;
   return (LName){0};
 }
 
+/** @brief NDNLPv2 fragmentation fields. */
+typedef struct PdumpFragment {
+  uint64_t seqNumBase; ///< seqNum-fragIndex
+  uint32_t payloadLen; ///< LpPayload TLV-LENGTH; LpPayload is the last field in LpPacket
+  uint8_t fragIndex;
+  uint8_t fragCount;
+} PdumpFragment;
+
+/**
+ * @brief Extract NDNLPv2 fragmentation fields from mbuf.
+ * @return whether @p pkt is a fragment of an NDNLPv2 packet with more than one fragments.
+ */
+__attribute__((nonnull)) static inline bool
+Pdump_ParseFragment(struct rte_mbuf* pkt, PdumpFragment* frag) {
+  TlvDecoder d = TlvDecoder_Init(pkt);
+  uint32_t length0, type0 = TlvDecoder_ReadTL(&d, &length0);
+  if (type0 != TtLpPacket) {
+    return false;
+  }
+
+  uint64_t seqNum = 0;
+  *frag = (PdumpFragment){.fragCount = 1};
+  TlvDecoder_EachTL (&d, type1, length1) {
+    switch (type1) {
+      case TtLpSeqNum:
+        if (unlikely(length1 != 8 || !TlvDecoder_ReadNniTo(&d, length1, &seqNum))) {
+          return false;
+        }
+        break;
+      case TtFragIndex:
+        if (unlikely(!TlvDecoder_ReadNniTo(&d, length1, LpMaxFragments - 1, &frag->fragIndex))) {
+          return false;
+        }
+        break;
+      case TtFragCount:
+        if (unlikely(!TlvDecoder_ReadNniTo(&d, length1, LpMaxFragments, &frag->fragCount))) {
+          return false;
+        }
+        break;
+      case TtLpPayload:
+        frag->payloadLen = length1;
+        frag->seqNumBase = seqNum - frag->fragIndex;
+        return frag->fragCount > 1 && frag->fragIndex < frag->fragCount && length1 > 0;
+      default:
+        TlvDecoder_Skip(&d, length1);
+        break;
+    }
+  }
+  return false;
+}
+
 #endif // NDNDPDK_PDUMP_PARSE_H
@@ -33,12 +33,7 @@ PdumpSourceRef_Set(PdumpSourceRef* ref, PdumpSource* s) {
 }
 
 __attribute__((nonnull)) static __rte_always_inline uint32_t
-PdumpFaceSource_NameProb(const PdumpFaceSource* source, struct rte_mbuf* pkt) {
-  if (source->nameL[0] == 0) {
-    return source->sample[0];
-  }
-
-  LName name = Pdump_ExtractName(pkt);
+PdumpFaceSource_NameProb(const PdumpFaceSource* source, LName name) {
   if (unlikely(name.length == 0)) {
     return 0;
   }
@@ -47,12 +42,158 @@ PdumpFaceSource_NameProb(const PdumpFaceSource* source, struct rte_mbuf* pkt) {
   return index >= 0 ? source->sample[index] : 0;
 }
 
+__attribute__((nonnull)) static __rte_always_inline bool
+PdumpFaceSource_Sample(PdumpFaceSource* s, uint32_t prob) {
+  return prob > 0 &&                      // skip pcg32 computation when there's no name match
+         prob >= pcg32_random_r(&s->rng); // '>=' because UINT32_MAX means always
+}
+
+__attribute__((nonnull)) static void
+PdumpReassSlot_Clear(PdumpReassSlot* slot) {
+  for (uint8_t i = 0; i < slot->fragCount; ++i) {
+    if (slot->received & (1 << i)) {
+      rte_pktmbuf_free(slot->frags[i]);
+    }
+  }
+  slot->fragCount = 0;
+}
+
+/**
+ * @brief Find reassembly slot for a fragment.
+ *
+ * If no slot matches, the least recently used slot is evicted and reused.
+ */
+__attribute__((nonnull)) static PdumpReassSlot*
+PdumpFaceSource_FindSlot(PdumpFaceSource* s, const PdumpFragment* frag) {
+  PdumpReassSlot* lru = &s->reass[0];
+  for (int i = 0; i < PdumpReassMaxPackets; ++i) {
+    PdumpReassSlot* slot = &s->reass[i];
+    if (slot->fragCount == frag->fragCount && slot->seqNumBase == frag->seqNumBase) {
+      return slot;
+    }
+    if (slot->fragCount == 0) {
+      lru = slot;
+    } else if (lru->fragCount != 0 && s->reassClock - slot->lastUsed > s->reassClock - lru->lastUsed) {
+      lru = slot;
+    }
+  }
+
+  PdumpReassSlot_Clear(lru);
+  lru->seqNumBase = frag->seqNumBase;
+  lru->fragCount = frag->fragCount;
+  lru->received = 0;
+  lru->nReceived = 0;
+  return lru;
+}
+
+/**
+ * @brief Determine whether to capture a packet.
+ * @param info parsed packet fields; only name is used if @c s->expr is empty.
+ */
+__attribute__((nonnull)) static __rte_always_inline bool
+PdumpFaceSource_Decide(PdumpFaceSource* s, const PdumpPacketInfo* info) {
+  if (!PdumpFilter_Eval(&s->expr, info)) {
+    return false;
+  }
+  if (s->nameL[0] == 0) {
+    return PdumpFaceSource_Sample(s, s->sample[0]);
+  }
+  return PdumpFaceSource_Sample(s, PdumpFaceSource_NameProb(s, info->name));
+}
+
+/**
+ * @brief Parse fields of a completely received packet.
+ * @param m a DIRECT mbuf to store the beginning of the reassembled L3 packet.
+ * @post L3 fields in @p info refer to @p m .
+ */
+__attribute__((nonnull)) static void
+PdumpReassSlot_Parse(PdumpReassSlot* slot, struct rte_mbuf* m, PdumpPacketInfo* info) {
+  for (uint8_t i = 0; i < slot->fragCount; ++i) {
+    struct rte_mbuf* frag = slot->frags[i];
+    uint32_t len = RTE_MIN(slot->payloadLen[i], (uint32_t)rte_pktmbuf_tailroom(m));
+    if (len == 0) {
+      break;
+    }
+    Mbuf_ReadTo(frag, frag->pkt_len - slot->payloadLen[i], len, rte_pktmbuf_append(m, len));
+  }
+
+  PdumpPacketInfo_Parse(info, slot->frags[0]);
+  bool isNack = info->l3 == PktNack;
+  info->name = (LName){0};
+  info->hasHopLimit = false;
+  TlvDecoder d = TlvDecoder_Init(m);
+  PdumpPacketInfo_ParseL3(info, &d, isNack);
+}
+
+/**
+ * @brief Buffer a fragment, and capture all fragments if the reassembled packet matches.
+ * @param pkt fragment; it is not owned by this function.
+ */
+__attribute__((nonnull)) static void
+PdumpFaceSource_Reassemble(PdumpFaceSource* s, struct rte_mbuf* pkt, const PdumpFragment* frag) {
+  PdumpReassSlot* slot = PdumpFaceSource_FindSlot(s, frag);
+  slot->lastUsed = ++s->reassClock;
+  if (slot->received & (1 << frag->fragIndex)) { // duplicate
+    return;
+  }
+
+  struct rte_mbuf* copy = rte_pktmbuf_copy(pkt, s->base.directMp, 0, UINT32_MAX);
+  if (unlikely(copy == NULL)) {
+    return;
+  }
+  copy->port = s->base.mbufPort;
+  copy->packet_type = s->base.mbufType;
+  slot->frags[frag->fragIndex] = copy;
+  slot->payloadLen[frag->fragIndex] = frag->payloadLen;
+  slot->received |= 1 << frag->fragIndex;
+  if (++slot->nReceived < slot->fragCount) {
+    return;
+  }
+
+  bool capture = false;
+  struct rte_mbuf* m = rte_pktmbuf_alloc(s->base.directMp);
+  if (likely(m != NULL)) {
+    PdumpPacketInfo info;
+    PdumpReassSlot_Parse(slot, m, &info);
+    capture = PdumpFaceSource_Decide(s, &info);
+    rte_pktmbuf_free(m);
+  }
+
+  if (capture) {
+    Mbuf_EnqueueVector(slot->frags, slot->fragCount, s->base.queue, true);
+  } else {
+    rte_pktmbuf_free_bulk(slot->frags, slot->fragCount);
+  }
+  slot->fragCount = 0;
+}
+
 bool
 PdumpFaceSource_Filter(PdumpSource* s0, struct rte_mbuf* pkt) {
   PdumpFaceSource* s = container_of(s0, PdumpFaceSource, base);
-  uint32_t prob = PdumpFaceSource_NameProb(s, pkt);
-  return prob > 0 &&                      // skip pcg32 computation when there's no name match
-         prob >= pcg32_random_r(&s->rng); // '>=' because UINT32_MAX means always
+  if (s->nameL[0] == 0 && s->expr.nInsns == 0) {
+    return PdumpFaceSource_Sample(s, s->sample[0]);
+  }
+
+  PdumpFragment frag;
+  if (s->reassemble && Pdump_ParseFragment(pkt, &frag)) {
+    PdumpFaceSource_Reassemble(s, pkt, &frag);
+    return false;
+  }
+
+  PdumpPacketInfo info;
+  if (s->expr.nInsns == 0) {
+    info.name = Pdump_ExtractName(pkt);
+  } else {
+    PdumpPacketInfo_Parse(&info, pkt);
+  }
+  return PdumpFaceSource_Decide(s, &info);
+}
+
+void
+PdumpFaceSource_Clear(PdumpFaceSource* s) {
+  for (int i = 0; i < PdumpReassMaxPackets; ++i) {
+    PdumpReassSlot_Clear(&s->reass[i]);
+  }
 }
 
 PdumpSourceRef gPdumpEthPortSources[RTE_MAX_ETHPORTS];
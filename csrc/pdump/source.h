@@ -7,6 +7,7 @@
 #include "../iface/faceid.h"
 #include "../vendor/pcg_basic.h"
 #include "enum.h"
+#include "filter.h"
 #include <urcu-pointer.h>
 
 typedef struct PdumpSource PdumpSource;
@@ -63,6 +64,17 @@ PdumpSourceRef_Process(PdumpSourceRef* ref, struct rte_mbuf** pkts, uint16_t cou
   return true;
 }
 
+/** @brief Partially received NDNLPv2 packet in PdumpFaceSource reassembly mode. */
+typedef struct PdumpReassSlot {
+  uint64_t seqNumBase;
+  uint32_t lastUsed; ///< PdumpFaceSource.reassClock when last updated
+  uint32_t received; ///< bitmap of received fragIndex
+  uint8_t fragCount; ///< 0 indicates unused slot
+  uint8_t nReceived;
+  uint32_t payloadLen[LpMaxFragments];
+  struct rte_mbuf* frags[LpMaxFragments]; ///< copied fragments
+} PdumpReassSlot;
+
 /** @brief Packet dump from a face on RX or TX direction. */
 typedef struct PdumpFaceSource {
   PdumpSource base;
@@ -70,6 +82,11 @@ typedef struct PdumpFaceSource {
   uint32_t sample[PdumpMaxNames];
   uint16_t nameL[PdumpMaxNames];
   uint8_t nameV[PdumpMaxNames * NameMaxLength];
+  PdumpFilter expr;
+
+  bool reassemble;
+  uint32_t reassClock;
+  PdumpReassSlot reass[PdumpReassMaxPackets];
 } PdumpFaceSource;
 
 /**
@@ -80,6 +97,10 @@ typedef struct PdumpFaceSource {
 __attribute__((nonnull)) bool
 PdumpFaceSource_Filter(PdumpSource* s, struct rte_mbuf* pkt);
 
+/** @brief Release fragments held in reassembly buffer. */
+__attribute__((nonnull)) void
+PdumpFaceSource_Clear(PdumpFaceSource* s);
+
 extern PdumpSourceRef gPdumpEthPortSources[RTE_MAX_ETHPORTS];
 
 /** @brief Packet dump for unmatched frames on an Ethernet port, contextual information. */
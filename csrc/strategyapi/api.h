@@ -4,6 +4,7 @@
 /** @file */
 
 #include "../strategycode/sec.h"
+#include "face.h"
 #include "fib.h"
 #include "packet.h"
 #include "pit.h"
@@ -117,6 +118,15 @@ typedef enum SgForwardInterestResult {
 __attribute__((nonnull)) SgForwardInterestResult
 SgForwardInterest(SgCtx* ctx, FaceID nh);
 
+/**
+ * @brief Retrieve face status.
+ * @param face face ID, typically a FIB nexthop.
+ * @param[out] dst face status snapshot.
+ * @return whether the face exists and is UP; @c dst is populated in either case.
+ */
+__attribute__((nonnull)) bool
+SgGetFaceStatus(SgCtx* ctx, FaceID face, SgFaceStatus* dst);
+
 /**
  * @brief Return Nacks downstream and erase PIT entry.
  * @pre Only available in @c SGEVT_INTEREST .
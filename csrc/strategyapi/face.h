@@ -0,0 +1,35 @@
+#ifndef NDNDPDK_STRATEGYAPI_FACE_H
+#define NDNDPDK_STRATEGYAPI_FACE_H
+
+/** @file */
+
+#include "../core/rttest.h"
+#include "common.h"
+
+/**
+ * @brief Read-only snapshot of face status.
+ *
+ * RTT and congestion information reflects observations made by the current forwarding thread,
+ * across all FIB entries.
+ */
+typedef struct SgFaceStatus {
+  RttValue rtt;             ///< face-wide RTT estimate in TSC unit, zero if unknown
+  TscTime lastCongMark;     ///< when Data/Nack with congestion mark was last received, or zero
+  TscTime lastNack;         ///< when Nack was last received, or zero
+  uint32_t txQueueCount;    ///< number of packets in the before-Tx queue
+  uint32_t txQueueCapacity; ///< capacity of the before-Tx queue
+  int32_t txDevCount;       ///< number of frames in the device TX queue, negative if unknown
+  uint32_t nCongMarks;      ///< number of Data/Nack with congestion mark, wraparound
+  uint32_t nNacks;          ///< number of Nacks, wraparound
+  bool isDown;              ///< face is down or does not exist
+} SgFaceStatus;
+
+/**
+ * @brief Determine whether congestion has been observed on the face since @p since .
+ */
+SUBROUTINE bool
+SgFaceStatus_IsCongestedSince(const SgFaceStatus* st, TscTime since) {
+  return st->lastCongMark != 0 && st->lastCongMark >= since;
+}
+
+#endif // NDNDPDK_STRATEGYAPI_FACE_H
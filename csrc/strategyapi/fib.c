@@ -8,5 +8,12 @@ static_assert(offsetof(SgFibEntryDyn, rtt) == offsetof(FibEntryDyn, rtt), "");
 static_assert(sizeof(SgFibEntry) <= sizeof(FibEntry), "");
 static_assert(offsetof(SgFibEntry, nNexthops) == offsetof(FibEntry, nNexthops), "");
 static_assert(offsetof(SgFibEntry, nexthops) == offsetof(FibEntry, nexthops), "");
+static_assert(offsetof(SgFibEntry, suppress) == offsetof(FibEntry, suppress), "");
+static_assert(sizeof(SgSuppressConfig) == sizeof(PitSuppressConfigCompact), "");
+static_assert(offsetof(SgSuppressConfig, minUs) == offsetof(PitSuppressConfigCompact, minUs), "");
+static_assert(offsetof(SgSuppressConfig, maxUs) == offsetof(PitSuppressConfigCompact, maxUs), "");
+static_assert(offsetof(SgSuppressConfig, multiplier) ==
+                offsetof(PitSuppressConfigCompact, multiplier),
+              "");
 
 static_assert(sizeof(SgFibNexthopFilter) == sizeof(FibNexthopFilter), "");
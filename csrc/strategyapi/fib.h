@@ -13,13 +13,32 @@ typedef struct SgFibEntryDyn {
   RttValue rtt[FibMaxNexthops];
 } SgFibEntryDyn;
 
+/**
+ * @brief PIT suppression configuration.
+ *
+ * All fields are zero if the FIB entry does not override the forwarder-wide configuration.
+ * Strategies should treat @c multiplier as opaque, because uBPF does not support floating point.
+ */
+typedef struct SgSuppressConfig {
+  uint32_t minUs;   ///< initial/minimum suppression duration in microseconds
+  uint32_t maxUs;   ///< maximum suppression duration in microseconds
+  float multiplier; ///< multiplier on each transmission
+} SgSuppressConfig;
+
 typedef struct SgFibEntry {
   uint8_t a_[525];
   uint8_t nNexthops;
   uint8_t b_[2];
   FaceID nexthops[FibMaxNexthops];
+  SgSuppressConfig suppress; ///< per-entry PIT suppression configuration
 } SgFibEntry;
 
+/** @brief Determine whether the FIB entry overrides PIT suppression configuration. */
+SUBROUTINE bool
+SgFibEntry_HasSuppress(const SgFibEntry* entry) {
+  return entry->suppress.maxUs > 0;
+}
+
 typedef uint32_t SgFibNexthopFilter;
 
 SUBROUTINE bool
@@ -9,20 +9,23 @@
 enum {
   TgcSeqNumSize = 1 + 1 + sizeof(uint64_t),
 
-  TgcTokenLength = 10,
+  TgcTokenLength = 14,
   TgcTokenOffsetPatternID = 0,
   TgcTokenOffsetRunNum = 1,
   TgcTokenOffsetTimestamp = 2,
+  TgcTokenOffsetSlot = 10,
 };
 
 __attribute__((nonnull)) static __rte_always_inline void
-TgcToken_Set(LpPitToken* token, uint8_t patternID, uint8_t runNum, TscTime timestamp) {
+TgcToken_Set(LpPitToken* token, uint8_t patternID, uint8_t runNum, TscTime timestamp,
+             uint32_t slot) {
   *token = (LpPitToken){
     .length = TgcTokenLength,
   };
   token->value[TgcTokenOffsetPatternID] = patternID;
   token->value[TgcTokenOffsetRunNum] = runNum;
   *(unaligned_uint64_t*)RTE_PTR_ADD(token->value, TgcTokenOffsetTimestamp) = timestamp;
+  *(unaligned_uint32_t*)RTE_PTR_ADD(token->value, TgcTokenOffsetSlot) = slot;
 }
 
 __attribute__((nonnull)) static __rte_always_inline uint8_t
@@ -40,4 +43,36 @@ TgcToken_GetTimestamp(const LpPitToken* token) {
   return *(const unaligned_uint64_t*)RTE_PTR_ADD(token->value, TgcTokenOffsetTimestamp);
 }
 
+__attribute__((nonnull)) static __rte_always_inline uint32_t
+TgcToken_GetSlot(const LpPitToken* token) {
+  return *(const unaligned_uint32_t*)RTE_PTR_ADD(token->value, TgcTokenOffsetSlot);
+}
+
+/**
+ * @brief Per-pattern window in closed-loop mode.
+ *
+ * Each slot contains the transmission time of an outstanding Interest, or zero if it is free.
+ * TX thread occupies a free slot when sending an Interest, and frees a slot upon timeout.
+ * RX thread frees a slot upon receiving Data or Nack, if the slot still belongs to the same
+ * Interest as indicated by the timestamp in PIT token.
+ */
+typedef struct TgcWindow {
+  uint64_t nCompleted; ///< (RX thread) Interests completed by Data or Nack
+
+  uint64_t nSent __rte_cache_aligned; ///< (TX thread) Interests sent
+  uint64_t nTimeouts;                 ///< (TX thread) Interests timed out
+  TscTime nextSweep;                  ///< (TX thread) when to check for timeouts
+  TscDuration timeout;                ///< InterestLifetime
+  uint32_t capacity;                  ///< maximum outstanding Interests
+  uint32_t pos;                       ///< (TX thread) next slot to check
+  TscTime slots[];
+} TgcWindow;
+
+/** @brief Free a slot if it still belongs to the Interest sent at @p sendTime . */
+__attribute__((nonnull)) static __rte_always_inline bool
+TgcWindow_Free(TgcWindow* win, uint32_t slot, TscTime sendTime) {
+  return __atomic_compare_exchange_n(&win->slots[slot], &sendTime, 0, false, __ATOMIC_RELEASE,
+                                     __ATOMIC_RELAXED);
+}
+
 #endif // NDNDPDK_TGCONSUMER_COMMON_H
@@ -29,14 +29,16 @@ TgcRx_ProcessData(TgcRx* cr, Packet* npkt, uint8_t id, TscTime sendTime) {
   TgcRxPattern* pattern = &cr->pattern[id];
   const PData* data = Packet_GetDataHdr(npkt);
 
-  uint64_t seqNum;
-  if (unlikely(!TgcRx_GetSeqNumFromName(cr, pattern, &data->name, &seqNum))) {
+  uint64_t seqNum = 0;
+  if (!cr->trace && unlikely(!TgcRx_GetSeqNumFromName(cr, pattern, &data->name, &seqNum))) {
     return;
   }
 
   N_LOGD(">D pattern=%" PRIu8 " seq=%" PRIx64, id, seqNum);
   TscTime recvTime = Mbuf_GetTimestamp(Packet_ToMbuf(npkt));
-  RunningStatI_Push(&pattern->rtt, recvTime - sendTime);
+  TscDuration rtt = recvTime - sendTime;
+  RunningStatI_Push(&pattern->rtt, rtt);
+  pattern->nHits += (uint64_t)(rtt < pattern->hitRtt);
 }
 
 __attribute__((nonnull)) static void
@@ -44,8 +46,8 @@ TgcRx_ProcessNack(TgcRx* cr, Packet* npkt, uint8_t id) {
   TgcRxPattern* pattern = &cr->pattern[id];
   const PNack* nack = Packet_GetNackHdr(npkt);
 
-  uint64_t seqNum;
-  if (unlikely(!TgcRx_GetSeqNumFromName(cr, pattern, &nack->interest.name, &seqNum))) {
+  uint64_t seqNum = 0;
+  if (!cr->trace && unlikely(!TgcRx_GetSeqNumFromName(cr, pattern, &nack->interest.name, &seqNum))) {
     return;
   }
 
@@ -53,6 +55,18 @@ TgcRx_ProcessNack(TgcRx* cr, Packet* npkt, uint8_t id) {
   ++pattern->nNacks;
 }
 
+__attribute__((nonnull)) static __rte_always_inline void
+TgcRx_CompleteWindow(TgcRx* cr, uint8_t id, const LpPitToken* token) {
+  TgcWindow* win = cr->pattern[id].window;
+  if (win == NULL) {
+    return;
+  }
+  uint32_t slot = TgcToken_GetSlot(token);
+  if (likely(slot < win->capacity) && TgcWindow_Free(win, slot, TgcToken_GetTimestamp(token))) {
+    __atomic_store_n(&win->nCompleted, win->nCompleted + 1, __ATOMIC_RELEASE);
+  }
+}
+
 int
 TgcRx_Run(TgcRx* cr) {
   struct rte_mbuf* pkts[MaxBurstSize];
@@ -70,6 +84,7 @@ TgcRx_Run(TgcRx* cr) {
       if (unlikely(id >= cr->nPatterns)) {
         continue;
       }
+      TgcRx_CompleteWindow(cr, id, token);
       switch (Packet_GetType(npkt)) {
         case PktData:
           TgcRx_ProcessData(cr, npkt, id, TgcToken_GetTimestamp(token));
@@ -13,6 +13,9 @@
 typedef struct TgcRxPattern {
   uint64_t nNacks;
   RunningStatI rtt;
+  TscDuration hitRtt; ///< if non-zero, Data with shorter RTT is counted as cache hit
+  uint64_t nHits;
+  TgcWindow* window; ///< if not NULL, closed-loop mode is enabled
   uint16_t prefixLen;
 } TgcRxPattern;
 
@@ -22,6 +25,7 @@ typedef struct TgcRx {
   PktQueue rxQueue;
   uint8_t runNum;
   uint8_t nPatterns;
+  bool trace; ///< if true, Interest names do not contain sequence number
   TgcRxPattern pattern[TgcMaxPatterns];
 } TgcRx;
 
@@ -3,6 +3,7 @@
 
 STATIC_ASSERT_FUNC_TYPE(TgcTxPattern_MakeSuffix, TgcTxPattern_MakeSuffix_Digest);
 STATIC_ASSERT_FUNC_TYPE(TgcTxPattern_MakeSuffix, TgcTxPattern_MakeSuffix_Offset);
+STATIC_ASSERT_FUNC_TYPE(TgcTxPattern_MakeSuffix, TgcTxPattern_MakeSuffix_Popularity);
 STATIC_ASSERT_FUNC_TYPE(TgcTxPattern_MakeSuffix, TgcTxPattern_MakeSuffix_Increment);
 
 N_LOG_INIT(Tgc);
@@ -82,6 +83,28 @@ TgcTxPattern_MakeSuffix_Offset(TgcTx* ct, uint8_t patternID, TgcTxPattern* patte
   return TgcSeqNumSize;
 }
 
+uint16_t
+TgcTxPattern_MakeSuffix_Popularity(TgcTx* ct, uint8_t patternID, TgcTxPattern* pattern) {
+  const TgcTxPopularity* pop = pattern->popularity;
+  uint32_t rank = 0;
+  if (pop->uniform) {
+    rank = pcg32_boundedrand_r(&ct->trafficRng, pop->catalogSize);
+  } else {
+    uint32_t r = pcg32_random_r(&ct->trafficRng);
+    uint32_t hi = pop->catalogSize - 1;
+    while (rank < hi) {
+      uint32_t mid = rank + (hi - rank) / 2;
+      if (r < pop->cdf[mid]) {
+        hi = mid;
+      } else {
+        rank = mid + 1;
+      }
+    }
+  }
+  pattern->seqNumV = pop->seqNumBase + rank;
+  return TgcSeqNumSize;
+}
+
 uint16_t
 TgcTxPattern_MakeSuffix_Increment(TgcTx* ct, uint8_t patternID, TgcTxPattern* pattern) {
   ++pattern->seqNumV;
@@ -95,8 +118,7 @@ TgcTx_SelectPattern(TgcTx* ct) {
 }
 
 __attribute__((nonnull)) static __rte_always_inline bool
-TgcTx_MakeInterest(TgcTx* ct, struct rte_mbuf* pkt, TscTime now) {
-  uint8_t id = TgcTx_SelectPattern(ct);
+TgcTx_EncodeInterest(TgcTx* ct, uint8_t id, struct rte_mbuf* pkt, TscTime now, uint32_t slot) {
   TgcTxPattern* pattern = &ct->pattern[id];
   ++pattern->nInterests;
 
@@ -109,11 +131,17 @@ TgcTx_MakeInterest(TgcTx* ct, struct rte_mbuf* pkt, TscTime now) {
   LName suffix = (LName){.length = suffixL, .value = &pattern->seqNumT};
   uint32_t nonce = pcg32_random_r(&ct->nonceRng);
   Packet* npkt = InterestTemplate_Encode(&pattern->tpl, pkt, suffix, nonce);
-  TgcToken_Set(&Packet_GetLpL3Hdr(npkt)->pitToken, id, ct->runNum, now);
-  N_LOGD("<I pattern=%" PRIu8 " seq=%" PRIx64, id, pattern->seqNumV);
+  TgcToken_Set(&Packet_GetLpL3Hdr(npkt)->pitToken, id, ct->runNum, now, slot);
+  N_LOGD("<I pattern=%" PRIu8 " seq=%" PRIx64 " slot=%" PRIu32, id, pattern->seqNumV, slot);
   return true;
 }
 
+__attribute__((nonnull)) static __rte_always_inline bool
+TgcTx_MakeInterest(TgcTx* ct, struct rte_mbuf* pkt, TscTime now) {
+  uint8_t id = TgcTx_SelectPattern(ct);
+  return TgcTx_EncodeInterest(ct, id, pkt, now, 0);
+}
+
 __attribute__((nonnull)) static void
 TgcTx_Burst(TgcTx* ct) {
   struct rte_mbuf* pkts[MaxBurstSize];
@@ -131,8 +159,218 @@ TgcTx_Burst(TgcTx* ct) {
   Face_TxBurst(ct->face, (Packet**)pkts, MaxBurstSize);
 }
 
+__attribute__((nonnull)) static __rte_always_inline void
+TgcTx_MakeTraceInterest(TgcTx* ct, const TgcTraceRecord* rec, struct rte_mbuf* pkt, TscTime now) {
+  TgcTrace* trace = ct->trace;
+  TgcTxPattern* pattern = &ct->pattern[rec->patternID];
+  ++pattern->nInterests;
+
+  LName suffix = (LName){.length = rec->suffixL,
+                         .value = RTE_PTR_ADD(trace->suffixes, rec->suffixOffset)};
+  uint32_t nonce = pcg32_random_r(&ct->nonceRng);
+  Packet* npkt = InterestTemplate_Encode(&trace->tpl[rec->tplID], pkt, suffix, nonce);
+  TgcToken_Set(&Packet_GetLpL3Hdr(npkt)->pitToken, rec->patternID, ct->runNum, now, 0);
+  N_LOGD("<I pattern=%" PRIu8 " trace-pos=%" PRIu32, rec->patternID, trace->pos);
+}
+
+__attribute__((nonnull)) static int
+TgcTx_RunTrace(TgcTx* ct) {
+  TgcTrace* trace = ct->trace;
+  TscTime epoch = rte_get_tsc_cycles();
+  uint32_t count = 0;
+  while (ThreadCtrl_Continue(ct->ctrl, count)) {
+    count = 0;
+    if (unlikely(trace->pos >= trace->nRecords)) {
+      if (!trace->loop) {
+        continue;
+      }
+      trace->pos = 0;
+      ++trace->nLoops;
+      epoch += trace->period;
+    }
+
+    TscTime now = rte_get_tsc_cycles();
+    uint32_t limit = RTE_MIN(trace->nRecords - trace->pos, (uint32_t)MaxBurstSize);
+    while (count < limit && epoch + trace->records[trace->pos + count].offset <= now) {
+      ++count;
+    }
+    if (count == 0) {
+      continue;
+    }
+
+    struct rte_mbuf* pkts[MaxBurstSize];
+    int res = rte_pktmbuf_alloc_bulk(ct->interestMp, pkts, count);
+    if (unlikely(res != 0)) {
+      N_LOGW("interestMp-full");
+      ++ct->nAllocError;
+      count = 0;
+      continue;
+    }
+
+    for (uint32_t i = 0; i < count; ++i) {
+      TgcTx_MakeTraceInterest(ct, &trace->records[trace->pos], pkts[i], now);
+      ++trace->pos;
+    }
+    Face_TxBurst(ct->face, (Packet**)pkts, count);
+  }
+  return 0;
+}
+
+/** @brief Draw an exponentially distributed interval between two Interests. */
+__attribute__((nonnull)) static __rte_always_inline TscDuration
+TgcTx_PoissonInterval(TgcTx* ct) {
+  double u = ((double)pcg32_random_r(&ct->trafficRng) + 1.0) / ((double)UINT32_MAX + 1.0);
+  return (TscDuration)(-log(u) * ct->burstInterval / MaxBurstSize);
+}
+
+__attribute__((nonnull)) static int
+TgcTx_RunPoisson(TgcTx* ct) {
+  TscTime nextTx = rte_get_tsc_cycles();
+  uint32_t count = 0;
+  while (ThreadCtrl_Continue(ct->ctrl, count)) {
+    count = 0;
+    TscTime now = rte_get_tsc_cycles();
+    while (count < MaxBurstSize && nextTx <= now) {
+      ++count;
+      nextTx += TgcTx_PoissonInterval(ct);
+    }
+    if (count == 0) {
+      continue;
+    }
+
+    struct rte_mbuf* pkts[MaxBurstSize];
+    int res = rte_pktmbuf_alloc_bulk(ct->interestMp, pkts, count);
+    if (unlikely(res != 0)) {
+      N_LOGW("interestMp-full");
+      ++ct->nAllocError;
+      continue;
+    }
+
+    for (uint32_t i = 0; i < count; ++i) {
+      while (!likely(TgcTx_MakeInterest(ct, pkts[i], now))) {
+      }
+    }
+    Face_TxBurst(ct->face, (Packet**)pkts, count);
+  }
+  return 0;
+}
+
+/** @brief Free slots of timed out Interests. */
+__attribute__((nonnull)) static void
+TgcWindow_Sweep(TgcWindow* win, TscTime now) {
+  win->nextSweep = now + (win->timeout >> 4);
+  for (uint32_t slot = 0; slot < win->capacity; ++slot) {
+    TscTime sendTime = __atomic_load_n(&win->slots[slot], __ATOMIC_ACQUIRE);
+    if (sendTime != 0 && sendTime + win->timeout < now && TgcWindow_Free(win, slot, sendTime)) {
+      ++win->nTimeouts;
+    }
+  }
+}
+
+/**
+ * @brief Occupy a free slot.
+ * @return slot index, or UINT32_MAX if the window is full.
+ */
+__attribute__((nonnull)) static __rte_always_inline uint32_t
+TgcWindow_Occupy(TgcWindow* win, TscTime now) {
+  uint64_t nCompleted = __atomic_load_n(&win->nCompleted, __ATOMIC_ACQUIRE);
+  if (win->nSent - nCompleted - win->nTimeouts >= win->capacity) {
+    if (now < win->nextSweep) {
+      return UINT32_MAX;
+    }
+    TgcWindow_Sweep(win, now);
+    if (win->nSent - nCompleted - win->nTimeouts >= win->capacity) {
+      return UINT32_MAX;
+    }
+  }
+
+  // a free slot exists, because RX thread frees a slot before incrementing nCompleted
+  while (__atomic_load_n(&win->slots[win->pos], __ATOMIC_ACQUIRE) != 0) {
+    if (++win->pos == win->capacity) {
+      win->pos = 0;
+    }
+  }
+  uint32_t slot = win->pos;
+  __atomic_store_n(&win->slots[slot], now, __ATOMIC_RELEASE);
+  ++win->nSent;
+  return slot;
+}
+
+/** @brief Release a slot occupied by an Interest that was not sent. */
+__attribute__((nonnull)) static __rte_always_inline void
+TgcWindow_Release(TgcWindow* win, uint32_t slot) {
+  __atomic_store_n(&win->slots[slot], 0, __ATOMIC_RELEASE);
+  --win->nSent;
+}
+
+__attribute__((nonnull)) static int
+TgcTx_RunClosedLoop(TgcTx* ct) {
+  uint8_t nextPattern = 0;
+  uint32_t count = 0;
+  while (ThreadCtrl_Continue(ct->ctrl, count)) {
+    count = 0;
+    TscTime now = rte_get_tsc_cycles();
+    uint8_t ids[MaxBurstSize];
+    uint32_t slots[MaxBurstSize];
+    for (uint8_t i = 0; i < ct->nPatterns && count < MaxBurstSize; ++i) {
+      uint8_t id = nextPattern;
+      if (++nextPattern == ct->nPatterns) {
+        nextPattern = 0;
+      }
+      TgcWindow* win = ct->pattern[id].window;
+      while (count < MaxBurstSize) {
+        uint32_t slot = TgcWindow_Occupy(win, now);
+        if (slot == UINT32_MAX) {
+          break;
+        }
+        ids[count] = id;
+        slots[count] = slot;
+        ++count;
+      }
+    }
+    if (count == 0) {
+      continue;
+    }
+
+    struct rte_mbuf* pkts[MaxBurstSize];
+    int res = rte_pktmbuf_alloc_bulk(ct->interestMp, pkts, count);
+    if (unlikely(res != 0)) {
+      N_LOGW("interestMp-full");
+      ++ct->nAllocError;
+      for (uint32_t i = 0; i < count; ++i) {
+        TgcWindow_Release(ct->pattern[ids[i]].window, slots[i]);
+      }
+      continue;
+    }
+
+    uint32_t nPkts = 0;
+    for (uint32_t i = 0; i < count; ++i) {
+      if (likely(TgcTx_EncodeInterest(ct, ids[i], pkts[nPkts], now, slots[i]))) {
+        ++nPkts;
+      } else {
+        TgcWindow_Release(ct->pattern[ids[i]].window, slots[i]);
+      }
+    }
+    if (unlikely(nPkts < count)) {
+      rte_pktmbuf_free_bulk(&pkts[nPkts], count - nPkts);
+    }
+    Face_TxBurst(ct->face, (Packet**)pkts, nPkts);
+  }
+  return 0;
+}
+
 int
 TgcTx_Run(TgcTx* ct) {
+  if (ct->trace != NULL) {
+    return TgcTx_RunTrace(ct);
+  }
+  if (ct->closedLoop) {
+    return TgcTx_RunClosedLoop(ct);
+  }
+  if (ct->poisson) {
+    return TgcTx_RunPoisson(ct);
+  }
+
   TscTime nextTxBurst = rte_get_tsc_cycles();
   int sent = 0;
   while (ThreadCtrl_Continue(ct->ctrl, sent)) {
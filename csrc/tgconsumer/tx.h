@@ -19,6 +19,19 @@ typedef struct TgcTxDigestPattern {
   LName prefix;
 } TgcTxDigestPattern;
 
+/** @brief Popularity distribution of sequence numbers within a catalog. */
+typedef struct TgcTxPopularity {
+  uint64_t seqNumBase;  ///< sequence number of the most popular name
+  uint32_t catalogSize; ///< number of names in the catalog
+  bool uniform;         ///< if true, cdf is unused
+  /**
+   * @brief Cumulative distribution function, scaled to UINT32_MAX.
+   *
+   * cdf[i] is the probability of selecting a name with rank less than or equal to i.
+   */
+  uint32_t cdf[];
+} TgcTxPopularity;
+
 typedef uint16_t (*TgcTxPattern_MakeSuffix)(TgcTx* ct, uint8_t patternID, TgcTxPattern* pattern);
 
 __attribute__((nonnull)) uint16_t
@@ -26,6 +39,8 @@ TgcTxPattern_MakeSuffix_Digest(TgcTx* ct, uint8_t patternID, TgcTxPattern* patte
 __attribute__((nonnull)) uint16_t
 TgcTxPattern_MakeSuffix_Offset(TgcTx* ct, uint8_t patternID, TgcTxPattern* pattern);
 __attribute__((nonnull)) uint16_t
+TgcTxPattern_MakeSuffix_Popularity(TgcTx* ct, uint8_t patternID, TgcTxPattern* pattern);
+__attribute__((nonnull)) uint16_t
 TgcTxPattern_MakeSuffix_Increment(TgcTx* ct, uint8_t patternID, TgcTxPattern* pattern);
 
 /** @brief Per-pattern information in traffic generator consumer. */
@@ -44,8 +59,10 @@ struct TgcTxPattern {
   union {
     TgcTxDigestPattern* digest;
     uint64_t seqNumOffset;
+    TgcTxPopularity* popularity;
   };
 
+  TgcWindow* window; ///< if not NULL, closed-loop mode is enabled
   InterestTemplate tpl;
 };
 static_assert(offsetof(TgcTxPattern, seqNumL) + 1 == offsetof(TgcTxPattern, seqNumV), "");
@@ -53,14 +70,39 @@ static_assert(offsetof(TgcTxPattern, seqNumT) + TgcSeqNumSize + ImplicitDigestSi
                 offsetof(TgcTxPattern, digestV) + RTE_SIZEOF_FIELD(TgcTxPattern, digestV),
               "");
 
+/** @brief Interest in trace-driven replay. */
+typedef struct TgcTraceRecord {
+  TscDuration offset;    ///< transmission time relative to start of loop
+  uint32_t suffixOffset; ///< name suffix offset in TgcTrace.suffixes
+  uint16_t suffixL;      ///< name suffix length
+  uint16_t tplID;        ///< InterestTemplate index in TgcTrace.tpl
+  uint8_t patternID;     ///< pattern for counters
+} TgcTraceRecord;
+
+/** @brief Trace-driven replay in traffic generator consumer. */
+typedef struct TgcTrace {
+  InterestTemplate* tpl;   ///< InterestTemplate array, each has a pattern prefix
+  const uint8_t* suffixes; ///< concatenated name suffixes
+  TscDuration period;      ///< duration of one loop
+  uint64_t nLoops;         ///< number of completed loops
+  uint32_t pos;            ///< next record index
+  uint32_t nRecords;
+  bool loop;
+  TgcTraceRecord records[];
+} TgcTrace;
+
 /** @brief Traffic generator consumer TX thread. */
 struct TgcTx {
   ThreadCtrl ctrl;
   uint32_t nWeights;
   FaceID face;
   uint8_t runNum;
+  uint8_t nPatterns;
+  bool poisson;    ///< if true, use Poisson arrival in open-loop mode
+  bool closedLoop; ///< if true, send Interests whenever a pattern has room in its window
   struct rte_mempool* interestMp;
   TscDuration burstInterval; ///< interval between two bursts
+  TgcTrace* trace;           ///< if not NULL, replay trace instead of random patterns
 
   pcg32_random_t trafficRng;
   pcg32_random_t nonceRng;
@@ -12,8 +12,10 @@ typedef struct TgpBurstCtx {
   PktQueuePopResult pop;
   uint16_t nDiscard;
   uint16_t nTx;
+  uint16_t nSign;
   Packet* rx[MaxBurstSize];
   Packet* tx[MaxBurstSize];
+  struct rte_crypto_op* sign[MaxBurstSize];
 } TgpBurstCtx;
 
 __attribute__((nonnull(1))) static inline void
@@ -32,6 +34,60 @@ TgpBurstCtx_Discard(TgpBurstCtx* ctx, uint16_t i) {
   ctx->rx[ctx->nDiscard++] = ctx->rx[i];
 }
 
+/**
+ * @brief Append SignatureValue and finish encoding signed Data.
+ * @param pkt Data with SignatureInfo appended, whose LpL3 contains the PIT token.
+ * @param sigValueL SignatureValue TLV length; 0 indicates signing failure.
+ * @return encoded packet, or NULL upon failure.
+ * @post If failure, @p pkt is freed.
+ */
+__attribute__((nonnull)) static Packet*
+Tgp_FinishSigned(Tgp* p, struct rte_mbuf* pkt, const uint8_t* sigValue, uint16_t sigValueL,
+                 PacketMempools* mp, PacketTxAlign align) {
+  if (unlikely(sigValueL == 0)) {
+    ++p->nSignErrors;
+    rte_pktmbuf_free(pkt);
+    return NULL;
+  }
+
+  LpPitToken token = Packet_GetLpL3Hdr(Packet_FromMbuf(pkt))->pitToken;
+  if (unlikely(!DataEnc_Append(pkt, sigValue, sigValueL, mp, align))) {
+    ++p->nAllocError;
+    return NULL;
+  }
+  Packet* output = DataEnc_Finish(pkt);
+  Packet_GetLpL3Hdr(output)->pitToken = token;
+  ++p->nSigned;
+  return output;
+}
+
+__attribute__((nonnull)) static void
+Tgp_RespondSigned(TgpBurstCtx* ctx, Packet* npkt, LName dataPrefix, TgpReply* reply) {
+  TgpSigner* signer = reply->signer;
+  struct rte_mbuf* pkt =
+    DataGen_EncodeUnsigned(&reply->dataGen, dataPrefix, &ctx->mp, ctx->faceTxAlign);
+  if (unlikely(pkt == NULL ||
+               !DataEnc_Append(pkt, signer->sigInfo, signer->sigInfoL, &ctx->mp,
+                               ctx->faceTxAlign))) {
+    TgpBurstCtx_Tx(ctx, NULL);
+    return;
+  }
+  Packet_GetLpL3Hdr(Packet_FromMbuf(pkt))->pitToken = Packet_GetLpL3Hdr(npkt)->pitToken;
+
+  if (signer->sess != NULL) {
+    ctx->sign[ctx->nSign++] = TgpSigner_Prepare(signer, pkt);
+    return;
+  }
+
+  uint8_t sigValue[TgpMaxSigValueLen];
+  uint16_t sigValueL = TgpSigner_Sign(signer, pkt, sigValue);
+  Packet* output =
+    Tgp_FinishSigned(ctx->p, pkt, sigValue, sigValueL, &ctx->mp, ctx->faceTxAlign);
+  if (likely(output != NULL)) {
+    TgpBurstCtx_Tx(ctx, output);
+  }
+}
+
 __attribute__((nonnull)) static void
 Tgp_RespondData(TgpBurstCtx* ctx, uint16_t i, TgpReply* reply) {
   Packet* npkt = ctx->rx[i];
@@ -41,6 +97,12 @@ Tgp_RespondData(TgpBurstCtx* ctx, uint16_t i, TgpReply* reply) {
     dataPrefix.length -= ImplicitDigestSize;
   }
 
+  if (unlikely(reply->signer != NULL)) {
+    Tgp_RespondSigned(ctx, npkt, dataPrefix, reply);
+    TgpBurstCtx_Discard(ctx, i);
+    return;
+  }
+
   Packet* output = DataGen_Encode(&reply->dataGen, dataPrefix, &ctx->mp, ctx->faceTxAlign);
   if (likely(output != NULL)) {
     Packet_GetLpL3Hdr(output)->pitToken = Packet_GetLpL3Hdr(npkt)->pitToken;
@@ -91,6 +153,50 @@ Tgp_ProcessInterest(Tgp* p, TgpBurstCtx* ctx, uint16_t i) {
   Tgp_RespondJmp[reply->kind](ctx, i, reply);
 }
 
+/** @brief Submit offloaded signing operations to the crypto device. */
+__attribute__((nonnull)) static void
+Tgp_EnqueueSign(Tgp* p, TgpBurstCtx* ctx) {
+  uint16_t nEnq = rte_cryptodev_enqueue_burst(p->cqp.dev, p->cqp.qp, ctx->sign, ctx->nSign);
+  for (uint16_t i = nEnq; i < ctx->nSign; ++i) {
+    rte_pktmbuf_free(ctx->sign[i]->sym->m_src);
+  }
+  p->nSignDrops += ctx->nSign - nEnq;
+}
+
+/**
+ * @brief Retrieve completed signing operations from the crypto device.
+ * @param tx if true, transmit signed Data; otherwise, discard them.
+ * @return number of completed operations.
+ */
+__attribute__((nonnull)) static uint16_t
+Tgp_DequeueSign(Tgp* p, TgpBurstCtx* ctx, bool tx) {
+  struct rte_crypto_op* ops[MaxBurstSize];
+  uint16_t nDeq = rte_cryptodev_dequeue_burst(p->cqp.dev, p->cqp.qp, ops, MaxBurstSize);
+  if (unlikely(!tx)) {
+    for (uint16_t i = 0; i < nDeq; ++i) {
+      rte_pktmbuf_free(ops[i]->sym->m_src);
+    }
+    return nDeq;
+  }
+
+  Packet* txSigned[MaxBurstSize];
+  uint16_t nSigned = 0;
+  for (uint16_t i = 0; i < nDeq; ++i) {
+    struct rte_mbuf* pkt = ops[i]->sym->m_src;
+    uint8_t sigValue[TgpMaxSigValueLen];
+    uint16_t sigValueL = TgpSigner_Finish(ops[i], sigValue);
+    Packet* output = Tgp_FinishSigned(p, pkt, sigValue, sigValueL, &ctx->mp, ctx->faceTxAlign);
+    if (likely(output != NULL)) {
+      Mbuf_SetTimestamp(Packet_ToMbuf(output), ctx->now);
+      txSigned[nSigned++] = output;
+    }
+  }
+
+  N_LOGD("sign face=%" PRI_FaceID " nDeq=%" PRIu16 " nTx=%" PRIu16, p->face, nDeq, nSigned);
+  Face_TxBurst(p->face, txSigned, nSigned);
+  return nDeq;
+}
+
 int
 Tgp_Run(Tgp* p) {
   TgpBurstCtx ctx = {
@@ -98,8 +204,14 @@ Tgp_Run(Tgp* p) {
     .mp = p->mp,
     .faceTxAlign = Face_PacketTxAlign(p->face),
   };
-  while (ThreadCtrl_Continue(p->ctrl, ctx.pop.count)) {
+  bool hasCrypto = p->cqp.sha256sess != NULL;
+  uint16_t nDeq = 0;
+  while (ThreadCtrl_Continue(p->ctrl, ctx.pop.count + nDeq)) {
     ctx.now = rte_get_tsc_cycles();
+    if (hasCrypto) {
+      nDeq = Tgp_DequeueSign(p, &ctx, true);
+    }
+
     ctx.pop = PktQueue_Pop(&p->rxQueue, (struct rte_mbuf**)ctx.rx, MaxBurstSize, ctx.now);
     if (unlikely(ctx.pop.count == 0)) {
       continue;
@@ -107,17 +219,25 @@ Tgp_Run(Tgp* p) {
 
     ctx.nDiscard = 0;
     ctx.nTx = 0;
+    ctx.nSign = 0;
     for (uint16_t i = 0; i < ctx.pop.count; ++i) {
       NDNDPDK_ASSERT(Packet_GetType(ctx.rx[i]) == PktInterest);
       Tgp_ProcessInterest(p, &ctx, i);
     }
 
-    N_LOGD("burst face=%" PRI_FaceID "nRx=%" PRIu16 " nTx=%" PRIu16, p->face, ctx.pop.count,
-           ctx.nTx);
+    N_LOGD("burst face=%" PRI_FaceID "nRx=%" PRIu16 " nTx=%" PRIu16 " nSign=%" PRIu16, p->face,
+           ctx.pop.count, ctx.nTx, ctx.nSign);
     Face_TxBurst(p->face, ctx.tx, ctx.nTx);
+    if (ctx.nSign > 0) {
+      Tgp_EnqueueSign(p, &ctx);
+    }
     if (likely(ctx.nDiscard > 0)) {
       rte_pktmbuf_free_bulk((struct rte_mbuf**)ctx.rx, ctx.nDiscard);
     }
   }
+
+  while (hasCrypto && Tgp_DequeueSign(p, &ctx, false) > 0) {
+    // discard Data whose signing completes after the thread is stopped
+  }
   return 0;
 }
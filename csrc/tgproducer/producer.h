@@ -8,12 +8,14 @@
 #include "../iface/pktqueue.h"
 #include "../vendor/pcg_basic.h"
 #include "enum.h"
+#include "signer.h"
 
 typedef uint8_t TgpReplyID;
 
 typedef struct TgpReply {
   uint64_t nInterests;
   DataGen dataGen;
+  TgpSigner* signer; ///< if not NULL, Data is signed by this signer
   uint8_t kind;
   uint8_t nackReason;
 } TgpReply;
@@ -31,11 +33,15 @@ typedef struct Tgp {
   ThreadCtrl ctrl;
   PktQueue rxQueue;
   PacketMempools mp; ///< mempools for Data encoding
+  CryptoQueuePair cqp; ///< crypto queue pair for offloaded signing
   FaceID face;
   uint8_t nPatterns;
 
   uint64_t nNoMatch;
   uint64_t nAllocError;
+  uint64_t nSigned;     ///< Data with computed signature
+  uint64_t nSignDrops;  ///< Data dropped due to full crypto queue
+  uint64_t nSignErrors; ///< Data dropped due to signing failure
   pcg32_random_t replyRng;
 
   uint16_t prefixL[TgpMaxPatterns];
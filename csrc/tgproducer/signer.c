@@ -0,0 +1,103 @@
+#include "signer.h"
+
+bool
+TgpSigner_Init(TgpSigner* signer, const uint8_t* key, size_t keyLen) {
+  switch (signer->sigType) {
+    case SigHmacWithSha256:
+      signer->key = EVP_PKEY_new_raw_private_key(EVP_PKEY_HMAC, NULL, key, keyLen);
+      break;
+    case SigSha256WithEcdsa: {
+      const uint8_t* der = key;
+      signer->key = d2i_AutoPrivateKey(NULL, &der, keyLen);
+      if (signer->key == NULL) {
+        break;
+      }
+      signer->pkeyCtx = EVP_PKEY_CTX_new(signer->key, NULL);
+      if (signer->pkeyCtx == NULL || EVP_PKEY_sign_init(signer->pkeyCtx) != 1 ||
+          EVP_PKEY_CTX_set_signature_md(signer->pkeyCtx, EVP_sha256()) != 1) {
+        goto FAIL;
+      }
+      break;
+    }
+    default:
+      NDNDPDK_ASSERT(false);
+  }
+  if (signer->key == NULL) {
+    goto FAIL;
+  }
+
+  signer->mdCtx = EVP_MD_CTX_new();
+  if (signer->mdCtx == NULL) {
+    goto FAIL;
+  }
+  return true;
+
+FAIL:
+  TgpSigner_Uninit(signer);
+  return false;
+}
+
+void
+TgpSigner_Uninit(TgpSigner* signer) {
+  EVP_MD_CTX_free(signer->mdCtx);
+  signer->mdCtx = NULL;
+  EVP_PKEY_CTX_free(signer->pkeyCtx);
+  signer->pkeyCtx = NULL;
+  EVP_PKEY_free(signer->key);
+  signer->key = NULL;
+}
+
+__attribute__((nonnull)) static inline uint16_t
+TgpSigner_EncodeSigValue(uint8_t* sigValue, size_t sigLen) {
+  sigValue[0] = TtDSigValue;
+  sigValue[1] = sigLen;
+  return 2 + sigLen;
+}
+
+uint16_t
+TgpSigner_Sign(TgpSigner* signer, struct rte_mbuf* pkt, uint8_t* sigValue) {
+  EVP_MD_CTX_reset(signer->mdCtx);
+  if (unlikely(EVP_DigestSignInit(signer->mdCtx, NULL, EVP_sha256(), NULL, signer->key) != 1)) {
+    return 0;
+  }
+  for (struct rte_mbuf* m = pkt; m != NULL; m = m->next) {
+    if (unlikely(EVP_DigestSignUpdate(signer->mdCtx, rte_pktmbuf_mtod(m, const uint8_t*),
+                                      m->data_len) != 1)) {
+      return 0;
+    }
+  }
+
+  size_t sigLen = TgpMaxSigValueLen - 2;
+  if (unlikely(EVP_DigestSignFinal(signer->mdCtx, &sigValue[2], &sigLen) != 1)) {
+    return 0;
+  }
+  return TgpSigner_EncodeSigValue(sigValue, sigLen);
+}
+
+uint16_t
+TgpSigner_Finish(struct rte_crypto_op* op, uint8_t* sigValue) {
+  NDNDPDK_ASSERT(op->mempool == NULL);
+  if (unlikely(op->status != RTE_CRYPTO_OP_STATUS_SUCCESS)) {
+    return 0;
+  }
+  TgpSignPriv* priv = container_of(op, TgpSignPriv, op);
+  const uint8_t* digest = TgpSignPriv_Digest(op->sym->m_src);
+
+  size_t sigLen = 0;
+  switch (priv->signer->sigType) {
+    case SigHmacWithSha256:
+      sigLen = ImplicitDigestLength;
+      rte_memcpy(&sigValue[2], digest, sigLen);
+      break;
+    case SigSha256WithEcdsa:
+      sigLen = TgpMaxSigValueLen - 2;
+      if (unlikely(EVP_PKEY_sign(priv->signer->pkeyCtx, &sigValue[2], &sigLen, digest,
+                                 ImplicitDigestLength) != 1)) {
+        return 0;
+      }
+      break;
+    default:
+      NDNDPDK_ASSERT(false);
+  }
+  return TgpSigner_EncodeSigValue(sigValue, sigLen);
+}
@@ -0,0 +1,109 @@
+#ifndef NDNDPDK_TGPRODUCER_SIGNER_H
+#define NDNDPDK_TGPRODUCER_SIGNER_H
+
+/** @file */
+
+#include "../dpdk/cryptodev.h"
+#include "../ndni/packet.h"
+#include "enum.h"
+
+#include <openssl/evp.h>
+
+enum {
+  /** @brief Maximum length of SignatureValue TLV, which may contain DER-encoded ECDSA signature. */
+  TgpMaxSigValueLen = 2 + 72,
+};
+
+/** @brief Data signer in traffic generator producer. */
+typedef struct TgpSigner {
+  EVP_PKEY* key;
+  EVP_MD_CTX* mdCtx;     ///< software signing context
+  EVP_PKEY_CTX* pkeyCtx; ///< ECDSA signing context for offloaded digest
+  /**
+   * @brief Crypto device session.
+   *
+   * For HMAC-SHA256, it is a HMAC-SHA256 session that computes the signature.
+   * For ECDSA, it is a SHA256 session that computes the digest, which is then signed in software.
+   * If NULL, the signature is computed in software.
+   */
+  void* sess;
+  uint8_t sigType;
+  uint16_t sigInfoL;
+  uint8_t sigInfo[TgpMaxSigInfoLen];
+} TgpSigner;
+
+/**
+ * @brief Initialize OpenSSL contexts of a signer.
+ * @param key for HMAC-SHA256, secret key; for ECDSA, PKCS#8 private key.
+ * @pre sigType and sigInfo are assigned.
+ * @return whether success.
+ */
+__attribute__((nonnull)) bool
+TgpSigner_Init(TgpSigner* signer, const uint8_t* key, size_t keyLen);
+
+/** @brief Release OpenSSL contexts of a signer. */
+__attribute__((nonnull)) void
+TgpSigner_Uninit(TgpSigner* signer);
+
+/** @brief Per-packet signing state, placed in PData.helperScratch of the Data being signed. */
+typedef struct TgpSignPriv {
+  struct rte_crypto_op op;
+  uint8_t opSym_[sizeof(struct rte_crypto_sym_op)];
+  TgpSigner* signer;
+} TgpSignPriv;
+
+/**
+ * @brief Access per-packet signing state.
+ * @param pkt result of @c DataGen_EncodeUnsigned .
+ */
+__attribute__((nonnull, returns_nonnull)) static inline TgpSignPriv*
+TgpSignPriv_Get(struct rte_mbuf* pkt) {
+  PData* data = &Packet_GetPriv_(Packet_FromMbuf(pkt))->data;
+  static_assert(sizeof(TgpSignPriv) <= sizeof(data->helperScratch), "");
+  return (TgpSignPriv*)data->helperScratch;
+}
+
+/**
+ * @brief Access digest buffer for offloaded signing.
+ * @param pkt result of @c DataGen_EncodeUnsigned .
+ */
+__attribute__((nonnull, returns_nonnull)) static inline uint8_t*
+TgpSignPriv_Digest(struct rte_mbuf* pkt) {
+  return Packet_GetPriv_(Packet_FromMbuf(pkt))->data.digest;
+}
+
+/**
+ * @brief Prepare a crypto_op for offloaded signing.
+ * @param pkt Data with SignatureInfo appended.
+ * @pre signer->sess is not NULL.
+ * @return rte_crypto_op placed in PData.helperScratch.
+ */
+__attribute__((nonnull, returns_nonnull)) static inline struct rte_crypto_op*
+TgpSigner_Prepare(TgpSigner* signer, struct rte_mbuf* pkt) {
+  TgpSignPriv* priv = TgpSignPriv_Get(pkt);
+  priv->op.mempool = NULL;
+  priv->op.phys_addr = 0;
+  priv->signer = signer;
+  CryptoOp_PrepareAuth(signer->sess, &priv->op, pkt, 0, pkt->pkt_len, TgpSignPriv_Digest(pkt));
+  return &priv->op;
+}
+
+/**
+ * @brief Compute signature in software.
+ * @param pkt Data with SignatureInfo appended.
+ * @param[out] sigValue SignatureValue TLV; must have room for @c TgpMaxSigValueLen octets.
+ * @return SignatureValue TLV length, or 0 upon failure.
+ */
+__attribute__((nonnull)) uint16_t
+TgpSigner_Sign(TgpSigner* signer, struct rte_mbuf* pkt, uint8_t* sigValue);
+
+/**
+ * @brief Compute signature from an offloaded crypto_op.
+ * @param op a dequeued crypto_op prepared by @c TgpSigner_Prepare .
+ * @param[out] sigValue SignatureValue TLV; must have room for @c TgpMaxSigValueLen octets.
+ * @return SignatureValue TLV length, or 0 upon failure.
+ */
+__attribute__((nonnull)) uint16_t
+TgpSigner_Finish(struct rte_crypto_op* op, uint8_t* sigValue);
+
+#endif // NDNDPDK_TGPRODUCER_SIGNER_H
@@ -1,6 +1,7 @@
 package cryptodev_test
 
 import (
+	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
 	"testing"
@@ -61,3 +62,39 @@ func TestCryptoDev(t *testing.T) {
 	assert.Equal(expects[1][:], unsafe.Slice(outputs[1], sha256.Size))
 	assert.Equal(0, qps[0].DequeueBurst(ops))
 }
+
+func TestHmacSession(t *testing.T) {
+	assert, require := makeAR(t)
+
+	cd, e := cryptodev.CreateVDev(cryptodev.VDevConfig{})
+	require.NoError(e)
+	defer cd.Close()
+	qp := cd.QueuePairs()[0]
+
+	_, e = cd.NewHmacSha256Session(nil)
+	assert.Error(e)
+
+	key := make([]byte, 32)
+	rand.Read(key)
+	sess, e := cd.NewHmacSha256Session(key)
+	require.NoError(e)
+	defer sess.Close()
+
+	input := make([]byte, 300)
+	rand.Read(input)
+	mac := hmac.New(sha256.New, key)
+	mac.Write(input[10:])
+	expect := mac.Sum(nil)
+
+	output := eal.Zmalloc[byte]("", sha256.Size, eal.NumaSocket{})
+	defer eal.Free(output)
+	op := eal.Zmalloc[cryptodev.Op]("CryptoOp", unsafe.Sizeof(cryptodev.Op{}), eal.NumaSocket{})
+	defer eal.Free(op)
+	sess.PrepareAuth(op, makePacket(input), 10, len(input)-10, unsafe.Pointer(output))
+
+	ops := cryptodev.OpVector{op}
+	require.Equal(1, qp.EnqueueBurst(ops))
+	require.Equal(1, qp.DequeueBurst(ops))
+	assert.NoError(ops[0].Error())
+	assert.Equal(expect, unsafe.Slice(output, sha256.Size))
+}
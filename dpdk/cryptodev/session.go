@@ -0,0 +1,60 @@
+package cryptodev
+
+/*
+#include "../../csrc/dpdk/cryptodev.h"
+*/
+import "C"
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/dpdk/pktmbuf"
+)
+
+// Session represents a symmetric crypto session.
+// It can be attached to crypto operations submitted to any queue pair of the same CryptoDev.
+type Session struct {
+	c   unsafe.Pointer
+	dev *CryptoDev
+}
+
+// Ptr returns the session pointer.
+func (sess *Session) Ptr() unsafe.Pointer {
+	return sess.c
+}
+
+// PrepareAuth prepares an authentication operation with this session.
+//
+// m[offset:offset+length] is the input to the authentication function.
+// output must have 32 bytes in C memory.
+func (sess *Session) PrepareAuth(op *Op, m *pktmbuf.Packet, offset, length int, output unsafe.Pointer) {
+	C.CryptoOp_PrepareAuth(sess.c, &op.op, (*C.struct_rte_mbuf)(m.Ptr()),
+		C.uint32_t(offset), C.uint32_t(length), (*C.uint8_t)(output))
+}
+
+// Close releases the session.
+func (sess *Session) Close() error {
+	if res := C.rte_cryptodev_sym_session_free(sess.dev.id, sess.c); res < 0 {
+		return fmt.Errorf("rte_cryptodev_sym_session_free error %w", eal.MakeErrno(res))
+	}
+	return nil
+}
+
+// NewHmacSha256Session creates a session for HMAC-SHA256 generation.
+// The output digest has 32 octets.
+func (cd *CryptoDev) NewHmacSha256Session(key []byte) (sess *Session, e error) {
+	if len(key) == 0 || len(key) > 64 {
+		return nil, errors.New("HMAC key length must be between 1 and 64")
+	}
+	keyC := C.CBytes(key)
+	defer C.free(keyC)
+
+	c := C.CryptoDev_CreateHmacSha256Session(cd.id, (*C.struct_rte_mempool)(cd.sessionPool.Ptr()),
+		(*C.uint8_t)(keyC), C.uint16_t(len(key)))
+	if c == nil {
+		return nil, fmt.Errorf("rte_cryptodev_sym_session_create error %w", eal.GetErrno())
+	}
+	return &Session{c: c, dev: cd}, nil
+}
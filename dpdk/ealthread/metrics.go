@@ -0,0 +1,26 @@
+package ealthread
+
+import (
+	"github.com/usnistgov/ndn-dpdk/core/metrics"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+)
+
+func init() {
+	metrics.AddCollector(func(w *metrics.Writer) {
+		for _, lc := range eal.Workers {
+			thObj, ok := activeThread.Load(lc)
+			if !ok {
+				continue
+			}
+			th, ok := thObj.(ThreadWithLoadStat)
+			if !ok {
+				continue
+			}
+
+			st, labels := th.ThreadLoadStat(), []metrics.Label{metrics.L("lcore", lc.ID()), metrics.L("role", allocated[lc.ID()])}
+			w.Counter("thread_empty_polls", "Polls that processed zero item.", st.EmptyPolls, labels...)
+			w.Counter("thread_valid_polls", "Polls that processed non-zero items.", st.ValidPolls, labels...)
+			w.Counter("thread_items", "Count of processed items.", st.Items, labels...)
+		}
+	})
+}
@@ -0,0 +1,21 @@
+package ethdev
+
+import (
+	"github.com/usnistgov/ndn-dpdk/core/metrics"
+)
+
+func init() {
+	metrics.AddCollector(func(w *metrics.Writer) {
+		for _, dev := range List() {
+			stats, label := dev.Stats(), metrics.L("port", dev.Name())
+			w.Counter("ethdev_rx_packets", "RX successfully received packets.", stats.Ipackets, label)
+			w.Counter("ethdev_rx_bytes", "RX successfully received bytes.", stats.Ibytes, label)
+			w.Counter("ethdev_rx_missed", "RX packets dropped by hardware because no RX buffer available.", stats.Imissed, label)
+			w.Counter("ethdev_rx_errors", "RX erroneous packets.", stats.Ierrors, label)
+			w.Counter("ethdev_rx_nombuf", "RX mbuf allocation failures.", stats.Rx_nombuf, label)
+			w.Counter("ethdev_tx_packets", "TX successfully transmitted packets.", stats.Opackets, label)
+			w.Counter("ethdev_tx_bytes", "TX successfully transmitted bytes.", stats.Obytes, label)
+			w.Counter("ethdev_tx_errors", "TX failed packets.", stats.Oerrors, label)
+		}
+	})
+}
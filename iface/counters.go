@@ -22,11 +22,12 @@ type RxCounters struct {
 	RxDecodeErrs   uint64 `json:"rxDecodeErrs" gqldesc:"RX decode errors."`
 	RxReassPackets uint64 `json:"rxReassPackets" gqldesc:"RX packets that were reassembled."`
 	RxReassDrops   uint64 `json:"rxReassDrops" gqldesc:"RX frames that were dropped by reassembler."`
+	RxAcks         uint64 `json:"rxAcks" gqldesc:"RX NDNLPv2 Ack fields."`
 }
 
 func (cnt RxCounters) String() string {
-	return fmt.Sprintf("%dfrm %db %dI %dD %dN %derr reass=(%dpkt %ddrop)",
-		cnt.RxFrames, cnt.RxOctets, cnt.RxInterests, cnt.RxData, cnt.RxNacks, cnt.RxDecodeErrs, cnt.RxReassPackets, cnt.RxReassDrops)
+	return fmt.Sprintf("%dfrm %db %dI %dD %dN %derr reass=(%dpkt %ddrop) %dack",
+		cnt.RxFrames, cnt.RxOctets, cnt.RxInterests, cnt.RxData, cnt.RxNacks, cnt.RxDecodeErrs, cnt.RxReassPackets, cnt.RxReassDrops, cnt.RxAcks)
 }
 
 func (cnt *RxCounters) readFrom(c *C.FaceRxThread) {
@@ -38,6 +39,7 @@ func (cnt *RxCounters) readFrom(c *C.FaceRxThread) {
 	cnt.RxDecodeErrs = uint64(c.nDecodeErr)
 	cnt.RxReassPackets = uint64(c.reass.nDeliverPackets)
 	cnt.RxReassDrops = uint64(c.reass.nDropFragments)
+	cnt.RxAcks = uint64(c.nAcks)
 
 	cnt.RxFrames = cnt.RxInterests + cnt.RxData + cnt.RxNacks - cnt.RxReassPackets + uint64(c.reass.nDeliverFragments) + cnt.RxReassDrops
 }
@@ -54,11 +56,17 @@ type TxCounters struct {
 	TxFragBad   uint64 `json:"txFragBad" gqldesc:"TX fragmentation failures."`
 	TxAllocErrs uint64 `json:"txAllocErrs" gqldesc:"TX allocation errors."`
 	TxDropped   uint64 `json:"txDropped" gqldesc:"TX dropped L2 frames due to full queue."`
+	TxCongMarks uint64 `json:"txCongMarks" gqldesc:"TX L3 packets with congestion mark added due to output queue occupancy."`
+
+	TxAcks uint64 `json:"txAcks" gqldesc:"TX NDNLPv2 Ack fields."`
+	TxRetx uint64 `json:"txRetx" gqldesc:"TX L2 frames retransmitted by NDNLPv2 link reliability."`
+	TxLost uint64 `json:"txLost" gqldesc:"TX L2 frames unacknowledged after maximum retransmissions."`
 }
 
 func (cnt TxCounters) String() string {
-	return fmt.Sprintf("%dfrm %db %dI %dD %dN frag=(%dgood %dbad) alloc=%derr %ddropped",
-		cnt.TxFrames, cnt.TxOctets, cnt.TxInterests, cnt.TxData, cnt.TxNacks, cnt.TxFragGood, cnt.TxFragBad, cnt.TxAllocErrs, cnt.TxDropped)
+	return fmt.Sprintf("%dfrm %db %dI %dD %dN frag=(%dgood %dbad) alloc=%derr %ddropped %dcongmark rel=(%dack %dretx %dlost)",
+		cnt.TxFrames, cnt.TxOctets, cnt.TxInterests, cnt.TxData, cnt.TxNacks, cnt.TxFragGood, cnt.TxFragBad, cnt.TxAllocErrs, cnt.TxDropped, cnt.TxCongMarks,
+		cnt.TxAcks, cnt.TxRetx, cnt.TxLost)
 }
 
 func (cnt *TxCounters) readFrom(c *C.FaceTxThread) {
@@ -72,6 +80,13 @@ func (cnt *TxCounters) readFrom(c *C.FaceTxThread) {
 	cnt.TxFragBad = uint64(c.nL3OverLength + c.nAllocFails)
 	cnt.TxAllocErrs = uint64(c.nAllocFails)
 	cnt.TxDropped = uint64(c.nDroppedFrames)
+	cnt.TxCongMarks = uint64(c.nCongMarks)
+}
+
+func (cnt *TxCounters) readRelFrom(rel *C.LpReliability) {
+	cnt.TxAcks = uint64(rel.nTxAcks)
+	cnt.TxRetx = uint64(rel.nRetx)
+	cnt.TxLost = uint64(rel.nLost)
 }
 
 // Counters contains face counters.
@@ -118,6 +133,9 @@ func (f *face) Counters() (cnt Counters) {
 	cnt.sumRx()
 
 	cnt.TxCounters.readFrom(&c.impl.tx[0])
+	if c.impl.rel != nil {
+		cnt.TxCounters.readRelFrom(c.impl.rel)
+	}
 
 	return cnt
 }
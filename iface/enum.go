@@ -26,6 +26,9 @@ const (
 	// DefaultReassemblerCapacity is the default partial message store capacity in the reassembler.
 	DefaultReassemblerCapacity = 64
 
+	// LpReliabilityWindow is the maximum number of unacknowledged frames retained by NDNLPv2 link reliability.
+	LpReliabilityWindow = 256
+
 	// MinOutputQueueSize is the minimum packet queue capacity before the output thread.
 	MinOutputQueueSize = 256
 
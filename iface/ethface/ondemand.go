@@ -0,0 +1,36 @@
+package ethface
+
+import (
+	"github.com/usnistgov/ndn-dpdk/core/macaddr"
+	"github.com/usnistgov/ndn-dpdk/iface/ethport"
+	"github.com/usnistgov/ndn-dpdk/ndn/packettransport"
+)
+
+func makeOnDemandLocator(port *ethport.Port, peer ethport.OnDemandPeer) ethport.Locator {
+	ether := EtherLocator{
+		FaceConfig: ethport.FaceConfig{EthDev: port.EthDev()},
+		Locator: packettransport.Locator{
+			Local:  macaddr.Flag{HardwareAddr: peer.Local},
+			Remote: macaddr.Flag{HardwareAddr: peer.Remote},
+			VLAN:   peer.VLAN,
+		},
+	}
+	ether.FaceConfig.HideFaceConfigFromJSON()
+	if !peer.IsUDP() {
+		return ether
+	}
+
+	return UDPLocator{
+		IPLocator: IPLocator{
+			EtherLocator: ether,
+			LocalIP:      peer.LocalIP,
+			RemoteIP:     peer.RemoteIP,
+		},
+		LocalUDP:  peer.LocalUDP,
+		RemoteUDP: peer.RemoteUDP,
+	}
+}
+
+func init() {
+	ethport.MakeOnDemandLocator = makeOnDemandLocator
+}
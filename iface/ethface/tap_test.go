@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"net"
 	"net/netip"
+	"slices"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -48,6 +50,7 @@ func (tap *TapFixture) WriteToFromLayers(hdrs ...gopacket.SerializableLayer) {
 func newTapFixture(
 	t testing.TB,
 	makeNetifConfig func(ifname string) ethnetif.Config,
+	modifyPortConfig ...func(cfg *ethport.Config),
 ) *TapFixture {
 	_, require := makeAR(t)
 
@@ -61,9 +64,13 @@ func newTapFixture(
 	e = netlink.LinkSetHardwareAddr(link, macaddr.MakeRandomUnicast())
 	require.NoError(e)
 
-	port, e := ethport.New(ethport.Config{
+	portCfg := ethport.Config{
 		Config: makeNetifConfig(intf.Name()),
-	})
+	}
+	for _, modify := range modifyPortConfig {
+		modify(&portCfg)
+	}
+	port, e := ethport.New(portCfg)
 	require.NoError(e)
 	t.Cleanup(func() { must.Close(port) })
 
@@ -375,3 +382,95 @@ func TestPassthru(t *testing.T) {
 	assert.EqualValues(500, txUDP4.Load())
 	assert.Less(int(txOther.Load())-int(cntPassthru.TxFrames), 50)
 }
+
+func TestOnDemand(t *testing.T) {
+	assert, require := makeAR(t)
+
+	var createdMutex sync.Mutex
+	var created []iface.Face
+	t.Cleanup(ethport.OnOnDemandFaceCreated(func(port *ethport.Port, face iface.Face) {
+		createdMutex.Lock()
+		defer createdMutex.Unlock()
+		created = append(created, face)
+	}))
+
+	tap := newTapFixture(t, func(ifname string) ethnetif.Config {
+		return ethnetif.Config{
+			Driver: ethnetif.DriverAfPacket,
+			Netif:  ifname,
+		}
+	}, func(cfg *ethport.Config) {
+		cfg.OnDemand = &ethport.OnDemandConfig{
+			Ether:       true,
+			UDPPorts:    []int{6363},
+			IdleTimeout: 1000,
+		}
+	})
+	localMAC := tap.Port.EthDev().HardwareAddr()
+	remoteEther := net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x02}
+	remoteUDP4 := net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x03}
+	localIP4, remoteIP4 := netip.MustParseAddr("192.168.2.1"), netip.MustParseAddr("192.168.2.3")
+
+	sendEther := func(i int) {
+		tap.WriteToFromLayers(
+			&layers.Ethernet{SrcMAC: remoteEther, DstMAC: localMAC, EthernetType: an.EtherTypeNDN},
+			makeRxFrame("Ether", i),
+		)
+	}
+	sendUDP4 := func(i int) {
+		tap.WriteToFromLayers(
+			&layers.Ethernet{SrcMAC: remoteUDP4, DstMAC: localMAC, EthernetType: layers.EthernetTypeIPv4},
+			&layers.IPv4{Version: 4, TTL: 64, Protocol: layers.IPProtocolUDP, SrcIP: net.IP(remoteIP4.AsSlice()), DstIP: net.IP(localIP4.AsSlice())},
+			&layers.UDP{SrcPort: 56363, DstPort: 6363},
+			makeRxFrame("UDP4", i),
+		)
+	}
+
+	sendEther(0)
+	sendUDP4(0)
+	time.Sleep(200 * time.Millisecond)
+
+	createdMutex.Lock()
+	faces := slices.Clone(created)
+	createdMutex.Unlock()
+	require.Len(faces, 2)
+	var faceEther, faceUDP4 iface.Face
+	for _, face := range faces {
+		assert.Equal(iface.PersistencyOnDemand, face.Persistency())
+		switch loc := face.Locator().(type) {
+		case ethface.EtherLocator:
+			assert.Equal(remoteEther, loc.Remote.HardwareAddr)
+			faceEther = face
+		case ethface.UDPLocator:
+			assert.Equal(remoteUDP4, loc.Remote.HardwareAddr)
+			assert.Equal(remoteIP4, loc.RemoteIP)
+			assert.Equal(56363, loc.RemoteUDP)
+			faceUDP4 = face
+		}
+	}
+	require.NotNil(faceEther)
+	require.NotNil(faceUDP4)
+
+	// triggering frames are received on the new faces
+	assert.EqualValues(1, faceEther.Counters().RxInterests)
+	assert.EqualValues(1, faceUDP4.Counters().RxInterests)
+
+	for i := 1; i <= 20; i++ {
+		time.Sleep(10 * time.Millisecond)
+		sendEther(i)
+		sendUDP4(i)
+	}
+	time.Sleep(10 * time.Millisecond)
+	assert.EqualValues(21, faceEther.Counters().RxInterests)
+	assert.EqualValues(21, faceUDP4.Counters().RxInterests)
+
+	createdMutex.Lock()
+	assert.Len(created, 2)
+	createdMutex.Unlock()
+
+	// idle faces are closed
+	time.Sleep(3500 * time.Millisecond)
+	assert.Nil(iface.Get(faceEther.ID()))
+	assert.Nil(iface.Get(faceUDP4.ID()))
+	assert.Empty(tap.Port.Faces())
+}
@@ -97,6 +97,10 @@ type Face struct {
 
 // NewFace creates a face on the given port.
 func NewFace(port *Port, loc Locator) (iface.Face, error) {
+	return newFace(port, loc, iface.PersistencyPersistent)
+}
+
+func newFace(port *Port, loc Locator, persistency iface.Persistency) (iface.Face, error) {
 	face := &Face{
 		port:   port,
 		loc:    loc,
@@ -104,13 +108,18 @@ func NewFace(port *Port, loc Locator) (iface.Face, error) {
 	}
 	port, loc = nil, nil
 	return iface.New(iface.NewParams{
-		Config:     face.loc.EthFaceConfig().WithMaxMTU(face.port.cfg.MTU - NewTxHdr(face.loc, false).IPLen()),
-		Socket:     face.port.dev.NumaSocket(),
-		SizeofPriv: C.sizeof_EthFacePriv,
+		Config:      face.loc.EthFaceConfig().WithMaxMTU(face.port.cfg.MTU - NewTxHdr(face.loc, false).IPLen()),
+		Socket:      face.port.dev.NumaSocket(),
+		SizeofPriv:  C.sizeof_EthFacePriv,
+		Persistency: persistency,
 		Init: func(f iface.Face) (initResult iface.InitResult, e error) {
 			face.port.mutex.Lock()
 			defer face.port.mutex.Unlock()
 
+			if face.port.rxImpl == nil {
+				return initResult, errors.New("Port is closed")
+			}
+
 			for _, other := range face.port.faces {
 				if e := CheckLocatorCoexist(face.loc, other.loc); e != nil {
 					return initResult, e
@@ -141,6 +150,7 @@ func NewFace(port *Port, loc Locator) (iface.Face, error) {
 
 			initResult.TxLinearize = !useTxMultiSegOffload
 			initResult.TxBurst = C.EthFace_TxBurst
+			initResult.TxQueueCount = C.EthFace_TxQueueCount
 			return initResult, nil
 		},
 		Start: func() error {
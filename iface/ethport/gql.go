@@ -1,9 +1,13 @@
 package ethport
 
 import (
+	"maps"
+	"reflect"
+
 	"github.com/graphql-go/graphql"
 	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
 	"github.com/usnistgov/ndn-dpdk/core/jsonhelper"
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
 	"github.com/usnistgov/ndn-dpdk/dpdk/ethdev"
 	"github.com/usnistgov/ndn-dpdk/dpdk/ethdev/ethnetif"
 	"github.com/usnistgov/ndn-dpdk/iface"
@@ -14,6 +18,7 @@ var (
 	GqlRxGroupInterface *gqlserver.Interface
 	GqlRxgFlowType      *graphql.Object
 	GqlRxgTableType     *graphql.Object
+	GqlOnDemandInput    *graphql.InputObject
 )
 
 func gqlDefineRxGroup[T iface.RxGroup](oc graphql.ObjectConfig) *graphql.Object {
@@ -110,10 +115,20 @@ func init() {
 		},
 	})
 
+	GqlOnDemandInput = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "EthOnDemandInput",
+		Description: "Ethernet port on-demand face creation config.",
+		Fields: gqlserver.BindInputFields[OnDemandConfig](gqlserver.FieldTypes{
+			reflect.TypeFor[nnduration.Milliseconds](): nnduration.GqlMilliseconds,
+		}),
+	})
+	configFieldTypes := maps.Clone(ethnetif.GqlConfigFieldTypes)
+	configFieldTypes[reflect.TypeFor[OnDemandConfig]()] = GqlOnDemandInput
+
 	gqlserver.AddMutation(&graphql.Field{
 		Name:        "createEthPort",
 		Description: "Create an Ethernet port.",
-		Args:        gqlserver.BindArguments[Config](ethnetif.GqlConfigFieldTypes),
+		Args:        gqlserver.BindArguments[Config](configFieldTypes),
 		Type:        ethdev.GqlEthDevType.Object,
 		Resolve: func(p graphql.ResolveParams) (any, error) {
 			var cfg Config
@@ -128,4 +143,34 @@ func init() {
 			return port.dev, nil
 		},
 	})
+
+	gqlserver.AddSubscription(&graphql.Field{
+		Name:        "ethOnDemandFaceCreated",
+		Description: "Receive on-demand faces created on Ethernet ports.",
+		Type:        graphql.NewNonNull(iface.GqlFaceType.Object),
+		Subscribe: func(p graphql.ResolveParams) (any, error) {
+			return gqlserver.PublishChan(func(updates chan<- any) {
+				created := make(chan iface.Face, 16)
+				defer OnOnDemandFaceCreated(func(port *Port, face iface.Face) {
+					select {
+					case created <- face:
+					default:
+					}
+				})()
+
+				for {
+					select {
+					case <-p.Context.Done():
+						return
+					case face := <-created:
+						select {
+						case <-p.Context.Done():
+							return
+						case updates <- face:
+						}
+					}
+				}
+			})
+		},
+	})
 }
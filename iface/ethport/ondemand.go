@@ -0,0 +1,376 @@
+package ethport
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/gopacket/gopacket"
+	"github.com/gopacket/gopacket/layers"
+	"github.com/usnistgov/ndn-dpdk/core/events"
+	"github.com/usnistgov/ndn-dpdk/core/macaddr"
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
+	"github.com/usnistgov/ndn-dpdk/dpdk/pktmbuf"
+	"github.com/usnistgov/ndn-dpdk/dpdk/ringbuffer"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
+	"go.uber.org/zap"
+	"go4.org/must"
+)
+
+// On-demand face creation defaults.
+const (
+	DefaultOnDemandMaxFaces        = 256
+	DefaultOnDemandMaxCreationRate = 10
+	DefaultOnDemandIdleTimeout     = 600 * time.Second
+
+	onDemandQueueCapacity = 256
+	onDemandPollInterval  = 10 * time.Millisecond
+)
+
+// OnDemandConfig enables on-demand face creation on a Port.
+//
+// When enabled, an unmatched frame from an unknown peer causes an Ethernet or UDP face to be created.
+// The triggering frame is then delivered to the new face.
+// This is supported only with RxTable receive path.
+type OnDemandConfig struct {
+	Ether bool `json:"ether,omitempty" gqldesc:"Create Ethernet faces for NDN frames from unknown unicast MAC addresses."`
+
+	UDPPorts []int `json:"udpPorts,omitempty" gqldesc:"Create UDP faces for datagrams from unknown endpoints to these local UDP ports."`
+
+	MaxFaces int `json:"maxFaces,omitempty" gqldesc:"Maximum number of on-demand faces on the port."`
+
+	MaxCreationRate float64 `json:"maxCreationRate,omitempty" gqldesc:"Maximum on-demand face creation rate (faces per second)."`
+
+	IdleTimeout nnduration.Milliseconds `json:"idleTimeout,omitempty" gqldesc:"Close an on-demand face after this duration without traffic."`
+}
+
+// Validate checks OnDemandConfig fields.
+func (cfg OnDemandConfig) Validate() error {
+	if !cfg.Ether && len(cfg.UDPPorts) == 0 {
+		return errors.New("OnDemand needs Ether or UDPPorts")
+	}
+	for _, p := range cfg.UDPPorts {
+		if p <= 0 || p > math.MaxUint16 {
+			return errors.New("invalid OnDemand UDP port")
+		}
+	}
+	if cfg.MaxFaces < 0 || cfg.MaxCreationRate < 0 {
+		return errors.New("invalid OnDemand limits")
+	}
+	return nil
+}
+
+func (cfg *OnDemandConfig) applyDefaults() {
+	if cfg.MaxFaces == 0 {
+		cfg.MaxFaces = DefaultOnDemandMaxFaces
+	}
+	if cfg.MaxCreationRate == 0 {
+		cfg.MaxCreationRate = DefaultOnDemandMaxCreationRate
+	}
+	if cfg.IdleTimeout <= 0 {
+		cfg.IdleTimeout = nnduration.Milliseconds(DefaultOnDemandIdleTimeout.Milliseconds())
+	}
+}
+
+// OnDemandPeer describes the sender of a frame that triggers on-demand face creation.
+type OnDemandPeer struct {
+	Local  net.HardwareAddr
+	Remote net.HardwareAddr
+	VLAN   int
+
+	// These fields are set only for UDP.
+	LocalIP   netip.Addr
+	RemoteIP  netip.Addr
+	LocalUDP  int
+	RemoteUDP int
+}
+
+// IsUDP determines whether the peer is a UDP endpoint.
+func (peer OnDemandPeer) IsUDP() bool {
+	return peer.LocalUDP != 0
+}
+
+// MakeOnDemandLocator constructs a locator for an on-demand face.
+// This is assigned during package ethface initialization.
+var MakeOnDemandLocator func(port *Port, peer OnDemandPeer) Locator
+
+var onDemandEmitter = events.NewEmitter()
+
+const evtOnDemandFaceCreated = "OnDemandFaceCreated"
+
+// OnOnDemandFaceCreated registers a callback when an on-demand face is created.
+// Return a function that cancels the callback registration.
+func OnOnDemandFaceCreated(cb func(port *Port, face iface.Face)) (cancel func()) {
+	return onDemandEmitter.On(evtOnDemandFaceCreated, cb)
+}
+
+type onDemandFace struct {
+	face       iface.Face
+	lastFrames uint64
+	lastActive time.Time
+}
+
+// onDemand creates and reclaims on-demand faces on a Port.
+//
+// C.EthRxTable enqueues candidate frames into queue.
+// A goroutine dequeues them and creates faces.
+// After creating a face, the goroutine passes the triggering frame back to C.EthRxTable via reinject,
+// so that it is received on the new face.
+// Both rings are freed after the RxGroup has been deactivated.
+type onDemand struct {
+	port        *Port
+	localMAC    net.HardwareAddr
+	cfg         OnDemandConfig
+	udpPorts    map[uint16]bool
+	idleTimeout time.Duration
+	queue       *ringbuffer.Ring
+	reinject    *ringbuffer.Ring
+	ctx         context.Context
+	cancel      context.CancelFunc
+	stopped     chan struct{}
+	logger      *zap.Logger
+
+	tokens    float64
+	lastToken time.Time
+
+	cancelFaceClosed func()
+	facesMutex       sync.Mutex
+	faces            map[iface.ID]*onDemandFace
+}
+
+func (od *onDemand) nFaces() int {
+	od.facesMutex.Lock()
+	defer od.facesMutex.Unlock()
+	return len(od.faces)
+}
+
+// start launches the goroutine.
+func (od *onDemand) start() {
+	od.ctx, od.cancel = context.WithCancel(context.Background())
+	od.stopped = make(chan struct{})
+	od.cancelFaceClosed = iface.OnFaceClosed(func(id iface.ID) {
+		od.facesMutex.Lock()
+		defer od.facesMutex.Unlock()
+		delete(od.faces, id)
+	})
+	od.tokens, od.lastToken = max(1, od.cfg.MaxCreationRate), time.Now()
+	go od.run()
+}
+
+// stop stops the goroutine and waits for it to exit.
+// Returns false if the goroutine was not running.
+func (od *onDemand) stop() bool {
+	if od.cancel == nil {
+		return false
+	}
+	od.cancel()
+	<-od.stopped
+	od.cancel = nil
+	return true
+}
+
+// close frees the rings.
+// The goroutine must have been stopped and the RxGroup must have been deactivated.
+func (od *onDemand) close() {
+	od.drain(od.queue, nil)
+	od.drain(od.reinject, nil)
+	must.Close(od.queue)
+	must.Close(od.reinject)
+}
+
+func (od *onDemand) run() {
+	defer func() {
+		od.cancelFaceClosed()
+		close(od.stopped)
+	}()
+
+	idleTicker := time.NewTicker(max(od.idleTimeout/4, time.Second))
+	defer idleTicker.Stop()
+	pollTicker := time.NewTicker(onDemandPollInterval)
+	defer pollTicker.Stop()
+	for {
+		select {
+		case <-od.ctx.Done():
+			return
+		case now := <-idleTicker.C:
+			for _, face := range od.findIdle(now) {
+				od.logger.Info("closing idle on-demand face", face.ID().ZapField("face"))
+				face.Close()
+			}
+		case <-pollTicker.C:
+			od.drain(od.queue, od.process)
+		}
+	}
+}
+
+// drain dequeues and frees frames in a ring.
+// If process returns true, it has taken ownership of the frame, which is not freed.
+func (od *onDemand) drain(r *ringbuffer.Ring, process func(pkt *pktmbuf.Packet) bool) {
+	vec := make(pktmbuf.Vector, iface.MaxBurstSize)
+	for {
+		n := ringbuffer.Dequeue(r, vec)
+		if n == 0 {
+			return
+		}
+		if process != nil {
+			for i, pkt := range vec[:n] {
+				if process(pkt) {
+					vec[i] = nil
+				}
+			}
+		}
+		vec[:n].Close()
+	}
+}
+
+func (od *onDemand) process(pkt *pktmbuf.Packet) (reinjected bool) {
+	peer, ok := od.parse(pkt.Bytes())
+	if !ok || od.hasPeer(peer) || !od.takeToken() {
+		return false
+	}
+	if od.nFaces() >= od.cfg.MaxFaces {
+		od.logger.Debug("on-demand face limit reached")
+		return false
+	}
+
+	loc := MakeOnDemandLocator(od.port, peer)
+	if e := loc.Validate(); e != nil {
+		od.logger.Debug("on-demand locator error", zap.Error(e))
+		return false
+	}
+	face, e := newFace(od.port, loc, iface.PersistencyOnDemand)
+	if e != nil {
+		od.logger.Warn("on-demand face creation error", zap.String("locator", iface.LocatorString(loc)), zap.Error(e))
+		return false
+	}
+
+	od.facesMutex.Lock()
+	od.faces[face.ID()] = &onDemandFace{
+		face:       face,
+		lastActive: time.Now(),
+	}
+	od.facesMutex.Unlock()
+	od.logger.Info("on-demand face created", zap.String("locator", iface.LocatorString(loc)), face.ID().ZapField("face"))
+	onDemandEmitter.Emit(evtOnDemandFaceCreated, od.port, face)
+
+	// the face is already in EthRxTable, so that the frame will match the new face
+	return ringbuffer.Enqueue(od.reinject, pktmbuf.Vector{pkt}) == 1
+}
+
+// parse extracts peer information from an unmatched frame.
+func (od *onDemand) parse(frame []byte) (peer OnDemandPeer, ok bool) {
+	pkt := gopacket.NewPacket(frame, layers.LayerTypeEthernet, gopacket.DecodeOptions{Lazy: true, NoCopy: true})
+	eth, _ := pkt.Layer(layers.LayerTypeEthernet).(*layers.Ethernet)
+	if eth == nil || !macaddr.Equal(eth.DstMAC, od.localMAC) || !macaddr.IsUnicast(eth.SrcMAC) {
+		return peer, false
+	}
+	peer.Local, peer.Remote = eth.DstMAC, eth.SrcMAC
+
+	etherType := eth.EthernetType
+	if dot1q, _ := pkt.Layer(layers.LayerTypeDot1Q).(*layers.Dot1Q); dot1q != nil {
+		peer.VLAN, etherType = int(dot1q.VLANIdentifier), dot1q.Type
+	}
+
+	switch etherType {
+	case an.EtherTypeNDN:
+		return peer, od.cfg.Ether
+	case layers.EthernetTypeIPv4:
+		ip, _ := pkt.Layer(layers.LayerTypeIPv4).(*layers.IPv4)
+		if ip == nil {
+			return peer, false
+		}
+		peer.LocalIP, _ = netip.AddrFromSlice(ip.DstIP)
+		peer.RemoteIP, _ = netip.AddrFromSlice(ip.SrcIP)
+	case layers.EthernetTypeIPv6:
+		ip, _ := pkt.Layer(layers.LayerTypeIPv6).(*layers.IPv6)
+		if ip == nil {
+			return peer, false
+		}
+		peer.LocalIP, _ = netip.AddrFromSlice(ip.DstIP)
+		peer.RemoteIP, _ = netip.AddrFromSlice(ip.SrcIP)
+	default:
+		return peer, false
+	}
+
+	udp, _ := pkt.Layer(layers.LayerTypeUDP).(*layers.UDP)
+	if udp == nil || !od.udpPorts[uint16(udp.DstPort)] ||
+		!peer.LocalIP.IsGlobalUnicast() && !peer.LocalIP.IsLinkLocalUnicast() ||
+		!peer.RemoteIP.IsGlobalUnicast() && !peer.RemoteIP.IsLinkLocalUnicast() {
+		return peer, false
+	}
+	peer.LocalUDP, peer.RemoteUDP = int(udp.DstPort), int(udp.SrcPort)
+	return peer, true
+}
+
+// hasPeer determines whether a face on the port already covers the peer.
+// This happens when frames were queued before the face was started.
+func (od *onDemand) hasPeer(peer OnDemandPeer) bool {
+	loc := MakeOnDemandLocator(od.port, peer)
+	od.port.mutex.Lock()
+	defer od.port.mutex.Unlock()
+	for _, face := range od.port.faces {
+		if CheckLocatorCoexist(loc, face.loc) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// takeToken enforces MaxCreationRate with a token bucket.
+func (od *onDemand) takeToken() bool {
+	now := time.Now()
+	burst := max(1, od.cfg.MaxCreationRate)
+	od.tokens = min(burst, od.tokens+now.Sub(od.lastToken).Seconds()*od.cfg.MaxCreationRate)
+	od.lastToken = now
+	if od.tokens < 1 {
+		od.logger.Debug("on-demand face creation rate limited")
+		return false
+	}
+	od.tokens--
+	return true
+}
+
+func (od *onDemand) findIdle(now time.Time) (idle []iface.Face) {
+	od.facesMutex.Lock()
+	defer od.facesMutex.Unlock()
+	for _, odf := range od.faces {
+		cnt := odf.face.Counters()
+		if frames := cnt.RxFrames + cnt.TxFrames; frames != odf.lastFrames {
+			odf.lastFrames, odf.lastActive = frames, now
+		} else if now.Sub(odf.lastActive) >= od.idleTimeout {
+			idle = append(idle, odf.face)
+		}
+	}
+	return idle
+}
+
+func newOnDemand(port *Port, cfg OnDemandConfig) (od *onDemand, e error) {
+	cfg.applyDefaults()
+	od = &onDemand{
+		port:        port,
+		localMAC:    port.dev.HardwareAddr(),
+		cfg:         cfg,
+		udpPorts:    map[uint16]bool{},
+		idleTimeout: cfg.IdleTimeout.Duration(),
+		logger:      port.logger.With(zap.String("role", "on-demand")),
+		faces:       map[iface.ID]*onDemandFace{},
+	}
+	for _, p := range cfg.UDPPorts {
+		od.udpPorts[uint16(p)] = true
+	}
+	if od.queue, e = ringbuffer.New(onDemandQueueCapacity, port.dev.NumaSocket(),
+		ringbuffer.ProducerSingle, ringbuffer.ConsumerSingle); e != nil {
+		return nil, e
+	}
+	if od.reinject, e = ringbuffer.New(onDemandQueueCapacity, port.dev.NumaSocket(),
+		ringbuffer.ProducerSingle, ringbuffer.ConsumerSingle); e != nil {
+		must.Close(od.queue)
+		return nil, e
+	}
+	return od, nil
+}
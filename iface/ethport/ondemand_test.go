@@ -0,0 +1,176 @@
+package ethport
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/gopacket/gopacket"
+	"github.com/gopacket/gopacket/layers"
+	"github.com/usnistgov/ndn-dpdk/core/testenv"
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
+	"go.uber.org/zap"
+)
+
+var makeAR = testenv.MakeAR
+
+func newTestOnDemand(cfg OnDemandConfig) *onDemand {
+	cfg.applyDefaults()
+	od := &onDemand{
+		localMAC: net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01},
+		cfg:      cfg,
+		udpPorts: map[uint16]bool{},
+		logger:   zap.NewNop(),
+	}
+	for _, p := range cfg.UDPPorts {
+		od.udpPorts[uint16(p)] = true
+	}
+	od.tokens, od.lastToken = max(1, cfg.MaxCreationRate), time.Now()
+	return od
+}
+
+func serializeFrame(hdrs ...gopacket.SerializableLayer) []byte {
+	for _, hdr := range hdrs {
+		if udp, ok := hdr.(*layers.UDP); ok {
+			for _, l := range hdrs {
+				if ip, ok := l.(gopacket.NetworkLayer); ok {
+					udp.SetNetworkLayerForChecksum(ip)
+				}
+			}
+		}
+	}
+	buf := gopacket.NewSerializeBuffer()
+	if e := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}, hdrs...); e != nil {
+		panic(e)
+	}
+	return buf.Bytes()
+}
+
+func TestOnDemandValidate(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	assert.Error(OnDemandConfig{}.Validate())
+	assert.NoError(OnDemandConfig{Ether: true}.Validate())
+	assert.NoError(OnDemandConfig{UDPPorts: []int{6363}}.Validate())
+	assert.Error(OnDemandConfig{UDPPorts: []int{0}}.Validate())
+	assert.Error(OnDemandConfig{UDPPorts: []int{65536}}.Validate())
+	assert.Error(OnDemandConfig{Ether: true, MaxFaces: -1}.Validate())
+	assert.Error(OnDemandConfig{Ether: true, MaxCreationRate: -1}.Validate())
+}
+
+func TestOnDemandParse(t *testing.T) {
+	assert, _ := makeAR(t)
+	od := newTestOnDemand(OnDemandConfig{Ether: true, UDPPorts: []int{6363}})
+	remoteMAC := net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x02}
+	payload := gopacket.Payload{0x05, 0x03, 0x07, 0x01, 0x00}
+	localIP4, remoteIP4 := netip.MustParseAddr("192.168.2.1"), netip.MustParseAddr("192.168.2.2")
+	localIP6, remoteIP6 := netip.MustParseAddr("fe80::1"), netip.MustParseAddr("fe80::2")
+
+	peer, ok := od.parse(serializeFrame(
+		&layers.Ethernet{SrcMAC: remoteMAC, DstMAC: od.localMAC, EthernetType: layers.EthernetTypeDot1Q},
+		&layers.Dot1Q{VLANIdentifier: 1987, Type: an.EtherTypeNDN},
+		payload,
+	))
+	if assert.True(ok) {
+		assert.Equal(remoteMAC, peer.Remote)
+		assert.Equal(od.localMAC, peer.Local)
+		assert.Equal(1987, peer.VLAN)
+		assert.False(peer.IsUDP())
+	}
+
+	peer, ok = od.parse(serializeFrame(
+		&layers.Ethernet{SrcMAC: remoteMAC, DstMAC: od.localMAC, EthernetType: layers.EthernetTypeIPv4},
+		&layers.IPv4{Version: 4, TTL: 64, Protocol: layers.IPProtocolUDP, SrcIP: remoteIP4.AsSlice(), DstIP: localIP4.AsSlice()},
+		&layers.UDP{SrcPort: 56363, DstPort: 6363},
+		payload,
+	))
+	if assert.True(ok) {
+		assert.True(peer.IsUDP())
+		assert.Equal(0, peer.VLAN)
+		assert.Equal(localIP4, peer.LocalIP)
+		assert.Equal(remoteIP4, peer.RemoteIP)
+		assert.Equal(6363, peer.LocalUDP)
+		assert.Equal(56363, peer.RemoteUDP)
+	}
+
+	peer, ok = od.parse(serializeFrame(
+		&layers.Ethernet{SrcMAC: remoteMAC, DstMAC: od.localMAC, EthernetType: layers.EthernetTypeIPv6},
+		&layers.IPv6{Version: 6, HopLimit: 64, NextHeader: layers.IPProtocolUDP, SrcIP: remoteIP6.AsSlice(), DstIP: localIP6.AsSlice()},
+		&layers.UDP{SrcPort: 6363, DstPort: 6363},
+		payload,
+	))
+	if assert.True(ok) {
+		assert.Equal(localIP6, peer.LocalIP)
+		assert.Equal(remoteIP6, peer.RemoteIP)
+	}
+
+	// UDP port not configured
+	_, ok = od.parse(serializeFrame(
+		&layers.Ethernet{SrcMAC: remoteMAC, DstMAC: od.localMAC, EthernetType: layers.EthernetTypeIPv4},
+		&layers.IPv4{Version: 4, TTL: 64, Protocol: layers.IPProtocolUDP, SrcIP: remoteIP4.AsSlice(), DstIP: localIP4.AsSlice()},
+		&layers.UDP{SrcPort: 6363, DstPort: 6364},
+		payload,
+	))
+	assert.False(ok)
+
+	// multicast destination IP
+	_, ok = od.parse(serializeFrame(
+		&layers.Ethernet{SrcMAC: remoteMAC, DstMAC: od.localMAC, EthernetType: layers.EthernetTypeIPv4},
+		&layers.IPv4{Version: 4, TTL: 64, Protocol: layers.IPProtocolUDP, SrcIP: remoteIP4.AsSlice(), DstIP: net.IPv4(224, 0, 23, 170)},
+		&layers.UDP{SrcPort: 6363, DstPort: 6363},
+		payload,
+	))
+	assert.False(ok)
+
+	// not addressed to local MAC
+	_, ok = od.parse(serializeFrame(
+		&layers.Ethernet{SrcMAC: remoteMAC, DstMAC: net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x03}, EthernetType: an.EtherTypeNDN},
+		payload,
+	))
+	assert.False(ok)
+
+	// multicast source MAC
+	_, ok = od.parse(serializeFrame(
+		&layers.Ethernet{SrcMAC: net.HardwareAddr{0x03, 0x00, 0x00, 0x00, 0x00, 0x02}, DstMAC: od.localMAC, EthernetType: an.EtherTypeNDN},
+		payload,
+	))
+	assert.False(ok)
+
+	// Ether disabled
+	odUDP := newTestOnDemand(OnDemandConfig{UDPPorts: []int{6363}})
+	odUDP.localMAC = od.localMAC
+	_, ok = odUDP.parse(serializeFrame(
+		&layers.Ethernet{SrcMAC: remoteMAC, DstMAC: od.localMAC, EthernetType: an.EtherTypeNDN},
+		payload,
+	))
+	assert.False(ok)
+
+	// truncated
+	_, ok = od.parse([]byte{0x02, 0x00, 0x00})
+	assert.False(ok)
+}
+
+func TestOnDemandTakeToken(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	od := newTestOnDemand(OnDemandConfig{Ether: true, MaxCreationRate: 4})
+	for range 4 {
+		assert.True(od.takeToken())
+	}
+	assert.False(od.takeToken())
+
+	time.Sleep(300 * time.Millisecond)
+	assert.True(od.takeToken())
+	assert.False(od.takeToken())
+
+	time.Sleep(2 * time.Second)
+	for range 4 {
+		assert.True(od.takeToken())
+	}
+	assert.False(od.takeToken())
+
+	odSlow := newTestOnDemand(OnDemandConfig{Ether: true, MaxCreationRate: 0.5})
+	assert.True(odSlow.takeToken())
+	assert.False(odSlow.takeToken())
+}
@@ -45,6 +45,8 @@ type Config struct {
 	MTU int `json:"mtu,omitempty" gqldesc:"Change interface MTU (excluding Ethernet/VLAN headers)."`
 
 	RxFlowQueues int `json:"rxFlowQueues,omitempty" gqldesc:"Enable RxFlow and set maximum queue count."`
+
+	OnDemand *OnDemandConfig `json:"onDemand,omitempty" gqldesc:"Enable on-demand face creation for unknown peers."`
 }
 
 // ensureEthDev creates EthDev if it's not set.
@@ -83,6 +85,7 @@ type Port struct {
 	faces        map[iface.ID]*Face
 	rxBouncePool *pktmbuf.Pool
 	rxImpl       rxImpl
+	onDemand     *onDemand
 	txl          iface.TxLoop
 }
 
@@ -110,10 +113,16 @@ func (port *Port) Close() error {
 }
 
 func (port *Port) closeWithPortsMutex() error {
+	// on-demand face creation acquires port.mutex, so the goroutine must exit before locking
+	onDemandStopped := port.onDemand != nil && port.onDemand.stop()
+
 	port.mutex.Lock()
 	defer port.mutex.Unlock()
 
 	if nFaces := len(port.faces); nFaces > 0 {
+		if onDemandStopped {
+			port.onDemand.start()
+		}
 		return fmt.Errorf("cannot close Port with %d active faces", nFaces)
 	}
 
@@ -124,6 +133,11 @@ func (port *Port) closeWithPortsMutex() error {
 		port.rxImpl = nil
 	}
 
+	if port.onDemand != nil {
+		port.onDemand.close()
+		port.onDemand = nil
+	}
+
 	if port.ddpRollback != nil {
 		errs = append(errs, port.ddpRollback())
 		port.ddpRollback = nil
@@ -207,6 +221,11 @@ func New(cfg Config) (port *Port, e error) {
 	if ndni.PacketMempool.Config().Dataroom < pktmbuf.DefaultHeadroom+cfg.MTU {
 		return nil, errors.New("PacketMempool dataroom is too small for requested MTU")
 	}
+	if cfg.OnDemand != nil {
+		if e := cfg.OnDemand.Validate(); e != nil {
+			return nil, e
+		}
+	}
 
 	port = &Port{
 		cfg:     cfg,
@@ -234,6 +253,18 @@ func New(cfg Config) (port *Port, e error) {
 		}
 	}
 
+	if cfg.OnDemand != nil {
+		if _, ok := port.rxImpl.(*rxTable); !ok || port.devInfo.Driver() == ethdev.DriverXDP || MakeOnDemandLocator == nil {
+			e = fmt.Errorf("OnDemand is not supported with %s on %s driver", port.rxImpl, port.devInfo.Driver())
+			port.closeWithPortsMutex()
+			return nil, e
+		}
+		if port.onDemand, e = newOnDemand(port, *cfg.OnDemand); e != nil {
+			port.closeWithPortsMutex()
+			return nil, e
+		}
+	}
+
 	if e := port.rxImpl.Init(port); e != nil {
 		port.logger.Error("rxImpl init error", zap.Error(e))
 		port.rxImpl = nil
@@ -241,7 +272,11 @@ func New(cfg Config) (port *Port, e error) {
 		return nil, e
 	}
 
-	port.logger.Info("port opened", zap.Stringer("rxImpl", port.rxImpl))
+	if port.onDemand != nil {
+		port.onDemand.start()
+	}
+
+	port.logger.Info("port opened", zap.Stringer("rxImpl", port.rxImpl), zap.Bool("on-demand", port.onDemand != nil))
 	ports[port.dev] = port
 	return port, nil
 }
@@ -95,6 +95,10 @@ func newRxgTable(port *Port) (rxt *rxgTable) {
 		rxPool := ndni.PacketMempool.Get(socket)
 		rxt.copyTo = (*C.struct_rte_mempool)(rxPool.Ptr())
 	}
+	if port.onDemand != nil {
+		rxt.onDemandQueue = (*C.struct_rte_ring)(port.onDemand.queue.Ptr())
+		rxt.onDemandReinject = (*C.struct_rte_ring)(port.onDemand.reinject.Ptr())
+	}
 
 	iface.ActivateRxGroup(rxt)
 	return rxt
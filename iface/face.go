@@ -24,6 +24,8 @@ import (
 var logger = logging.New("iface")
 
 // Face represents a network layer face.
+//
+// Close is idempotent: a face may be closed by several parties, such as its listener and upon transport failure.
 type Face interface {
 	eal.WithNumaSocket
 	WithInputDemuxes
@@ -53,6 +55,9 @@ type Face interface {
 
 	// SetDown changes face UP/DOWN state.
 	SetDown(isDown bool)
+
+	// Persistency returns how the lifetime of this face is managed.
+	Persistency() Persistency
 }
 
 // Config contains face configuration.
@@ -70,6 +75,13 @@ type Config struct {
 	// Otherwise, it is adjusted up to the next power of 2.
 	OutputQueueSize int `json:"outputQueueSize,omitempty"`
 
+	// CongMarkThreshold is the output queue occupancy at which the output thread adds a
+	// congestion mark to an outgoing packet, at most once per burst.
+	//
+	// If this value is zero, egress congestion marking is disabled.
+	// Otherwise, it is clamped between 1 and OutputQueueSize.
+	CongMarkThreshold int `json:"congMarkThreshold,omitempty"`
+
 	// MTU is the maximum size of outgoing NDNLP packets.
 	// This excludes lower layer headers, such as Ethernet/VXLAN headers.
 	//
@@ -77,6 +89,15 @@ type Config struct {
 	// If this is less than MinMTU or greater than the maximum, the face will fail to initialize.
 	MTU int `json:"mtu,omitempty"`
 
+	// Reliability enables NDNLPv2 link reliability protocol, if not nil.
+	Reliability *ReliabilityConfig `json:"reliability,omitempty"`
+
+	// IncomingFaceID requests the forwarder to add NDNLPv2 IncomingFaceId field to Interests sent
+	// to this face, which indicates the face on which the forwarder received the Interest.
+	// This is intended for internal faces toward local applications.
+	// It cannot be set via JSON, so that face IDs are not disclosed to remote peers.
+	IncomingFaceID bool `json:"-"`
+
 	maxMTU int
 }
 
@@ -88,6 +109,9 @@ func (c *Config) ApplyDefaults() {
 	c.ReassemblerCapacity = generic.Clamp(c.ReassemblerCapacity, MinReassemblerCapacity, MaxReassemblerCapacity)
 
 	c.OutputQueueSize = ringbuffer.AlignCapacity(c.OutputQueueSize, MinOutputQueueSize, DefaultOutputQueueSize)
+	if c.CongMarkThreshold != 0 {
+		c.CongMarkThreshold = generic.Clamp(c.CongMarkThreshold, 1, c.OutputQueueSize)
+	}
 }
 
 // WithMaxMTU returns a copy of Config with consideration of device MTU.
@@ -116,6 +140,10 @@ type NewParams struct {
 	// Socket indicates where to allocate memory.
 	Socket eal.NumaSocket
 
+	// Persistency indicates how the lifetime of the face is managed.
+	// Default is PersistencyPersistent.
+	Persistency Persistency
+
 	// SizeOfPriv is the size of C.FaceImpl.priv struct.
 	SizeofPriv uintptr
 
@@ -167,6 +195,10 @@ type InitResult struct {
 
 	// TxBurst is a C function of C.Face_TxBurstFunc type.
 	TxBurst unsafe.Pointer
+
+	// TxQueueCount is a C function of C.Face_TxQueueCountFunc type.
+	// This is optional.
+	TxQueueCount unsafe.Pointer
 }
 
 // New creates a Face.
@@ -178,6 +210,9 @@ func New(p NewParams) (face Face, e error) {
 	if p.Socket.IsAny() {
 		p.Socket = eal.RandomSocket()
 	}
+	if p.Persistency == "" {
+		p.Persistency = PersistencyPersistent
+	}
 
 	eal.CallMain(func() {
 		face, e = newFace(p)
@@ -189,6 +224,7 @@ func newFace(p NewParams) (Face, error) {
 	f := &face{
 		id:                 AllocID(),
 		socket:             p.Socket,
+		persistency:        p.Persistency,
 		locatorCallback:    p.Locator,
 		stopCallback:       p.Stop,
 		closeCallback:      p.Close,
@@ -227,11 +263,21 @@ func newFace(p NewParams) (Face, error) {
 	} else {
 		c.impl.txLoop = C.Face_TxLoopFunc(initResult.TxLoop)
 	}
+	lpHeaderSize := ndni.LpHeaderHeadroom
+	if p.Reliability == nil {
+		lpHeaderSize -= ndni.LpReliabilityHeadroom
+	}
+	if !p.IncomingFaceID {
+		lpHeaderSize -= ndni.LpIncomingFaceIDHeadroom
+	}
+	c.txInFaceID = C.bool(p.IncomingFaceID)
 	c.txAlign = C.PacketTxAlign{
 		linearize:           C.bool(initResult.TxLinearize),
-		fragmentPayloadSize: C.uint16_t(p.MTU - ndni.LpHeaderHeadroom),
+		fragmentPayloadSize: C.uint16_t(p.MTU - lpHeaderSize),
 	}
 	c.impl.txBurst = C.Face_TxBurstFunc(initResult.TxBurst)
+	c.impl.txQueueCount = C.Face_TxQueueCountFunc(initResult.TxQueueCount)
+	c.impl.txCongMarkThreshold = C.uint32_t(p.CongMarkThreshold)
 	(*ndni.Mempools)(unsafe.Pointer(&c.impl.txMempools)).Assign(p.Socket)
 
 	outputQueue, e := ringbuffer.New(p.OutputQueueSize, p.Socket, ringbuffer.ProducerMulti, ringbuffer.ConsumerSingle)
@@ -241,6 +287,13 @@ func newFace(p NewParams) (Face, error) {
 	}
 	c.outputQueue = (*C.struct_rte_ring)(outputQueue.Ptr())
 
+	if p.Reliability != nil {
+		if c.impl.rel, e = newReliability(*p.Reliability, p.Socket); e != nil {
+			logEntry.Warn("reliability error", zap.Error(e))
+			return f.clear(), e
+		}
+	}
+
 	for i := range MaxFaceRxThreads {
 		reassID := C.CString(eal.AllocObjectID("iface.Reassembler"))
 		defer C.free(unsafe.Pointer(reassID))
@@ -266,10 +319,12 @@ func newFace(p NewParams) (Face, error) {
 type face struct {
 	id                 ID
 	socket             eal.NumaSocket
+	persistency        Persistency
 	locatorCallback    func() Locator
 	stopCallback       func() error
 	closeCallback      func() error
 	exCountersCallback func() any
+	removed            bool
 }
 
 func (f *face) ptr() *C.Face {
@@ -288,6 +343,10 @@ func (f *face) NumaSocket() eal.NumaSocket {
 	return f.socket
 }
 
+func (f *face) Persistency() Persistency {
+	return f.persistency
+}
+
 func (f *face) Locator() Locator {
 	return f.locatorCallback()
 }
@@ -297,7 +356,12 @@ func (f *face) Close() (e error) {
 	return e
 }
 
+// close closes the face.
+// It is idempotent: closing a face that has been removed has no effect.
 func (f *face) close() error {
+	if f.removed {
+		return nil
+	}
 	f.ptr().state = StateDown
 	emitter.Emit(evtFaceClosing, f.id)
 
@@ -316,6 +380,7 @@ func (f *face) close() error {
 
 func (f *face) clear() Face {
 	id, c := f.id, f.ptr()
+	f.removed = true
 	c.state = StateRemoved
 	if c.impl != nil {
 		for i := range MaxFaceRxThreads {
@@ -324,6 +389,9 @@ func (f *face) clear() Face {
 		if c.impl.rxDemuxes != nil {
 			eal.Free(c.impl.rxDemuxes)
 		}
+		if c.impl.rel != nil {
+			closeReliability(c.impl.rel)
+		}
 		eal.Free(c.impl)
 		c.impl = nil
 	}
@@ -60,6 +60,14 @@ func init() {
 					return locw, nil
 				},
 			},
+			"persistency": &graphql.Field{
+				Type:        gqlserver.NonNullString,
+				Description: "How the face lifetime is managed, either 'persistent' or 'on-demand'.",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					face := p.Source.(Face)
+					return string(face.Persistency()), nil
+				},
+			},
 			"numaSocket": eal.GqlWithNumaSocket,
 			"isDown": &graphql.Field{
 				Type:        gqlserver.NonNullBoolean,
@@ -14,6 +14,8 @@ type ID uint16
 const (
 	MinID = 0x1000
 	MaxID = 0xEFFF
+
+	_ = "enumgen::Face"
 )
 
 // AllocID allocates a random ID.
@@ -53,8 +53,16 @@ func New(cfg socketface.Config) (f *IntFace, e error) {
 		return nil, e
 	}
 
+	var cfgA l3.FaceConfig
+	if rel := cfg.Reliability; rel != nil {
+		cfgA.Reliability = &l3.ReliabilityConfig{
+			RetxTimeout: rel.RetxTimeout.Duration(),
+			MaxRetx:     rel.MaxRetx,
+		}
+	}
+
 	f = &IntFace{}
-	if f.A, e = l3.NewFace(trA, l3.FaceConfig{}); e != nil {
+	if f.A, e = l3.NewFace(trA, cfgA); e != nil {
 		return nil, e
 	}
 	if f.D, e = socketface.Wrap(trD, cfg); e != nil {
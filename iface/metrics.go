@@ -0,0 +1,13 @@
+package iface
+
+import (
+	"github.com/usnistgov/ndn-dpdk/core/metrics"
+)
+
+func init() {
+	metrics.AddCollector(func(w *metrics.Writer) {
+		for _, face := range List() {
+			w.Struct("face", face.Counters(), metrics.L("face", face.ID()), metrics.L("scheme", face.Locator().Scheme()))
+		}
+	})
+}
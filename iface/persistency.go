@@ -0,0 +1,14 @@
+package iface
+
+// Persistency indicates how the lifetime of a face is managed.
+type Persistency string
+
+// Persistency values.
+const (
+	// PersistencyPersistent indicates the face is created and closed by explicit commands.
+	PersistencyPersistent Persistency = "persistent"
+
+	// PersistencyOnDemand indicates the face is created upon incoming traffic from a listener,
+	// and is closed automatically when it becomes idle or its underlying connection fails.
+	PersistencyOnDemand Persistency = "on-demand"
+)
@@ -0,0 +1,75 @@
+package iface
+
+/*
+#include "../csrc/iface/reliability.h"
+*/
+import "C"
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+	"unsafe"
+
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/dpdk/ringbuffer"
+	"go4.org/must"
+)
+
+const (
+	// DefaultRetxTimeout is the default NDNLPv2 link reliability retransmission timeout.
+	DefaultRetxTimeout = 200 * time.Millisecond
+
+	// DefaultMaxRetx is the default NDNLPv2 link reliability maximum number of retransmissions.
+	DefaultMaxRetx = 3
+
+	reliabilityRingCapacity = 4096
+)
+
+// ReliabilityConfig contains NDNLPv2 link reliability options.
+//
+// When enabled, each outgoing frame carries a TxSequence and is retained until acknowledged by the peer.
+// Unacknowledged frames are retransmitted with a new TxSequence.
+// Incoming TxSequence numbers are acknowledged by piggybacking Ack fields on outgoing frames,
+// or in IDLE packets if there is no outgoing traffic.
+type ReliabilityConfig struct {
+	// RetxTimeout is the retransmission timeout.
+	// Default is DefaultRetxTimeout.
+	RetxTimeout nnduration.Milliseconds `json:"retxTimeout,omitempty"`
+
+	// MaxRetx is the maximum number of retransmissions of a frame.
+	// Default is DefaultMaxRetx.
+	// Otherwise, it is clamped between 1 and 255.
+	MaxRetx int `json:"maxRetx,omitempty"`
+}
+
+func newReliability(cfg ReliabilityConfig, socket eal.NumaSocket) (*C.LpReliability, error) {
+	rel := eal.Zmalloc[C.LpReliability]("LpReliability", C.sizeof_LpReliability, socket)
+	for _, ring := range []**C.struct_rte_ring{&rel.rxAcks, &rel.pendingAcks} {
+		r, e := ringbuffer.New(reliabilityRingCapacity, socket, ringbuffer.ProducerMulti, ringbuffer.ConsumerSingle)
+		if e != nil {
+			closeReliability(rel)
+			return nil, e
+		}
+		*ring = (*C.struct_rte_ring)(r.Ptr())
+	}
+
+	rel.rto = C.TscDuration(eal.ToTscDuration(cfg.RetxTimeout.DurationOr(nnduration.Milliseconds(DefaultRetxTimeout / time.Millisecond))))
+	if cfg.MaxRetx <= 0 {
+		cfg.MaxRetx = DefaultMaxRetx
+	}
+	rel.maxRetx = C.uint8_t(min(cfg.MaxRetx, math.MaxUint8))
+	rel.nextTxSeq = C.uint64_t(rand.Uint64())
+	rel.oldestTxSeq = rel.nextTxSeq
+	return rel, nil
+}
+
+func closeReliability(rel *C.LpReliability) {
+	C.LpReliability_Close(rel)
+	for _, ring := range []*C.struct_rte_ring{rel.rxAcks, rel.pendingAcks} {
+		if ring != nil {
+			must.Close(ringbuffer.FromPtr(unsafe.Pointer(ring)))
+		}
+	}
+	eal.Free(rel)
+}
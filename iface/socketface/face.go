@@ -47,9 +47,15 @@ func New(loc Locator) (iface.Face, error) {
 
 // Wrap wraps a sockettransport.Transport to a socket face.
 func Wrap(transport sockettransport.Transport, cfg Config) (iface.Face, error) {
+	return wrap(transport, cfg, iface.PersistencyPersistent, nil)
+}
+
+// wrap creates a socket face.
+// If initial is not nil, it is delivered as the first received packet; this requires RxConns implementation.
+func wrap(transport sockettransport.Transport, cfg Config, persistency iface.Persistency, initial []byte) (iface.Face, error) {
 	_, isUDP := transport.Conn().(*net.UDPConn)
 	rxi := &rxConnsImpl
-	if isUDP && !gCfg.RxEpoll.Disabled {
+	if isUDP && !gCfg.RxEpoll.Disabled && initial == nil {
 		rxi = &rxEpollImpl
 	}
 	txi := &txConnImpl
@@ -59,11 +65,13 @@ func Wrap(transport sockettransport.Transport, cfg Config) (iface.Face, error) {
 
 	face := &socketFace{
 		transport: transport,
+		initial:   initial,
 	}
 	return iface.New(iface.NewParams{
-		Config:     cfg.Config.WithMaxMTU(ndni.PacketMempool.Config().Dataroom - pktmbuf.DefaultHeadroom),
-		Socket:     gCfg.numaSocket(),
-		SizeofPriv: C.sizeof_SocketFacePriv,
+		Config:      cfg.Config.WithMaxMTU(ndni.PacketMempool.Config().Dataroom - pktmbuf.DefaultHeadroom),
+		Socket:      gCfg.numaSocket(),
+		Persistency: persistency,
+		SizeofPriv:  C.sizeof_SocketFacePriv,
 		Init: func(f iface.Face) (res iface.InitResult, e error) {
 			face.Face = f
 			id, faceC := face.ID(), (*C.Face)(face.Ptr())
@@ -91,6 +99,9 @@ func Wrap(transport sockettransport.Transport, cfg Config) (iface.Face, error) {
 
 			face.cancelStateChangeHandler = face.transport.OnStateChange(func(st l3.TransportState) {
 				face.SetDown(st != l3.TransportUp)
+				if st == l3.TransportClosed && persistency == iface.PersistencyOnDemand {
+					go face.Close()
+				}
 			})
 
 			face.logger.Info("face started", zap.Stringer("rx-impl", rxi), zap.Stringer("tx-impl", txi))
@@ -127,6 +138,7 @@ func Wrap(transport sockettransport.Transport, cfg Config) (iface.Face, error) {
 type socketFace struct {
 	iface.Face
 	transport                sockettransport.Transport
+	initial                  []byte
 	logger                   *zap.Logger
 	priv                     *C.SocketFacePriv
 	cancelStateChangeHandler func()
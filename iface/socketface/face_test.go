@@ -150,3 +150,57 @@ func TestUnix(t *testing.T) {
 		return face
 	})
 }
+
+func TestListener(t *testing.T) {
+	assert, require := makeAR(t)
+
+	l, e := socketface.Listen(socketface.ListenerConfig{
+		Network:     "tcp",
+		Local:       "127.0.0.1:0",
+		IdleTimeout: 1000,
+	})
+	require.NoError(e)
+	defer l.Close()
+	assert.Same(l, socketface.GetListener(l.ID()))
+	addr := l.Addr().String()
+
+	conn1, e := net.Dial("tcp", addr)
+	require.NoError(e)
+	defer conn1.Close()
+	time.Sleep(200 * time.Millisecond)
+	faces := l.Faces()
+	require.Len(faces, 1)
+	face1 := faces[0]
+	assert.Equal(iface.PersistencyOnDemand, face1.Persistency())
+	assert.Equal(conn1.LocalAddr().String(), face1.Locator().(socketface.Locator).Remote)
+
+	// closing a face twice is harmless
+	assert.NoError(face1.Close())
+	assert.NoError(face1.Close())
+	assert.Nil(iface.Get(face1.ID()))
+	assert.Len(l.Faces(), 0)
+
+	// idle face is closed by listener
+	conn2, e := net.Dial("tcp", addr)
+	require.NoError(e)
+	defer conn2.Close()
+	time.Sleep(200 * time.Millisecond)
+	faces = l.Faces()
+	require.Len(faces, 1)
+	face2 := faces[0]
+	time.Sleep(2500 * time.Millisecond)
+	assert.Len(l.Faces(), 0)
+	assert.Nil(iface.Get(face2.ID()))
+	assert.NoError(face2.Close())
+
+	// closing listener closes remaining faces
+	conn3, e := net.Dial("tcp", addr)
+	require.NoError(e)
+	defer conn3.Close()
+	time.Sleep(200 * time.Millisecond)
+	faces = l.Faces()
+	require.Len(faces, 1)
+	assert.NoError(l.Close())
+	assert.Nil(iface.Get(faces[0].ID()))
+	assert.Nil(socketface.GetListener(l.ID()))
+}
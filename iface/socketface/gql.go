@@ -1,15 +1,23 @@
 package socketface
 
 import (
+	"errors"
+	"strconv"
+
 	"github.com/graphql-go/graphql"
 	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
+	"github.com/usnistgov/ndn-dpdk/core/jsonhelper"
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
 	"github.com/usnistgov/ndn-dpdk/iface"
 )
 
+var errGqlCreateListenerDisallowed = errors.New("createSocketListener is disallowed; is NDN-DPDK forwarder activated?")
+
 // GraphQL types.
 var (
-	GqlRxConnsType *graphql.Object
-	GqlRxEpollType *graphql.Object
+	GqlRxConnsType  *graphql.Object
+	GqlRxEpollType  *graphql.Object
+	GqlListenerType *gqlserver.NodeType[*Listener]
 )
 
 func init() {
@@ -28,4 +36,90 @@ func init() {
 	iface.GqlRxGroupInterface.AppendTo(&ocRxEpoll)
 	GqlRxEpollType = graphql.NewObject(ocRxEpoll)
 	gqlserver.ImplementsInterface[*rxEpoll](GqlRxEpollType, iface.GqlRxGroupInterface)
+
+	GqlListenerType = gqlserver.NewNodeType(graphql.ObjectConfig{
+		Name: "SocketListener",
+		Fields: graphql.Fields{
+			"nid": &graphql.Field{
+				Type:        gqlserver.NonNullInt,
+				Description: "Numeric listener identifier.",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					l := p.Source.(*Listener)
+					return l.ID(), nil
+				},
+			},
+			"scheme": &graphql.Field{
+				Type:        gqlserver.NonNullString,
+				Description: "Socket network.",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					l := p.Source.(*Listener)
+					return l.Config().Network, nil
+				},
+			},
+			"local": &graphql.Field{
+				Type:        gqlserver.NonNullString,
+				Description: "Local address.",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					l := p.Source.(*Listener)
+					return l.Addr().String(), nil
+				},
+			},
+			"idleTimeout": &graphql.Field{
+				Type:        graphql.NewNonNull(nnduration.GqlMilliseconds),
+				Description: "Idle timeout of on-demand faces.",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					l := p.Source.(*Listener)
+					return nnduration.Milliseconds(l.idleTimeout.Milliseconds()), nil
+				},
+			},
+			"faces": &graphql.Field{
+				Type:        gqlserver.NewListNonNullBoth(iface.GqlFaceType.Object),
+				Description: "On-demand faces created by this listener.",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					l := p.Source.(*Listener)
+					return l.Faces(), nil
+				},
+			},
+		},
+	}, gqlserver.NodeConfig[*Listener]{
+		GetID: func(l *Listener) string {
+			return strconv.Itoa(l.ID())
+		},
+		RetrieveInt: GetListener,
+		Delete: func(l *Listener) error {
+			return l.Close()
+		},
+	})
+
+	gqlserver.AddQuery(&graphql.Field{
+		Name:        "socketListeners",
+		Description: "List of socket listeners.",
+		Type:        gqlserver.NewListNonNullBoth(GqlListenerType.Object),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return ListListeners(), nil
+		},
+	})
+
+	gqlserver.AddMutation(&graphql.Field{
+		Name:        "createSocketListener",
+		Description: "Create a socket listener that creates on-demand faces upon incoming connections.",
+		Args: graphql.FieldConfigArgument{
+			"listener": &graphql.ArgumentConfig{
+				Description: "JSON object that satisfies the schema given in 'socketlistener.schema.json'.",
+				Type:        gqlserver.NonNullJSON,
+			},
+		},
+		Type: graphql.NewNonNull(GqlListenerType.Object),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			if !iface.GqlCreateFaceAllowed {
+				return nil, errGqlCreateListenerDisallowed
+			}
+
+			var cfg ListenerConfig
+			if e := jsonhelper.Roundtrip(p.Args["listener"], &cfg, jsonhelper.DisallowUnknownFields); e != nil {
+				return nil, e
+			}
+			return Listen(cfg)
+		},
+	})
 }
@@ -0,0 +1,330 @@
+package socketface
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gogf/greuse"
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
+	"github.com/usnistgov/ndn-dpdk/dpdk/pktmbuf"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndn/sockettransport"
+	"github.com/usnistgov/ndn-dpdk/ndni"
+	"go.uber.org/zap"
+)
+
+// DefaultIdleTimeout is the default idle timeout of on-demand faces.
+const DefaultIdleTimeout = 600 * time.Second
+
+// ListenerConfig contains socket listener configuration.
+type ListenerConfig struct {
+	// Config contains configuration of on-demand faces created by the listener.
+	Config
+
+	// Network is the socket network, one of "tcp", "unix", "udp".
+	Network string `json:"scheme"`
+
+	// Local is the local address to listen on.
+	Local string `json:"local"`
+
+	// IdleTimeout is the duration after which an on-demand face without any traffic is closed.
+	// Default is DefaultIdleTimeout.
+	IdleTimeout nnduration.Milliseconds `json:"idleTimeout,omitempty"`
+}
+
+// Validate checks the network and local address.
+func (cfg ListenerConfig) Validate() error {
+	switch cfg.Network {
+	case schemeTCP, schemeUnix, schemeUDP:
+	default:
+		return fmt.Errorf("unknown network %s", cfg.Network)
+	}
+	_, e := greuse.ResolveAddr(cfg.Network, cfg.Local)
+	return e
+}
+
+var (
+	lastListenerID int
+	listeners      = map[int]*Listener{}
+	listenersMutex sync.RWMutex
+)
+
+// Listener accepts incoming connections on a socket, and creates an on-demand face for each of them.
+//
+// A stream listener (TCP or Unix) creates a face for each accepted connection.
+// A datagram listener (UDP) creates a face for each new remote endpoint, using a connected socket that
+// shares the local address; the first datagram is delivered to the new face.
+// An on-demand face is closed when it has no traffic for IdleTimeout, or when its connection fails.
+type Listener struct {
+	id          int
+	cfg         ListenerConfig
+	idleTimeout time.Duration
+	stream      net.Listener
+	dgram       net.PacketConn
+	ctx         context.Context
+	cancel      context.CancelFunc
+	running     sync.WaitGroup
+	closeOnce   sync.Once
+	logger      *zap.Logger
+
+	cancelFaceClosed func()
+	facesMutex       sync.Mutex
+	faces            map[iface.ID]*onDemandFace
+}
+
+type onDemandFace struct {
+	face       iface.Face
+	peer       string
+	lastFrames uint64
+	lastActive time.Time
+}
+
+// ID returns listener identifier.
+func (l *Listener) ID() int {
+	return l.id
+}
+
+// Config returns listener configuration.
+func (l *Listener) Config() ListenerConfig {
+	return l.cfg
+}
+
+// Addr returns the local address.
+func (l *Listener) Addr() net.Addr {
+	if l.stream != nil {
+		return l.stream.Addr()
+	}
+	return l.dgram.LocalAddr()
+}
+
+// Faces returns on-demand faces created by this listener.
+func (l *Listener) Faces() (list []iface.Face) {
+	l.facesMutex.Lock()
+	defer l.facesMutex.Unlock()
+	for _, odf := range l.faces {
+		list = append(list, odf.face)
+	}
+	slices.SortFunc(list, func(a, b iface.Face) int { return int(a.ID()) - int(b.ID()) })
+	return list
+}
+
+// Close stops the listener and closes its on-demand faces.
+func (l *Listener) Close() (e error) {
+	l.closeOnce.Do(func() {
+		l.cancel()
+		errs := []error{}
+		if l.stream != nil {
+			errs = append(errs, l.stream.Close())
+		} else {
+			errs = append(errs, l.dgram.Close())
+		}
+		l.running.Wait()
+
+		for _, face := range l.Faces() {
+			errs = append(errs, face.Close())
+		}
+		l.cancelFaceClosed()
+
+		listenersMutex.Lock()
+		delete(listeners, l.id)
+		listenersMutex.Unlock()
+		l.logger.Info("listener closed")
+		e = errors.Join(errs...)
+	})
+	return e
+}
+
+func (l *Listener) acceptStream() {
+	defer l.running.Done()
+	for {
+		conn, e := l.stream.Accept()
+		if e != nil {
+			if l.ctx.Err() != nil {
+				return
+			}
+			l.logger.Warn("accept error", zap.Error(e))
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+		l.addFace(conn, conn.RemoteAddr().String(), nil)
+	}
+}
+
+func (l *Listener) receiveDgram() {
+	defer l.running.Done()
+	buf := make([]byte, iface.MaxMTU)
+	local := l.dgram.LocalAddr().String()
+	for {
+		n, raddr, e := l.dgram.ReadFrom(buf)
+		if e != nil {
+			if l.ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		peer := raddr.String()
+		if l.hasPeer(peer) { // arrived before the connected socket took over
+			continue
+		}
+
+		conn, e := greuse.Dial(l.cfg.Network, local, peer)
+		if e != nil {
+			l.logger.Warn("connected socket error", zap.String("peer", peer), zap.Error(e))
+			continue
+		}
+		l.addFace(conn, peer, bytes.Clone(buf[:n]))
+	}
+}
+
+func (l *Listener) hasPeer(peer string) bool {
+	l.facesMutex.Lock()
+	defer l.facesMutex.Unlock()
+	for _, odf := range l.faces {
+		if odf.peer == peer {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *Listener) addFace(conn net.Conn, peer string, initial []byte) {
+	tcfg := l.cfg.transportConfig()
+	tcfg.Passive = true
+	transport, e := sockettransport.New(conn, tcfg)
+	if e != nil {
+		conn.Close()
+		l.logger.Warn("transport error", zap.String("peer", peer), zap.Error(e))
+		return
+	}
+
+	face, e := wrap(transport, l.cfg.Config, iface.PersistencyOnDemand, initial)
+	if e != nil {
+		transport.Close()
+		l.logger.Warn("face creation error", zap.String("peer", peer), zap.Error(e))
+		return
+	}
+
+	l.facesMutex.Lock()
+	l.faces[face.ID()] = &onDemandFace{
+		face:       face,
+		peer:       peer,
+		lastActive: time.Now(),
+	}
+	l.facesMutex.Unlock()
+	l.logger.Info("on-demand face created", zap.String("peer", peer), face.ID().ZapField("face"))
+}
+
+func (l *Listener) checkIdle() {
+	defer l.running.Done()
+	ticker := time.NewTicker(max(l.idleTimeout/4, time.Second))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.ctx.Done():
+			return
+		case now := <-ticker.C:
+			for _, face := range l.findIdle(now) {
+				l.logger.Info("closing idle on-demand face", face.ID().ZapField("face"))
+				face.Close()
+			}
+		}
+	}
+}
+
+func (l *Listener) findIdle(now time.Time) (idle []iface.Face) {
+	l.facesMutex.Lock()
+	defer l.facesMutex.Unlock()
+	for _, odf := range l.faces {
+		cnt := odf.face.Counters()
+		if frames := cnt.RxFrames + cnt.TxFrames; frames != odf.lastFrames {
+			odf.lastFrames, odf.lastActive = frames, now
+		} else if now.Sub(odf.lastActive) >= l.idleTimeout {
+			idle = append(idle, odf.face)
+		}
+	}
+	return idle
+}
+
+// Listen creates a Listener.
+func Listen(cfg ListenerConfig) (l *Listener, e error) {
+	if e := cfg.Validate(); e != nil {
+		return nil, e
+	}
+	if cfg.MTU > 0 && ndni.PacketMempool.Config().Dataroom < pktmbuf.DefaultHeadroom+cfg.MTU {
+		return nil, errors.New("PacketMempool dataroom is too small for requested MTU")
+	}
+
+	l = &Listener{
+		cfg:         cfg,
+		idleTimeout: cfg.IdleTimeout.DurationOr(nnduration.Milliseconds(DefaultIdleTimeout.Milliseconds())),
+		faces:       map[iface.ID]*onDemandFace{},
+	}
+	switch cfg.Network {
+	case schemeUDP:
+		l.dgram, e = greuse.ListenPacket(cfg.Network, cfg.Local)
+	default:
+		l.stream, e = net.Listen(cfg.Network, cfg.Local)
+	}
+	if e != nil {
+		return nil, e
+	}
+	l.ctx, l.cancel = context.WithCancel(context.Background())
+
+	l.cancelFaceClosed = iface.OnFaceClosed(func(id iface.ID) {
+		l.facesMutex.Lock()
+		defer l.facesMutex.Unlock()
+		delete(l.faces, id)
+	})
+
+	listenersMutex.Lock()
+	lastListenerID++
+	l.id = lastListenerID
+	listeners[l.id] = l
+	listenersMutex.Unlock()
+	l.logger = logger.With(zap.Int("listener", l.id), zap.String("network", cfg.Network), zap.Stringer("local", l.Addr()))
+
+	l.running.Add(2)
+	if l.stream != nil {
+		go l.acceptStream()
+	} else {
+		go l.receiveDgram()
+	}
+	go l.checkIdle()
+
+	l.logger.Info("listener started", zap.Duration("idle-timeout", l.idleTimeout))
+	return l, nil
+}
+
+// GetListener retrieves Listener by ID.
+// Returns nil if it does not exist.
+func GetListener(id int) *Listener {
+	listenersMutex.RLock()
+	defer listenersMutex.RUnlock()
+	return listeners[id]
+}
+
+// ListListeners returns a list of listeners.
+func ListListeners() (list []*Listener) {
+	listenersMutex.RLock()
+	defer listenersMutex.RUnlock()
+	for _, l := range listeners {
+		list = append(list, l)
+	}
+	slices.SortFunc(list, func(a, b *Listener) int { return a.id - b.id })
+	return list
+}
+
+func init() {
+	iface.OnCloseAll(func() {
+		for _, l := range ListListeners() {
+			l.Close()
+		}
+	})
+}
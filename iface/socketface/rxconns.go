@@ -55,14 +55,19 @@ func (rxc *rxConns) run(face *socketFace) error {
 		pkt := vec[0]
 		pkt.SetHeadroom(0)
 
-		for {
-			n, e := pkt.ReadFrom(face.transport)
-			if e != nil {
-				vec.Close()
-				return e
-			}
-			if n > 0 {
-				break
+		if face.initial != nil { // first datagram received by listener
+			pkt.Append(face.initial)
+			face.initial = nil
+		} else {
+			for {
+				n, e := pkt.ReadFrom(face.transport)
+				if e != nil {
+					vec.Close()
+					return e
+				}
+				if n > 0 {
+					break
+				}
 			}
 		}
 
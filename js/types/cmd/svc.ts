@@ -1,6 +1,7 @@
 import type { EalConfig, LCoreAllocConfig, PktmbufPoolTemplateUpdates } from "../dpdk.js";
 import type { FwdpConfig } from "../fwdp.js";
 import type { FaceLocator, SocketFaceGlobalConfig } from "../iface.js";
+import type { NfdServerConfig } from "../nfdserver.js";
 import type { FileServerConfig } from "../tg/mod.js";
 
 export interface ActivateArgsCommon<Roles extends string = never> {
@@ -17,6 +18,9 @@ export interface ActivateArgsCommon<Roles extends string = never> {
  */
 export interface ActivateFwArgs extends ActivateArgsCommon<"RX" | "TX" | "CRYPTO" | "DISK" | "FWD">, FwdpConfig {
   mempool?: PktmbufPoolTemplateUpdates<"DIRECT" | "INDIRECT" | "HEADER">;
+
+  /** Enable NFD management responder. */
+  nfdServer?: NfdServerConfig;
 }
 
 /**
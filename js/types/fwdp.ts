@@ -1,7 +1,7 @@
 import type { Uint } from "./core.js";
 import type { BdevLocator } from "./dpdk.js";
 import type { FibConfig } from "./fib.js";
-import type { NdtConfig } from "./ndt.js";
+import type { NdtBalanceConfig, NdtConfig } from "./ndt.js";
 import type { PcctConfig } from "./pcct.js";
 import type { SuppressConfig } from "./pit.js";
 import type { PktQueueConfig } from "./pktqueue.js";
@@ -12,6 +12,7 @@ import type { PktQueueConfig } from "./pktqueue.js";
  */
 export interface FwdpConfig {
   ndt?: NdtConfig;
+  ndtBalance?: NdtBalanceConfig;
   fib?: FibConfig;
   pcct?: PcctConfig;
   suppress?: SuppressConfig;
@@ -32,11 +32,40 @@ export interface FaceConfig {
    */
   outputQueueSize?: Uint;
 
+  /**
+   * @minimum 0
+   * @default 0
+   */
+  congMarkThreshold?: Uint;
+
   /**
    * @minimum 960
    * @maximum 65000
    */
   mtu?: Uint;
+
+  /**
+   * NDNLPv2 link reliability, disabled if omitted.
+   */
+  reliability?: FaceReliabilityConfig;
+}
+
+/**
+ * NDNLPv2 link reliability configuration.
+ * @see <https://pkg.go.dev/github.com/usnistgov/ndn-dpdk/iface#ReliabilityConfig>
+ */
+export interface FaceReliabilityConfig {
+  /**
+   * @default 200
+   */
+  retxTimeout?: NNMilliseconds;
+
+  /**
+   * @minimum 1
+   * @maximum 255
+   * @default 3
+   */
+  maxRetx?: Uint;
 }
 
 /**
@@ -63,8 +92,38 @@ export type EthPortConfig = EthNetifConfig & {
   mtu?: Uint;
 
   rxFlowQueues?: number;
+
+  onDemand?: EthOnDemandConfig;
 };
 
+/**
+ * Ethernet port on-demand face creation config.
+ * @see <https://pkg.go.dev/github.com/usnistgov/ndn-dpdk/iface/ethport#OnDemandConfig>
+ */
+export interface EthOnDemandConfig {
+  ether?: boolean;
+
+  /**
+   * @minItems 1
+   */
+  udpPorts?: Uint[];
+
+  /**
+   * @default 256
+   */
+  maxFaces?: Uint;
+
+  /**
+   * @default 10
+   */
+  maxCreationRate?: number;
+
+  /**
+   * @default 600000
+   */
+  idleTimeout?: NNMilliseconds;
+}
+
 interface EthFaceConfig extends FaceConfig {
   port?: string;
 
@@ -265,6 +324,20 @@ export interface SocketFaceLocator extends SocketFaceConfig {
   remote: string;
 }
 
+/**
+ * Socket listener configuration.
+ * @see <https://pkg.go.dev/github.com/usnistgov/ndn-dpdk/iface/socketface#ListenerConfig>
+ */
+export interface SocketListenerConfig extends SocketFaceConfig {
+  scheme: "udp" | "tcp" | "unix";
+  local: string;
+
+  /**
+   * @default 600000
+   */
+  idleTimeout?: NNMilliseconds;
+}
+
 /**
  * Face counters.
  * @see <https://pkg.go.dev/github.com/usnistgov/ndn-dpdk/iface#Counters>
@@ -283,6 +356,7 @@ export interface FaceRxCounters {
   rxDecodeErrs: Counter;
   rxReassPackets: Counter;
   rxReassDrops: Counter;
+  rxAcks: Counter;
 }
 
 export interface FaceTxCounters {
@@ -296,4 +370,9 @@ export interface FaceTxCounters {
   txFragBad: Counter;
   txAllocErrs: Counter;
   txDropped: Counter;
+  txCongMarks: Counter;
+
+  txAcks: Counter;
+  txRetx: Counter;
+  txLost: Counter;
 }
@@ -7,6 +7,7 @@ export * from "./iface.js";
 export * from "./mgmt/mod.js";
 export * from "./ndni.js";
 export * from "./ndt.js";
+export * from "./nfdserver.js";
 export * from "./pcct.js";
 export * from "./pit.js";
 export * from "./pktqueue.js";
@@ -46,8 +46,83 @@ export interface DataGen {
   freshnessPeriod?: NNMilliseconds;
 
   /**
+   * Content payload length; ignored if `payloadLenDist` is specified.
    * @default 0
    * @minimum 0
+   * @maximum 65535
    */
   payloadLen?: Uint;
+
+  payloadLenDist?: PayloadLenDist;
+
+  /**
+   * Filename to read Content payload from.
+   * If the file is shorter than the maximum payload length, its content is repeated.
+   * If the file is longer, only its beginning is used.
+   */
+  payloadFile?: string;
+}
+
+/**
+ * Data payload length distribution.
+ * Exactly one of `min`+`max`, `histogram`, `sizes` should be specified.
+ * @see <https://pkg.go.dev/github.com/usnistgov/ndn-dpdk/ndni#PayloadLenDist>
+ */
+export interface PayloadLenDist {
+  /**
+   * Minimum payload length in uniform distribution.
+   * @minimum 0
+   * @maximum 65535
+   */
+  min?: Uint;
+
+  /**
+   * Maximum payload length in uniform distribution.
+   * @minimum 0
+   * @maximum 65535
+   */
+  max?: Uint;
+
+  /** Empirical histogram. */
+  histogram?: PayloadLenDist.Bin[];
+
+  /** List of sizes with weights. */
+  sizes?: PayloadLenDist.Size[];
+}
+
+export namespace PayloadLenDist {
+  export interface Bin {
+    /**
+     * @minimum 0
+     * @maximum 65535
+     */
+    min: Uint;
+
+    /**
+     * @minimum 0
+     * @maximum 65535
+     */
+    max: Uint;
+
+    /**
+     * Relative frequency of this bin.
+     * @minimum 0
+     */
+    weight: number;
+  }
+
+  export interface Size {
+    /**
+     * @minimum 0
+     * @maximum 65535
+     */
+    len: Uint;
+
+    /**
+     * Relative frequency of this size.
+     * @default 1
+     * @minimum 0
+     */
+    weight?: number;
+  }
 }
@@ -1,4 +1,4 @@
-import type { Uint } from "./core.js";
+import type { NNMilliseconds, Uint } from "./core.js";
 
 /**
  * Name Dispatch Table (NDT) configuration.
@@ -26,3 +26,26 @@ export interface NdtConfig {
    */
   sampleInterval?: Uint;
 }
+
+/**
+ * NDT automatic load balancing configuration.
+ * @see <https://pkg.go.dev/github.com/usnistgov/ndn-dpdk/container/ndt#BalanceConfig>
+ */
+export interface NdtBalanceConfig {
+  /**
+   * @default 0
+   */
+  interval?: NNMilliseconds;
+
+  /**
+   * @minimum 0
+   * @default 0.2
+   */
+  threshold?: number;
+
+  /**
+   * @minimum 1
+   * @default 64
+   */
+  maxMoves?: Uint;
+}
@@ -0,0 +1,16 @@
+import type { SocketFaceConfig } from "./iface.js";
+import type { Name } from "./ndni.js";
+
+/**
+ * NFD management responder configuration.
+ * @see <https://pkg.go.dev/github.com/usnistgov/ndn-dpdk/app/nfdserver#Config>
+ */
+export interface NfdServerConfig {
+  /**
+   * Command prefix.
+   * @default "/localhost/nfd"
+   */
+  prefix?: Name;
+
+  face?: SocketFaceConfig;
+}
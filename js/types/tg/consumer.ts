@@ -8,8 +8,60 @@ import type { PktQueueConfig } from "../pktqueue.js";
  */
 export interface TgcConfig {
   rxQueue?: PktQueueConfig.Plain | PktQueueConfig.Delay;
-  interval: NNNanoseconds;
-  patterns: TgcPattern[];
+  /** Average Interest interval in open-loop mode. */
+  interval?: NNNanoseconds;
+
+  /**
+   * Interest arrival process in open-loop mode.
+   * @default "constant"
+   */
+  arrival?: "constant" | "poisson";
+
+  /**
+   * Maximum outstanding Interests per pattern; positive value enables closed-loop mode.
+   * @default 0
+   * @minimum 0
+   * @maximum 65536
+   */
+  window?: Uint;
+
+  /** Required unless `trace` is specified. */
+  patterns?: TgcPattern[];
+  trace?: TgcTraceConfig;
+}
+
+/**
+ * Traffic generator consumer trace-driven replay config.
+ * @see <https://pkg.go.dev/github.com/usnistgov/ndn-dpdk/app/tgconsumer#TraceConfig>
+ */
+export interface TgcTraceConfig {
+  /** Trace file name, ending with .pcapng, .csv, .json, or .jsonl extension. */
+  filename: string;
+
+  /**
+   * Whether to replay outgoing Interests from a pcapng trace.
+   * @default false
+   */
+  outgoing?: boolean;
+
+  /**
+   * Number of name components that identifies a trace prefix.
+   * @default 1
+   * @minimum 1
+   */
+  prefixLen?: Uint;
+
+  /**
+   * Speed-up factor.
+   * @default 1
+   */
+  speed?: number;
+
+  /**
+   * Whether to restart from the beginning after reaching the end of trace.
+   * @default false
+   */
+  loop?: boolean;
 }
 
 /**
@@ -29,12 +81,47 @@ export interface TgcPattern extends InterestTemplate {
   seqNumOffset?: Uint;
 
   digest?: DataGen;
+
+  popularity?: TgcPopularity;
+}
+
+/**
+ * Traffic generator consumer popularity distribution definition.
+ * @see <https://pkg.go.dev/github.com/usnistgov/ndn-dpdk/app/tgconsumer#PopularityConfig>
+ */
+export interface TgcPopularity {
+  /**
+   * Number of names in the catalog.
+   * @minimum 1
+   * @maximum 4194304
+   */
+  catalogSize: Uint;
+
+  /**
+   * Popularity distribution.
+   * @default "zipf"
+   */
+  distribution?: "zipf" | "uniform";
+
+  /**
+   * Exponent of Zipf distribution.
+   * @default 1
+   */
+  alpha?: number;
+
+  /**
+   * RTT threshold for classifying Data as cache hits.
+   * Zero disables hit/miss counters.
+   * @default 0
+   */
+  hitRtt?: NNNanoseconds;
 }
 
 export interface TgcCounters extends TgcCounters.PacketCounters {
   nAllocError: Counter;
   rtt: RunningStatSnapshot;
   perPattern: TgcCounters.PatternCounters[];
+  trace?: TgcCounters.TraceCounters;
 }
 
 export namespace TgcCounters {
@@ -46,5 +133,23 @@ export namespace TgcCounters {
 
   export interface PatternCounters extends PacketCounters {
     rtt: RunningStatSnapshot;
+    cache?: CacheCounters;
+    window?: WindowCounters;
+  }
+
+  export interface CacheCounters {
+    nHits: Counter;
+    nMisses: Counter;
+  }
+
+  export interface WindowCounters {
+    nOutstanding: Counter;
+    nTimeouts: Counter;
+  }
+
+  export interface TraceCounters {
+    nRecords: Counter;
+    pos: Counter;
+    nLoops: Counter;
   }
 }
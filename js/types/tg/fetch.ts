@@ -31,6 +31,42 @@ export interface FetchTaskDef extends InterestTemplate {
   filename?: string;
   fileSize?: Uint;
   segmentLen?: Uint;
+
+  congestionControl?: FetchCcConfig;
+
+  /**
+   * Output directory of a directory task.
+   * If set, prefix refers to a directory on ndn6-file-server, which is fetched recursively.
+   */
+  directory?: string;
+}
+
+export type FetchCcAlgo = "CUBIC" | "AIMD" | "BBR" | "FIXED";
+
+export interface FetchCcConfig {
+  /** @default "CUBIC" */
+  algo?: FetchCcAlgo;
+
+  /**
+   * @exclusiveMinimum 0
+   * @exclusiveMaximum 1
+   * @default 0.5
+   */
+  aimdDecrease?: number;
+
+  /**
+   * @minimum 1
+   * @default 64
+   */
+  fixedCwnd?: Uint;
+}
+
+export interface FetchCcState {
+  ssthresh?: number;
+  wMax?: number;
+  phase?: "startup" | "drain" | "probeBw";
+  btlBw?: number;
+  minRtt?: NNNanoseconds;
 }
 
 export interface FetchCounters {
@@ -40,7 +76,20 @@ export interface FetchCounters {
   sRtt: NNNanoseconds;
   rto: NNNanoseconds;
   cwnd: Counter;
+  cc: FetchCcAlgo;
+  ccState: FetchCcState;
   nInFlight: Counter;
   nTxRetx: Counter;
   nRxData: Counter;
+  dir?: FetchDirProgress;
+}
+
+export interface FetchDirProgress {
+  listed: boolean;
+  nFiles: Counter;
+  nFilesDone: Counter;
+  nBytes: Counter;
+  nBytesDone: Counter;
+  current?: string;
+  error?: string;
 }
@@ -33,6 +33,7 @@ export namespace TgpReply {
   }
 
   export interface Data extends Common, DataGen {
+    signer?: TgpSigner;
   }
 
   export interface Nack extends Common {
@@ -48,11 +49,39 @@ export namespace TgpReply {
   }
 }
 
+/**
+ * Traffic generator producer Data signing definition.
+ * @see <https://pkg.go.dev/github.com/usnistgov/ndn-dpdk/app/tgproducer#SignerConfig>
+ */
+export interface TgpSigner {
+  algorithm: "ecdsa" | "hmac";
+
+  /** KeyLocator name. */
+  keyName?: Name;
+
+  /**
+   * Signing key, base64 encoded.
+   * For ECDSA, it is a P-256 private key in PKCS#8 format.
+   * For HMAC, it is a secret key between 1 and 64 octets.
+   * If omitted, a random key is generated.
+   */
+  key?: string;
+
+  /**
+   * Whether to disable crypto device offload.
+   * @default false
+   */
+  noOffload?: boolean;
+}
+
 export interface TgpCounters {
   perPattern: TgpCounters.PatternCounters[];
   nInterests: Counter;
   nNoMatch: Counter;
   nAllocError: Counter;
+  nSigned: Counter;
+  nSignDrops: Counter;
+  nSignErrors: Counter;
 }
 export namespace TgpCounters {
   export interface PatternCounters {
@@ -12,6 +12,7 @@ OUTDIR=$MESON_BUILD_ROOT/share/ndn-dpdk
 mkdir -p "$OUTDIR"
 node mk/schema/make-jrgen.js $INFILE Mgmt >"$OUTDIR"/jsonrpc2.jrgen.json
 node mk/schema/make-schema.js $INFILE FaceLocator >"$OUTDIR"/locator.schema.json
+node mk/schema/make-schema.js $INFILE SocketListenerConfig >"$OUTDIR"/socketlistener.schema.json
 node mk/schema/make-schema.js $INFILE ActivateFwArgs >"$OUTDIR"/forwarder.schema.json
 node mk/schema/make-schema.js $INFILE ActivateGenArgs >"$OUTDIR"/trafficgen.schema.json
 node mk/schema/make-schema.js $INFILE ActivateFileServerArgs >"$OUTDIR"/fileserver.schema.json
@@ -12,7 +12,10 @@ const (
 	TtPitToken       = 0x62
 	TtNack           = 0x0320
 	TtNackReason     = 0x0321
+	TtIncomingFaceID = 0x032C
 	TtCongestionMark = 0x0340
+	TtLpAck          = 0x0344
+	TtLpTxSequence   = 0x0348
 
 	TtName                            = 0x07
 	TtGenericNameComponent            = 0x08
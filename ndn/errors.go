@@ -8,6 +8,7 @@ import (
 var (
 	ErrFragment      = errors.New("bad fragment")
 	ErrL3Type        = errors.New("unknown L3 packet type")
+	ErrLpRel         = errors.New("bad TxSequence or Ack")
 	ErrComponentType = errors.New("NameComponent TLV-TYPE out of range")
 	ErrNonceLen      = errors.New("Nonce wrong length")
 	ErrLifetime      = errors.New("InterestLifetime out of range")
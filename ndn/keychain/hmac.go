@@ -0,0 +1,95 @@
+package keychain
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
+)
+
+// HMACKeyLength is the secret length of a key created by NewHMACKeyPair.
+const HMACKeyLength = sha256.Size
+
+// ErrHMACSPKI indicates a HMAC key cannot be exported as SubjectPublicKeyInfo.
+var ErrHMACSPKI = errors.New("HMAC key has no SubjectPublicKeyInfo")
+
+// hmacSecret is a HMAC-SHA256 secret key.
+type hmacSecret []byte
+
+// NewHMACPrivateKey creates a signing key for SigHmacWithSha256 signature type.
+func NewHMACPrivateKey(keyName ndn.Name, secret []byte) (PrivateKey, error) {
+	key := hmacSecret(secret)
+	return newPrivateKey(an.SigHmacWithSha256, keyName, key, func(input []byte) (sig []byte, e error) {
+		return key.sum(input), nil
+	})
+}
+
+// NewHMACPublicKey creates a verification key for SigHmacWithSha256 signature type.
+// Since HMAC is symmetric, secret must be the same as the signing key.
+// The returned key does not support SPKI method.
+func NewHMACPublicKey(keyName ndn.Name, secret []byte) (PublicKey, error) {
+	key := hmacSecret(secret)
+	return newPublicKey(an.SigHmacWithSha256, keyName, key, func(input, sig []byte) error {
+		if !hmac.Equal(sig, key.sum(input)) {
+			return ndn.ErrSigValue
+		}
+		return nil
+	})
+}
+
+// NewHMACKeyPair creates a random key for SigHmacWithSha256 signature type.
+func NewHMACKeyPair(name ndn.Name) (PrivateKey, PublicKey, error) {
+	keyName := ToKeyName(name)
+	secret := make([]byte, HMACKeyLength)
+	if _, e := rand.Read(secret); e != nil {
+		return nil, nil, e
+	}
+	pvt, e := NewHMACPrivateKey(keyName, secret)
+	if e != nil {
+		return nil, nil, e
+	}
+	pub, e := NewHMACPublicKey(keyName, secret)
+	if e != nil {
+		return nil, nil, e
+	}
+	return pvt, pub, e
+}
+
+func (key hmacSecret) sum(input []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(input)
+	return h.Sum(nil)
+}
+
+// oidHMACWithSHA256 is the hmacWithSHA256 algorithm identifier defined in RFC 8018.
+var oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+
+// hmacPKCS8 is a PKCS#8 PrivateKeyInfo structure that carries a HMAC secret key.
+type hmacPKCS8 struct {
+	Version    int
+	Algorithm  pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+func (key hmacSecret) marshalPKCS8() ([]byte, error) {
+	return asn1.Marshal(hmacPKCS8{
+		Algorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  oidHMACWithSHA256,
+			Parameters: asn1.NullRawValue,
+		},
+		PrivateKey: key,
+	})
+}
+
+func parseHMACPKCS8(der []byte) (key hmacSecret, ok bool) {
+	var info hmacPKCS8
+	if rest, e := asn1.Unmarshal(der, &info); e != nil || len(rest) > 0 || !info.Algorithm.Algorithm.Equal(oidHMACWithSHA256) {
+		return nil, false
+	}
+	return hmacSecret(info.PrivateKey), true
+}
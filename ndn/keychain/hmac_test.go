@@ -0,0 +1,77 @@
+package keychain_test
+
+import (
+	"testing"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
+	"github.com/usnistgov/ndn-dpdk/ndn/keychain"
+	"github.com/usnistgov/ndn-dpdk/ndn/ndntestenv"
+	"github.com/usnistgov/ndn-dpdk/ndn/ndntestvector"
+)
+
+func TestHMACSigning(t *testing.T) {
+	assert, require := makeAR(t)
+	secretA := []byte("0123456789ABCDEF0123456789ABCDEF")
+
+	subjectName := ndn.ParseName("/K")
+	_, e := keychain.NewHMACPrivateKey(subjectName, secretA)
+	assert.Error(e)
+	_, e = keychain.NewHMACPublicKey(subjectName, secretA)
+	assert.Error(e)
+
+	keyNameA := keychain.ToKeyName(subjectName)
+	pvtA, e := keychain.NewHMACPrivateKey(keyNameA, secretA)
+	require.NoError(e)
+	pubA, e := keychain.NewHMACPublicKey(keyNameA, secretA)
+	require.NoError(e)
+	nameEqual(assert, keyNameA, pvtA)
+	nameEqual(assert, keyNameA, pubA)
+
+	pvtB, pubB, e := keychain.NewHMACKeyPair(subjectName)
+	require.NoError(e)
+	nameEqual(assert, pvtB, pubB)
+
+	_, e = pubA.SPKI()
+	assert.ErrorIs(e, keychain.ErrHMACSPKI)
+	pvtWireEnc, e := keychain.MarshalKeyEncrypted(pvtA, []byte("PASSPHRASE"))
+	require.NoError(e)
+	assert.NotContains(string(pvtWireEnc), string(secretA))
+	_, e = keychain.UnmarshalKeyEncrypted(pvtWireEnc, []byte("WRONG"))
+	assert.Error(e)
+	pvtDec, e := keychain.UnmarshalKeyEncrypted(pvtWireEnc, []byte("PASSPHRASE"))
+	require.NoError(e)
+	nameEqual(assert, pubA, pvtDec)
+
+	pvtWireA, e := keychain.MarshalKey(pvtA)
+	require.NoError(e)
+	pvtA, e = keychain.UnmarshalKey(pvtWireA)
+	require.NoError(e)
+	nameEqual(assert, pubA, pvtA)
+
+	var c ndntestenv.SignVerifyTester
+	c.PvtA, c.PvtB, c.PubA, c.PubB = pvtA, pvtB, pubA, pubB
+	c.CheckInterest(t)
+	c.CheckInterestParameterized(t)
+	rec := c.CheckData(t)
+
+	dataA := rec.PktA.(*ndn.Data)
+	assert.EqualValues(an.SigHmacWithSha256, dataA.SigInfo.Type)
+	nameEqual(assert, pubA, dataA.SigInfo.KeyLocator)
+}
+
+func TestHMACVerify(t *testing.T) {
+	assert, require := makeAR(t)
+
+	data := ndntestvector.HMACDemo()
+	assert.EqualValues(an.SigHmacWithSha256, data.SigInfo.Type)
+	nameEqual(assert, "/HMAC-demo/KEY/demo", data.SigInfo.KeyLocator)
+
+	pub, e := keychain.NewHMACPublicKey(ndn.ParseName("/HMAC-demo/KEY/demo"), ndntestvector.HMACDemoSecret)
+	require.NoError(e)
+	assert.NoError(pub.Verify(data))
+
+	wrong, e := keychain.NewHMACPublicKey(ndn.ParseName("/HMAC-demo/KEY/demo"), []byte("wrong secret"))
+	require.NoError(e)
+	assert.ErrorIs(wrong.Verify(data), ndn.ErrSigValue)
+}
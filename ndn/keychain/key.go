@@ -35,7 +35,7 @@ func (signer namedSigner) Sign(packet ndn.Signable) error {
 
 type privateKey struct {
 	namedSigner
-	key any // *rsa.PrivateKey or *ecdsa.PrivateKey or ed25519.PrivateKey
+	key any // *rsa.PrivateKey or *ecdsa.PrivateKey or ed25519.PrivateKey or hmacSecret
 }
 
 func (pvt privateKey) Name() ndn.Name {
@@ -76,7 +76,7 @@ type PublicKey interface {
 type publicKey struct {
 	sigType  uint32
 	keyName  ndn.Name
-	key      any // *rsa.PublicKey or *ecdsa.PublicKey or ed25519.PublicKey
+	key      any // *rsa.PublicKey or *ecdsa.PublicKey or ed25519.PublicKey or hmacSecret
 	llVerify ndn.LLVerify
 }
 
@@ -97,6 +97,9 @@ func (pub publicKey) Verify(packet ndn.Verifiable) error {
 }
 
 func (pub publicKey) SPKI() (spki []byte, e error) {
+	if _, ok := pub.key.(hmacSecret); ok {
+		return nil, ErrHMACSPKI
+	}
 	return x509.MarshalPKIXPublicKey(pub.key)
 }
 
@@ -11,10 +11,18 @@ import (
 
 	"github.com/usnistgov/ndn-dpdk/ndn"
 	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
+	"github.com/youmark/pkcs8"
 )
 
 // MarshalKey serializes a private key to an internal format.
 func MarshalKey(key PrivateKey) ([]byte, error) {
+	return MarshalKeyEncrypted(key, nil)
+}
+
+// MarshalKeyEncrypted serializes a private key to an internal format.
+// If passphrase is non-empty, the key is encrypted in the same way as ExportSafeBag.
+// The key name is not encrypted.
+func MarshalKeyEncrypted(key PrivateKey, passphrase []byte) ([]byte, error) {
 	pkey, _ := key.(*privateKey)
 	if pkey == nil {
 		return nil, fmt.Errorf("unknown key type %T", key)
@@ -25,28 +33,54 @@ func MarshalKey(key PrivateKey) ([]byte, error) {
 		return nil, e
 	}
 
-	pkcs8, e := x509.MarshalPKCS8PrivateKey(key.(*privateKey).key)
+	var pkcs8Wire []byte
+	if secret, ok := pkey.key.(hmacSecret); ok {
+		if pkcs8Wire, e = secret.marshalPKCS8(); e == nil && len(passphrase) > 0 {
+			pkcs8Wire, e = encryptPKCS8(pkcs8Wire, passphrase)
+		}
+	} else if len(passphrase) == 0 {
+		pkcs8Wire, e = x509.MarshalPKCS8PrivateKey(pkey.key)
+	} else {
+		pkcs8Wire, e = pkcs8.MarshalPrivateKey(pkey.key, passphrase, nil)
+	}
 	if e != nil {
 		return nil, e
 	}
 
-	return bytes.Join([][]byte{name, pkcs8}, nil), nil
+	return bytes.Join([][]byte{name, pkcs8Wire}, nil), nil
 }
 
 // UnmarshalKey deserializes a private key from the result of MarshalKey.
 func UnmarshalKey(wire []byte) (PrivateKey, error) {
-	d := tlv.DecodingBuffer(wire)
-	nameEle, e := d.Element()
+	return UnmarshalKeyEncrypted(wire, nil)
+}
+
+// UnmarshalKeyEncrypted deserializes a private key from the result of MarshalKeyEncrypted.
+// passphrase must match the value given to MarshalKeyEncrypted.
+func UnmarshalKeyEncrypted(wire, passphrase []byte) (PrivateKey, error) {
+	name, pkcs8Wire, e := splitMarshaledKey(wire)
 	if e != nil {
 		return nil, e
 	}
-	var name ndn.Name
-	if e := nameEle.UnmarshalValue(&name); e != nil {
-		return nil, e
+	if len(passphrase) > 0 {
+		plain, e := decryptPKCS8(pkcs8Wire, passphrase)
+		switch {
+		case e == nil:
+			pkcs8Wire, passphrase = plain, nil
+		case !errors.Is(e, errUnsupportedPBES2):
+			return nil, e
+		}
+	}
+	if secret, ok := parseHMACPKCS8(pkcs8Wire); ok {
+		return NewHMACPrivateKey(name, secret)
 	}
 
-	pkcs8 := d.Rest()
-	key, e := x509.ParsePKCS8PrivateKey(pkcs8)
+	var key any
+	if len(passphrase) == 0 {
+		key, e = x509.ParsePKCS8PrivateKey(pkcs8Wire)
+	} else {
+		key, e = pkcs8.ParsePKCS8PrivateKey(pkcs8Wire, passphrase)
+	}
 	if e != nil {
 		return nil, e
 	}
@@ -62,6 +96,18 @@ func UnmarshalKey(wire []byte) (PrivateKey, error) {
 	return nil, fmt.Errorf("unknown private key type %T", key)
 }
 
+func splitMarshaledKey(wire []byte) (name ndn.Name, pkcs8Wire []byte, e error) {
+	d := tlv.DecodingBuffer(wire)
+	nameEle, e := d.Element()
+	if e != nil {
+		return nil, nil, e
+	}
+	if e := nameEle.UnmarshalValue(&name); e != nil {
+		return nil, nil, e
+	}
+	return name, d.Rest(), nil
+}
+
 // MarshalCert serializes a certificate to an internal format.
 func MarshalCert(cert *Certificate) ([]byte, error) {
 	return tlv.EncodeFrom(cert.Data())
@@ -0,0 +1,148 @@
+package keychain
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+
+	"github.com/youmark/pkcs8"
+)
+
+var (
+	oidPBES2              = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2             = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidPRFHMACWithSHA1    = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidPRFHMACWithSHA256  = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	errUnsupportedPBES2   = errors.New("unsupported PBES2 parameters")
+	errPBES2Decryption    = errors.New("PBES2 decryption failed")
+	pbes2EncryptionScheme = pkcs8.DefaultOpts.Cipher
+)
+
+type pbes2EncryptedPrivateKeyInfo struct {
+	EncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedData       []byte
+}
+
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// encryptPKCS8 encrypts a PKCS#8 PrivateKeyInfo into an EncryptedPrivateKeyInfo.
+// It produces the same PBES2 envelope as pkcs8.MarshalPrivateKey with default options, but also
+// accepts private keys not recognized by crypto/x509, such as HMAC secrets.
+func encryptPKCS8(plain, passphrase []byte) ([]byte, error) {
+	kdf := pkcs8.DefaultOpts.KDFOpts
+	salt := make([]byte, kdf.GetSaltSize())
+	iv := make([]byte, pbes2EncryptionScheme.IVSize())
+	if _, e := rand.Read(salt); e != nil {
+		return nil, e
+	}
+	if _, e := rand.Read(iv); e != nil {
+		return nil, e
+	}
+
+	key, kdfParams, e := kdf.DeriveKey(passphrase, salt, pbes2EncryptionScheme.KeySize())
+	if e != nil {
+		return nil, e
+	}
+	encrypted, e := pbes2EncryptionScheme.Encrypt(key, iv, plain)
+	if e != nil {
+		return nil, e
+	}
+
+	kdfParamsWire, e := asn1.Marshal(kdfParams)
+	if e != nil {
+		return nil, e
+	}
+	ivWire, e := asn1.Marshal(iv)
+	if e != nil {
+		return nil, e
+	}
+	paramsWire, e := asn1.Marshal(pbes2Params{
+		KeyDerivationFunc: pkix.AlgorithmIdentifier{
+			Algorithm:  kdf.OID(),
+			Parameters: asn1.RawValue{FullBytes: kdfParamsWire},
+		},
+		EncryptionScheme: pkix.AlgorithmIdentifier{
+			Algorithm:  pbes2EncryptionScheme.OID(),
+			Parameters: asn1.RawValue{FullBytes: ivWire},
+		},
+	})
+	if e != nil {
+		return nil, e
+	}
+	return asn1.Marshal(pbes2EncryptedPrivateKeyInfo{
+		EncryptionAlgorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPBES2,
+			Parameters: asn1.RawValue{FullBytes: paramsWire},
+		},
+		EncryptedData: encrypted,
+	})
+}
+
+// decryptPKCS8 decrypts an EncryptedPrivateKeyInfo into a PKCS#8 PrivateKeyInfo.
+// It supports PBKDF2 key derivation and the encryption scheme used by encryptPKCS8.
+// Other parameters cause errUnsupportedPBES2.
+func decryptPKCS8(wire, passphrase []byte) ([]byte, error) {
+	var info pbes2EncryptedPrivateKeyInfo
+	if rest, e := asn1.Unmarshal(wire, &info); e != nil || len(rest) > 0 || !info.EncryptionAlgorithm.Algorithm.Equal(oidPBES2) {
+		return nil, errUnsupportedPBES2
+	}
+
+	var params pbes2Params
+	if _, e := asn1.Unmarshal(info.EncryptionAlgorithm.Parameters.FullBytes, &params); e != nil ||
+		!params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) ||
+		!params.EncryptionScheme.Algorithm.Equal(pbes2EncryptionScheme.OID()) {
+		return nil, errUnsupportedPBES2
+	}
+
+	var kdfParams pbkdf2Params
+	if _, e := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdfParams); e != nil {
+		return nil, errUnsupportedPBES2
+	}
+	var prf crypto.Hash
+	switch {
+	case len(kdfParams.PRF.Algorithm) == 0, kdfParams.PRF.Algorithm.Equal(oidPRFHMACWithSHA1):
+		prf = crypto.SHA1
+	case kdfParams.PRF.Algorithm.Equal(oidPRFHMACWithSHA256):
+		prf = crypto.SHA256
+	default:
+		return nil, errUnsupportedPBES2
+	}
+
+	var iv []byte
+	if _, e := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); e != nil ||
+		len(iv) != pbes2EncryptionScheme.IVSize() {
+		return nil, errUnsupportedPBES2
+	}
+	if len(info.EncryptedData) == 0 || len(info.EncryptedData)%len(iv) != 0 {
+		return nil, errPBES2Decryption
+	}
+
+	kdf := pkcs8.PBKDF2Opts{IterationCount: kdfParams.IterationCount, HMACHash: prf}
+	key, _, e := kdf.DeriveKey(passphrase, kdfParams.Salt, pbes2EncryptionScheme.KeySize())
+	if e != nil {
+		return nil, e
+	}
+	plain, e := pbes2EncryptionScheme.Decrypt(key, iv, info.EncryptedData)
+	if e != nil {
+		return nil, errPBES2Decryption
+	}
+
+	// remove PKCS#7 padding, which is not removed by pkcs8 cipher
+	padLen := int(plain[len(plain)-1])
+	if padLen == 0 || padLen > len(plain) || !bytes.Equal(plain[len(plain)-padLen:], bytes.Repeat([]byte{byte(padLen)}, padLen)) {
+		return nil, errPBES2Decryption
+	}
+	return plain[:len(plain)-padLen], nil
+}
@@ -0,0 +1,93 @@
+package keychain
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// OpenFileStore opens a filesystem-backed Store.
+// dir is a directory that is created if it does not exist.
+//
+// If passphrase is non-empty, private keys are encrypted with the passphrase, see MarshalKeyEncrypted.
+// Certificates and the default identity are stored unencrypted.
+func OpenFileStore(dir string, passphrase []byte) (Store, error) {
+	b := &fileStoreBackend{dir: dir}
+	for _, kind := range []storeKind{storeKey, storeCert, storeDefault} {
+		if e := os.MkdirAll(b.kindDir(kind), 0o700); e != nil {
+			return nil, e
+		}
+	}
+	return &store{
+		backend:    b,
+		passphrase: passphrase,
+	}, nil
+}
+
+type fileStoreBackend struct {
+	dir string
+}
+
+func (b *fileStoreBackend) kindDir(kind storeKind) string {
+	return filepath.Join(b.dir, string(kind))
+}
+
+func (b *fileStoreBackend) filename(kind storeKind, id string) string {
+	return filepath.Join(b.dir, string(kind), id)
+}
+
+func (b *fileStoreBackend) List(kind storeKind) (list [][]byte, e error) {
+	entries, e := os.ReadDir(b.kindDir(kind))
+	if e != nil {
+		return nil, e
+	}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || filepath.Ext(entry.Name()) != "" {
+			continue
+		}
+		wire, e := b.Get(kind, entry.Name())
+		if errors.Is(e, ErrNotFound) { // deleted concurrently
+			continue
+		}
+		if e != nil {
+			return nil, e
+		}
+		list = append(list, wire)
+	}
+	return list, nil
+}
+
+func (b *fileStoreBackend) Get(kind storeKind, id string) ([]byte, error) {
+	wire, e := os.ReadFile(b.filename(kind, id))
+	if errors.Is(e, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return wire, e
+}
+
+func (b *fileStoreBackend) Put(kind storeKind, id string, wire []byte) error {
+	// write to a temporary file and then rename, so that a crash does not leave a partial file
+	tmp, e := os.CreateTemp(b.kindDir(kind), id+".*.tmp")
+	if e != nil {
+		return e
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, e := tmp.Write(wire); e != nil {
+		tmp.Close()
+		return e
+	}
+	if e := tmp.Close(); e != nil {
+		return e
+	}
+	return os.Rename(tmp.Name(), b.filename(kind, id))
+}
+
+func (b *fileStoreBackend) Delete(kind storeKind, id string) error {
+	e := os.Remove(b.filename(kind, id))
+	if errors.Is(e, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+	return e
+}
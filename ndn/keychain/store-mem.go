@@ -0,0 +1,59 @@
+package keychain
+
+import (
+	"maps"
+	"slices"
+	"sync"
+)
+
+// NewMemStore creates an in-memory Store.
+// Stored objects are lost when the Store is garbage collected.
+// This is mainly useful in unit tests.
+func NewMemStore() Store {
+	return &store{
+		backend: &memStoreBackend{
+			m: map[storeKind]map[string][]byte{},
+		},
+	}
+}
+
+type memStoreBackend struct {
+	mutex sync.RWMutex
+	m     map[storeKind]map[string][]byte
+}
+
+func (b *memStoreBackend) List(kind storeKind) ([][]byte, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return slices.Collect(maps.Values(b.m[kind])), nil
+}
+
+func (b *memStoreBackend) Get(kind storeKind, id string) ([]byte, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	wire, ok := b.m[kind][id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return wire, nil
+}
+
+func (b *memStoreBackend) Put(kind storeKind, id string, wire []byte) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.m[kind] == nil {
+		b.m[kind] = map[string][]byte{}
+	}
+	b.m[kind][id] = slices.Clone(wire)
+	return nil
+}
+
+func (b *memStoreBackend) Delete(kind storeKind, id string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if _, ok := b.m[kind][id]; !ok {
+		return ErrNotFound
+	}
+	delete(b.m[kind], id)
+	return nil
+}
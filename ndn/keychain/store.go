@@ -0,0 +1,263 @@
+package keychain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"slices"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+)
+
+// ErrNotFound indicates the requested object does not exist in the Store.
+var ErrNotFound = errors.New("not found in keychain store")
+
+// Store is a persistent storage of private keys and certificates.
+//
+// Private keys are identified by key names, and certificates are identified by certificate names.
+// An identity is a subject name that has at least one private key in the store.
+type Store interface {
+	// ListIdentities returns subject names of stored private keys.
+	ListIdentities() ([]ndn.Name, error)
+
+	// ListKeys returns names of stored private keys under a prefix.
+	// Use nil prefix to list all keys.
+	ListKeys(prefix ndn.Name) ([]ndn.Name, error)
+
+	// GetKey retrieves a private key by key name or certificate name.
+	GetKey(name ndn.Name) (PrivateKey, error)
+
+	// InsertKey inserts or replaces a private key.
+	InsertKey(key PrivateKey) error
+
+	// DeleteKey deletes a private key and its certificates.
+	DeleteKey(name ndn.Name) error
+
+	// ListCerts returns names of stored certificates under a prefix.
+	// Use nil prefix to list all certificates.
+	ListCerts(prefix ndn.Name) ([]ndn.Name, error)
+
+	// GetCert retrieves a certificate by certificate name.
+	GetCert(name ndn.Name) (*Certificate, error)
+
+	// InsertCert inserts or replaces a certificate.
+	InsertCert(cert *Certificate) error
+
+	// DeleteCert deletes a certificate.
+	DeleteCert(name ndn.Name) error
+
+	// DefaultIdentity returns the default identity.
+	// Returns ErrNotFound if the default identity is unset or has no private key.
+	DefaultIdentity() (ndn.Name, error)
+
+	// SetDefaultIdentity changes the default identity.
+	// The identity must have at least one private key.
+	SetDefaultIdentity(name ndn.Name) error
+}
+
+type storeKind string
+
+const (
+	storeKey     storeKind = "keys"
+	storeCert    storeKind = "certs"
+	storeDefault storeKind = "default"
+)
+
+const storeDefaultIdentity = "identity"
+
+// storeBackend stores serialized objects.
+type storeBackend interface {
+	// List returns all objects of a kind.
+	List(kind storeKind) ([][]byte, error)
+
+	// Get retrieves an object; returns ErrNotFound if it does not exist.
+	Get(kind storeKind, id string) ([]byte, error)
+
+	// Put inserts or replaces an object.
+	Put(kind storeKind, id string, wire []byte) error
+
+	// Delete deletes an object; returns ErrNotFound if it does not exist.
+	Delete(kind storeKind, id string) error
+}
+
+// store implements Store on top of storeBackend.
+type store struct {
+	backend    storeBackend
+	passphrase []byte
+}
+
+var _ Store = (*store)(nil)
+
+func (s *store) ListIdentities() (list []ndn.Name, e error) {
+	keys, e := s.ListKeys(nil)
+	if e != nil {
+		return nil, e
+	}
+	for _, keyName := range keys {
+		subject := ToSubjectName(keyName)
+		if !slices.ContainsFunc(list, subject.Equal) {
+			list = append(list, subject)
+		}
+	}
+	slices.SortFunc(list, ndn.Name.Compare)
+	return list, nil
+}
+
+func (s *store) ListKeys(prefix ndn.Name) ([]ndn.Name, error) {
+	return s.listNames(storeKey, prefix, func(wire []byte) (ndn.Name, error) {
+		name, _, e := splitMarshaledKey(wire)
+		return name, e
+	})
+}
+
+func (s *store) GetKey(name ndn.Name) (PrivateKey, error) {
+	id, e := storeKeyID(name)
+	if e != nil {
+		return nil, e
+	}
+	wire, e := s.backend.Get(storeKey, id)
+	if e != nil {
+		return nil, e
+	}
+	return UnmarshalKeyEncrypted(wire, s.passphrase)
+}
+
+func (s *store) InsertKey(key PrivateKey) error {
+	id, e := storeKeyID(key.Name())
+	if e != nil {
+		return e
+	}
+	wire, e := MarshalKeyEncrypted(key, s.passphrase)
+	if e != nil {
+		return e
+	}
+	return s.backend.Put(storeKey, id, wire)
+}
+
+func (s *store) DeleteKey(name ndn.Name) error {
+	id, e := storeKeyID(name)
+	if e != nil {
+		return e
+	}
+	if e := s.backend.Delete(storeKey, id); e != nil {
+		return e
+	}
+
+	certs, e := s.ListCerts(ToKeyName(name))
+	if e != nil {
+		return e
+	}
+	for _, certName := range certs {
+		if e := s.DeleteCert(certName); e != nil && !errors.Is(e, ErrNotFound) {
+			return e
+		}
+	}
+	return nil
+}
+
+func (s *store) ListCerts(prefix ndn.Name) ([]ndn.Name, error) {
+	return s.listNames(storeCert, prefix, func(wire []byte) (ndn.Name, error) {
+		cert, e := UnmarshalCert(wire)
+		if e != nil {
+			return nil, e
+		}
+		return cert.Name(), nil
+	})
+}
+
+func (s *store) GetCert(name ndn.Name) (*Certificate, error) {
+	if !IsCertName(name) {
+		return nil, ErrCertName
+	}
+	wire, e := s.backend.Get(storeCert, storeID(name))
+	if e != nil {
+		return nil, e
+	}
+	return UnmarshalCert(wire)
+}
+
+func (s *store) InsertCert(cert *Certificate) error {
+	wire, e := MarshalCert(cert)
+	if e != nil {
+		return e
+	}
+	return s.backend.Put(storeCert, storeID(cert.Name()), wire)
+}
+
+func (s *store) DeleteCert(name ndn.Name) error {
+	if !IsCertName(name) {
+		return ErrCertName
+	}
+	return s.backend.Delete(storeCert, storeID(name))
+}
+
+func (s *store) DefaultIdentity() (ndn.Name, error) {
+	wire, e := s.backend.Get(storeDefault, storeDefaultIdentity)
+	if e != nil {
+		return nil, e
+	}
+	var name ndn.Name
+	if e := name.UnmarshalBinary(wire); e != nil {
+		return nil, e
+	}
+	if e := s.checkIdentity(name); e != nil {
+		return nil, e
+	}
+	return name, nil
+}
+
+func (s *store) SetDefaultIdentity(name ndn.Name) error {
+	name = ToSubjectName(name)
+	if e := s.checkIdentity(name); e != nil {
+		return e
+	}
+	wire, e := name.MarshalBinary()
+	if e != nil {
+		return e
+	}
+	return s.backend.Put(storeDefault, storeDefaultIdentity, wire)
+}
+
+func (s *store) checkIdentity(name ndn.Name) error {
+	keys, e := s.ListKeys(name)
+	if e != nil {
+		return e
+	}
+	if !slices.ContainsFunc(keys, func(keyName ndn.Name) bool { return ToSubjectName(keyName).Equal(name) }) {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *store) listNames(kind storeKind, prefix ndn.Name, decodeName func(wire []byte) (ndn.Name, error)) (list []ndn.Name, e error) {
+	wires, e := s.backend.List(kind)
+	if e != nil {
+		return nil, e
+	}
+	for _, wire := range wires {
+		name, e := decodeName(wire)
+		if e != nil {
+			return nil, e
+		}
+		if prefix.IsPrefixOf(name) {
+			list = append(list, name)
+		}
+	}
+	slices.SortFunc(list, ndn.Name.Compare)
+	return list, nil
+}
+
+// storeID derives object ID from key name or certificate name.
+// It is a hash so that arbitrarily long names can be used as filenames.
+func storeID(name ndn.Name) string {
+	value, _ := name.MarshalBinary()
+	sum := sha256.Sum256(value)
+	return hex.EncodeToString(sum[:])
+}
+
+func storeKeyID(name ndn.Name) (string, error) {
+	if !IsKeyName(name) && !IsCertName(name) {
+		return "", ErrKeyName
+	}
+	return storeID(ToKeyName(name)), nil
+}
@@ -0,0 +1,131 @@
+package keychain_test
+
+import (
+	"testing"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/keychain"
+)
+
+func testStore(t *testing.T, open func() keychain.Store) {
+	assert, require := makeAR(t)
+	store := open()
+
+	_, e := store.DefaultIdentity()
+	assert.ErrorIs(e, keychain.ErrNotFound)
+
+	pvtA, pubA, e := keychain.NewECDSAKeyPair(ndn.ParseName("/A"))
+	require.NoError(e)
+	certA, e := keychain.MakeCert(pubA, pvtA, keychain.MakeCertOptions{IssuerID: keychain.ComponentSelfIssuer})
+	require.NoError(e)
+	pvtB, pubB, e := keychain.NewEd25519KeyPair(ndn.ParseName("/B"))
+	require.NoError(e)
+	certB, e := keychain.MakeCert(pubB, pvtA.WithKeyLocator(certA.Name()), keychain.MakeCertOptions{})
+	require.NoError(e)
+
+	require.NoError(store.InsertKey(pvtA))
+	require.NoError(store.InsertCert(certA))
+	require.NoError(store.InsertKey(pvtB))
+	require.NoError(store.InsertCert(certB))
+	assert.ErrorIs(store.SetDefaultIdentity(ndn.ParseName("/C")), keychain.ErrNotFound)
+	require.NoError(store.SetDefaultIdentity(ndn.ParseName("/B")))
+
+	store = open()
+	identities, e := store.ListIdentities()
+	require.NoError(e)
+	if assert.Len(identities, 2) {
+		nameEqual(assert, "/A", identities[0])
+		nameEqual(assert, "/B", identities[1])
+	}
+
+	keys, e := store.ListKeys(ndn.ParseName("/A"))
+	require.NoError(e)
+	if assert.Len(keys, 1) {
+		nameEqual(assert, pvtA, keys[0])
+	}
+	certs, e := store.ListCerts(nil)
+	require.NoError(e)
+	assert.Len(certs, 2)
+
+	pvtA2, e := store.GetKey(certA.Name())
+	require.NoError(e)
+	nameEqual(assert, pvtA, pvtA2)
+	data := ndn.MakeData("/A/data")
+	require.NoError(pvtA2.Sign(&data))
+	assert.NoError(pubA.Verify(data))
+
+	certB2, e := store.GetCert(certB.Name())
+	require.NoError(e)
+	nameEqual(assert, certB, certB2)
+	_, e = store.GetCert(ndn.ParseName("/B"))
+	assert.ErrorIs(e, keychain.ErrCertName)
+
+	defaultIdentity, e := store.DefaultIdentity()
+	require.NoError(e)
+	nameEqual(assert, "/B", defaultIdentity)
+
+	require.NoError(store.DeleteKey(pvtB.Name()))
+	assert.ErrorIs(store.DeleteKey(pvtB.Name()), keychain.ErrNotFound)
+	_, e = store.GetKey(pvtB.Name())
+	assert.ErrorIs(e, keychain.ErrNotFound)
+	_, e = store.GetCert(certB.Name())
+	assert.ErrorIs(e, keychain.ErrNotFound)
+	_, e = store.DefaultIdentity()
+	assert.ErrorIs(e, keychain.ErrNotFound)
+
+	require.NoError(store.DeleteCert(certA.Name()))
+	certs, e = store.ListCerts(nil)
+	require.NoError(e)
+	assert.Len(certs, 0)
+	identities, e = store.ListIdentities()
+	require.NoError(e)
+	assert.Len(identities, 1)
+}
+
+func TestMemStore(t *testing.T) {
+	store := keychain.NewMemStore()
+	testStore(t, func() keychain.Store { return store })
+}
+
+func TestFileStore(t *testing.T) {
+	_, require := makeAR(t)
+	dir := t.TempDir()
+	testStore(t, func() keychain.Store {
+		store, e := keychain.OpenFileStore(dir, nil)
+		require.NoError(e)
+		return store
+	})
+}
+
+func TestFileStoreEncrypted(t *testing.T) {
+	assert, require := makeAR(t)
+	dir := t.TempDir()
+	passphrase := []byte("PASSPHRASE")
+	testStore(t, func() keychain.Store {
+		store, e := keychain.OpenFileStore(dir, passphrase)
+		require.NoError(e)
+		return store
+	})
+
+	store, e := keychain.OpenFileStore(dir, passphrase)
+	require.NoError(e)
+	pvt, _, e := keychain.NewECDSAKeyPair(ndn.ParseName("/E"))
+	require.NoError(e)
+	require.NoError(store.InsertKey(pvt))
+	hmacPvt, _, e := keychain.NewHMACKeyPair(ndn.ParseName("/H"))
+	require.NoError(e)
+	require.NoError(store.InsertKey(hmacPvt))
+	hmacPvt2, e := store.GetKey(hmacPvt.Name())
+	require.NoError(e)
+	nameEqual(assert, hmacPvt, hmacPvt2)
+
+	wrong, e := keychain.OpenFileStore(dir, []byte("WRONG"))
+	require.NoError(e)
+	keys, e := wrong.ListKeys(ndn.ParseName("/E"))
+	require.NoError(e)
+	assert.Len(keys, 1)
+	_, e = wrong.GetKey(pvt.Name())
+	assert.Error(e)
+	_, e = wrong.GetKey(hmacPvt.Name())
+	assert.Error(e)
+}
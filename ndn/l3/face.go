@@ -11,6 +11,8 @@ package l3
 
 import (
 	"fmt"
+	"sync/atomic"
+	"time"
 
 	"github.com/usnistgov/ndn-dpdk/ndn"
 	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
@@ -27,7 +29,15 @@ const (
 type FaceConfig struct {
 	// ReassemblerCapacity is the maximum number of partial messages stored in the reassembler.
 	// Default is MinReassemblerCapacity.
-	ReassemblerCapacity int
+	ReassemblerCapacity int `json:"reassemblerCapacity,omitempty"`
+
+	// ReassemblerTimeout is how long a partial message is kept in the reassembler since its last arriving fragment.
+	// Default is ndn.DefaultLpReassemblerTimeout.
+	ReassemblerTimeout time.Duration `json:"reassemblerTimeout,omitempty"`
+
+	// ReassemblerBufLimit is the maximum total size of fragments buffered in the reassembler, in octets.
+	// Default is ndn.DefaultLpReassemblerBufLimit.
+	ReassemblerBufLimit int `json:"reassemblerBufLimit,omitempty"`
 
 	// RxQueueSize is the Go channel buffer size of RX channel.
 	// Default is DefaultRxQueueSize.
@@ -36,6 +46,9 @@ type FaceConfig struct {
 	// TxQueueSize is the Go channel buffer size of TX channel.
 	// Default is DefaultTxQueueSize.
 	TxQueueSize int `json:"txQueueSize,omitempty"`
+
+	// Reliability enables NDNLPv2 link reliability protocol if not nil.
+	Reliability *ReliabilityConfig `json:"reliability,omitempty"`
 }
 
 func (cfg *FaceConfig) applyDefaults() {
@@ -67,6 +80,35 @@ type Face interface {
 	OnStateChange(cb func(st TransportState)) (cancel func())
 }
 
+// FaceWithCounters is an optional interface implemented by a Face or FwFace that maintains counters.
+// Faces created by NewFace, and FwFaces added to a Forwarder, implement this interface.
+type FaceWithCounters interface {
+	Counters() FaceCounters
+}
+
+// FaceCounters contains face counters.
+type FaceCounters struct {
+	RxReassPackets  uint64 `json:"rxReassPackets"`  // received packets that were reassembled
+	RxReassDrops    uint64 `json:"rxReassDrops"`    // received fragments that were dropped by reassembler
+	RxReassTimeouts uint64 `json:"rxReassTimeouts"` // partial packets discarded due to reassembly timeout
+	TxFragGood      uint64 `json:"txFragGood"`      // transmitted packets that were fragmented
+	TxFragBad       uint64 `json:"txFragBad"`       // fragmentation failures
+
+	RxAcks uint64 `json:"rxAcks"` // received Acks that acknowledged a transmitted frame
+	TxAcks uint64 `json:"txAcks"` // transmitted Acks
+	TxRetx uint64 `json:"txRetx"` // retransmitted frames
+	TxLost uint64 `json:"txLost"` // frames not acknowledged after MaxRetx retransmissions
+}
+
+type faceCounters struct {
+	txFragGood atomic.Uint64
+	txFragBad  atomic.Uint64
+	rxAcks     atomic.Uint64
+	txAcks     atomic.Uint64
+	txRetx     atomic.Uint64
+	txLost     atomic.Uint64
+}
+
 // NewFace creates a Face.
 // tr.Read() and tr.Write() should not be used after this operation.
 func NewFace(tr Transport, cfg FaceConfig) (Face, error) {
@@ -77,12 +119,21 @@ func NewFace(tr Transport, cfg FaceConfig) (Face, error) {
 	}
 
 	f := &face{
-		faceTr:      faceTr{tr},
-		rx:          make(chan *ndn.Packet, cfg.RxQueueSize),
-		tx:          make(chan ndn.L3Packet, cfg.TxQueueSize),
-		mtu:         mtu,
-		fragmenter:  ndn.NewLpFragmenter(mtu),
-		reassembler: ndn.NewLpReassembler(cfg.ReassemblerCapacity),
+		faceTr: faceTr{tr},
+		rx:     make(chan *ndn.Packet, cfg.RxQueueSize),
+		tx:     make(chan ndn.L3Packet, cfg.TxQueueSize),
+		mtu:    mtu,
+		reassembler: ndn.NewLpReassemblerWithConfig(ndn.LpReassemblerConfig{
+			Capacity: cfg.ReassemblerCapacity,
+			Timeout:  cfg.ReassemblerTimeout,
+			BufLimit: cfg.ReassemblerBufLimit,
+		}),
+	}
+	if cfg.Reliability == nil {
+		f.fragmenter = ndn.NewLpFragmenter(mtu)
+	} else {
+		f.rel = newLpReliability(*cfg.Reliability, &f.cnt)
+		f.fragmenter = ndn.NewLpFragmenter(mtu - ndn.LpRelFieldSize*(1+MaxAcksPerFrame))
 	}
 	go f.rxLoop()
 	go f.txLoop()
@@ -97,6 +148,8 @@ type face struct {
 	mtu         int
 	fragmenter  *ndn.LpFragmenter
 	reassembler *ndn.LpReassembler
+	rel         *lpReliability
+	cnt         faceCounters
 }
 
 type faceTr struct {
@@ -115,6 +168,24 @@ func (f *face) Tx() chan<- ndn.L3Packet {
 	return f.tx
 }
 
+var _ FaceWithCounters = (*face)(nil)
+
+// Counters implements FaceWithCounters interface.
+func (f *face) Counters() FaceCounters {
+	reass := f.reassembler.Counters()
+	return FaceCounters{
+		RxReassPackets:  reass.DeliverPackets,
+		RxReassDrops:    reass.DropFragments,
+		RxReassTimeouts: reass.Timeouts,
+		TxFragGood:      f.cnt.txFragGood.Load(),
+		TxFragBad:       f.cnt.txFragBad.Load(),
+		RxAcks:          f.cnt.rxAcks.Load(),
+		TxAcks:          f.cnt.txAcks.Load(),
+		TxRetx:          f.cnt.txRetx.Load(),
+		TxLost:          f.cnt.txLost.Load(),
+	}
+}
+
 func (f *face) rxLoop() {
 	buf := make([]byte, f.mtu)
 	for {
@@ -122,7 +193,7 @@ func (f *face) rxLoop() {
 		if e != nil {
 			break
 		}
-		if n == 0 {
+		if n == 0 || (f.rel != nil && !f.rel.receive(buf[:n])) {
 			continue
 		}
 
@@ -131,9 +202,12 @@ func (f *face) rxLoop() {
 			continue
 		}
 
-		if pkt.Fragment == nil {
+		switch {
+		case pkt.Fragment == nil && pkt.Interest == nil && pkt.Data == nil && pkt.Nack == nil: // IDLE packet
+			continue
+		case pkt.Fragment == nil:
 			f.rx <- &pkt
-		} else {
+		default:
 			full, e := f.reassembler.Accept(&pkt)
 			if e == nil && full != nil {
 				f.rx <- full
@@ -146,18 +220,48 @@ func (f *face) rxLoop() {
 }
 
 func (f *face) txLoop() {
-	for l3packet := range f.tx {
-		pkt := l3packet.ToPacket()
-		frames, e := f.fragmenter.Fragment(pkt)
-		if e != nil {
-			continue
-		}
+	var ticks <-chan time.Time
+	if f.rel != nil {
+		ticker := time.NewTicker(f.rel.cfg.RetxTimeout / 4)
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
 
-		for _, frame := range frames {
-			if wire, e := tlv.EncodeFrom(frame); e == nil {
+	for {
+		select {
+		case l3packet, ok := <-f.tx:
+			if !ok {
+				f.faceTr.Close()
+				return
+			}
+			f.txPacket(l3packet)
+		case now := <-ticks:
+			for _, wire := range f.rel.poll(now) {
 				f.faceTr.Write(wire)
 			}
 		}
 	}
-	f.faceTr.Close()
+}
+
+func (f *face) txPacket(l3packet ndn.L3Packet) {
+	pkt := l3packet.ToPacket()
+	frames, e := f.fragmenter.Fragment(pkt)
+	switch {
+	case e != nil:
+		f.cnt.txFragBad.Add(1)
+		return
+	case len(frames) > 1:
+		f.cnt.txFragGood.Add(1)
+	}
+
+	now := time.Now()
+	for _, frame := range frames {
+		wire, e := tlv.EncodeFrom(frame)
+		if e == nil && f.rel != nil {
+			wire, e = f.rel.send(wire, now)
+		}
+		if e == nil {
+			f.faceTr.Write(wire)
+		}
+	}
 }
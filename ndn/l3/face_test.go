@@ -0,0 +1,52 @@
+package l3_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/endpoint"
+	"github.com/usnistgov/ndn-dpdk/ndn/l3"
+	"github.com/usnistgov/ndn-dpdk/ndn/ndntestenv"
+)
+
+func TestFragmentation(t *testing.T) {
+	assert, require := makeAR(t)
+
+	br := ndntestenv.NewBridge(ndntestenv.BridgeConfig{
+		MTU: 1200,
+	})
+	defer br.Close()
+
+	p, e := endpoint.Produce(context.Background(), endpoint.ProducerOptions{
+		Prefix: ndn.ParseName("/B"),
+		Handler: func(ctx context.Context, interest ndn.Interest) (ndn.Data, error) {
+			size, _ := strconv.Atoi(string(interest.Name[len(interest.Name)-1].Value))
+			return ndn.MakeData(interest, make([]byte, size)), nil
+		},
+		Fw: br.FwB,
+	})
+	require.NoError(e)
+	defer p.Close()
+
+	for _, size := range []int{100, 5000, 30000} {
+		interest := ndn.MakeInterest(fmt.Sprintf("/B/%d", size), 500*time.Millisecond)
+		data, e := endpoint.Consume(context.Background(), interest, endpoint.ConsumerOptions{Fw: br.FwA})
+		require.NoError(e, size)
+		assert.Len(data.Content, size)
+	}
+
+	// 40000 octets cannot fit in LpMaxFragments fragments
+	interest := ndn.MakeInterest("/B/40000", 500*time.Millisecond)
+	_, e = endpoint.Consume(context.Background(), interest, endpoint.ConsumerOptions{Fw: br.FwA})
+	assert.Error(e)
+
+	cntA, cntB := br.FaceA.(l3.FaceWithCounters).Counters(), br.FaceB.(l3.FaceWithCounters).Counters()
+	assert.EqualValues(2, cntB.TxFragGood)
+	assert.EqualValues(1, cntB.TxFragBad)
+	assert.EqualValues(2, cntA.RxReassPackets)
+	assert.Zero(cntA.RxReassDrops)
+}
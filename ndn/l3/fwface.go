@@ -68,6 +68,17 @@ func (f *fwFace) rxLoop() {
 	}
 }
 
+var _ FaceWithCounters = (*fwFace)(nil)
+
+// Counters implements FaceWithCounters interface.
+// It returns zeros if the underlying Face does not maintain counters.
+func (f *fwFace) Counters() (cnt FaceCounters) {
+	if fc, ok := f.Face.(FaceWithCounters); ok {
+		return fc.Counters()
+	}
+	return
+}
+
 func (f *fwFace) AddRoute(name ndn.Name) {
 	nameV, _ := name.MarshalBinary()
 	nameS := string(nameV)
@@ -0,0 +1,166 @@
+package l3
+
+import (
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+)
+
+// Link reliability limits and defaults.
+const (
+	DefaultRetxTimeout = 200 * time.Millisecond
+	DefaultMaxRetx     = 3
+
+	// MaxAcksPerFrame is the maximum number of Ack fields in an outgoing frame.
+	MaxAcksPerFrame = 8
+
+	maxPendingAcks = 1024
+)
+
+// ReliabilityConfig contains NDNLPv2 link reliability options.
+type ReliabilityConfig struct {
+	// RetxTimeout is the retransmission timeout.
+	// A frame is retransmitted if it is not acknowledged within this duration.
+	// Acks that cannot be piggybacked are sent in IDLE packets every quarter of this duration.
+	// Default is DefaultRetxTimeout.
+	RetxTimeout time.Duration `json:"retxTimeout,omitempty"`
+
+	// MaxRetx is the maximum number of retransmissions of a frame.
+	// Default is DefaultMaxRetx.
+	MaxRetx int `json:"maxRetx,omitempty"`
+}
+
+func (cfg *ReliabilityConfig) applyDefaults() {
+	if cfg.RetxTimeout <= 0 {
+		cfg.RetxTimeout = DefaultRetxTimeout
+	}
+	if cfg.MaxRetx <= 0 {
+		cfg.MaxRetx = DefaultMaxRetx
+	}
+}
+
+type lpRelFrame struct {
+	wire  []byte // frame without link reliability fields
+	sent  time.Time
+	nRetx int
+}
+
+// lpReliability implements NDNLPv2 link reliability protocol.
+//
+// Each outgoing frame is assigned a TxSequence and retained until it is acknowledged.
+// Unacknowledged frames are retransmitted with a new TxSequence, until MaxRetx is reached.
+// TxSequence numbers of incoming frames are acknowledged by piggybacking Ack fields on outgoing frames,
+// or in IDLE packets if there is no outgoing traffic.
+type lpReliability struct {
+	cfg ReliabilityConfig
+	cnt *faceCounters
+
+	mutex       sync.Mutex
+	nextTxSeq   uint64
+	unacked     map[uint64]*lpRelFrame
+	pendingAcks []uint64
+}
+
+// send assigns TxSequence and piggybacks Acks on an outgoing frame.
+func (r *lpReliability) send(wire []byte, now time.Time) ([]byte, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.sendLocked(&lpRelFrame{wire: wire}, now)
+}
+
+func (r *lpReliability) sendLocked(fr *lpRelFrame, now time.Time) ([]byte, error) {
+	seq := r.nextTxSeq
+	r.nextTxSeq++
+	fr.sent = now
+	r.unacked[seq] = fr
+
+	rel := ndn.LpRel{
+		TxSequence:    seq,
+		HasTxSequence: true,
+		Acks:          r.takeAcks(),
+	}
+	return rel.Apply(fr.wire)
+}
+
+func (r *lpReliability) takeAcks() (acks []uint64) {
+	n := min(len(r.pendingAcks), MaxAcksPerFrame)
+	if n == 0 {
+		return nil
+	}
+	acks = slices.Clone(r.pendingAcks[:n])
+	r.pendingAcks = slices.Delete(r.pendingAcks, 0, n)
+	r.cnt.txAcks.Add(uint64(n))
+	return acks
+}
+
+// receive processes link reliability fields on an incoming frame.
+// Returns whether the frame carries a payload that should be decoded further.
+func (r *lpReliability) receive(frame []byte) bool {
+	rel, hasPayload, e := ndn.ParseLpRel(frame)
+	if e != nil {
+		return false
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, ack := range rel.Acks {
+		if _, ok := r.unacked[ack]; ok {
+			delete(r.unacked, ack)
+			r.cnt.rxAcks.Add(1)
+		}
+	}
+	if rel.HasTxSequence && len(r.pendingAcks) < maxPendingAcks {
+		r.pendingAcks = append(r.pendingAcks, rel.TxSequence)
+	}
+	return hasPayload
+}
+
+// poll retransmits expired frames and sends remaining Acks in IDLE packets.
+// Returns frames to be transmitted.
+func (r *lpReliability) poll(now time.Time) (wires [][]byte) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var expired []uint64
+	for seq, fr := range r.unacked {
+		if now.Sub(fr.sent) >= r.cfg.RetxTimeout {
+			expired = append(expired, seq)
+		}
+	}
+	slices.Sort(expired)
+
+	for _, seq := range expired {
+		fr := r.unacked[seq]
+		delete(r.unacked, seq)
+		if fr.nRetx >= r.cfg.MaxRetx {
+			r.cnt.txLost.Add(1)
+			continue
+		}
+		fr.nRetx++
+		r.cnt.txRetx.Add(1)
+		if wire, e := r.sendLocked(fr, now); e == nil {
+			wires = append(wires, wire)
+		}
+	}
+
+	for len(r.pendingAcks) > 0 {
+		rel := ndn.LpRel{Acks: r.takeAcks()}
+		if wire, e := rel.Apply(nil); e == nil {
+			wires = append(wires, wire)
+		}
+	}
+	return wires
+}
+
+func newLpReliability(cfg ReliabilityConfig, cnt *faceCounters) *lpReliability {
+	cfg.applyDefaults()
+	return &lpReliability{
+		cfg:       cfg,
+		cnt:       cnt,
+		nextTxSeq: rand.Uint64(),
+		unacked:   map[uint64]*lpRelFrame{},
+	}
+}
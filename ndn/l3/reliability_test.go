@@ -0,0 +1,66 @@
+package l3_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/endpoint"
+	"github.com/usnistgov/ndn-dpdk/ndn/l3"
+	"github.com/usnistgov/ndn-dpdk/ndn/ndntestenv"
+)
+
+func TestReliability(t *testing.T) {
+	assert, require := makeAR(t)
+
+	br := ndntestenv.NewBridge(ndntestenv.BridgeConfig{
+		RelayAB: ndntestenv.BridgeRelayConfig{Loss: 0.2},
+		RelayBA: ndntestenv.BridgeRelayConfig{Loss: 0.2},
+		FaceConfig: l3.FaceConfig{
+			Reliability: &l3.ReliabilityConfig{
+				RetxTimeout: 40 * time.Millisecond,
+				MaxRetx:     8,
+			},
+		},
+	})
+	defer br.Close()
+
+	p, e := endpoint.Produce(context.Background(), endpoint.ProducerOptions{
+		Prefix: ndn.ParseName("/B"),
+		Handler: func(ctx context.Context, interest ndn.Interest) (ndn.Data, error) {
+			return ndn.MakeData(interest), nil
+		},
+		Fw: br.FwB,
+	})
+	require.NoError(e)
+	defer p.Close()
+
+	const nInterests = 200
+	var nData atomic.Int32
+	var wg sync.WaitGroup
+	for i := range nInterests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			interest := ndn.MakeInterest(fmt.Sprintf("/B/%d", i), 2*time.Second)
+			if _, e := endpoint.Consume(context.Background(), interest, endpoint.ConsumerOptions{Fw: br.FwA}); e == nil {
+				nData.Add(1)
+			}
+		}()
+		time.Sleep(time.Millisecond)
+	}
+	wg.Wait()
+
+	// without link reliability, 36% of Interest-Data exchanges would fail
+	assert.GreaterOrEqual(int(nData.Load()), nInterests*95/100)
+
+	cntA, cntB := br.FaceA.(l3.FaceWithCounters).Counters(), br.FaceB.(l3.FaceWithCounters).Counters()
+	assert.Greater(cntA.TxRetx, uint64(0))
+	assert.Greater(cntB.TxRetx, uint64(0))
+	assert.Greater(cntA.RxAcks, uint64(nInterests/2))
+	assert.Greater(cntB.TxAcks, uint64(nInterests/2))
+}
@@ -2,13 +2,15 @@ package ndn
 
 import (
 	"bytes"
+	"container/list"
 	"encoding/binary"
 	"math/rand"
 	"strconv"
+	"sync/atomic"
+	"time"
 
 	"github.com/usnistgov/ndn-dpdk/ndn/an"
 	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
-	"github.com/zyedidia/generic/cache"
 )
 
 func lpIsCritical(typ uint32) bool {
@@ -28,11 +30,15 @@ type LpL3 struct {
 	PitToken   []byte
 	NackReason uint8
 	CongMark   uint8
+
+	// IncomingFaceID is the forwarder face on which an Interest was received.
+	// It is only present on Interests sent by the forwarder to a face that requested it.
+	IncomingFaceID uint64
 }
 
 // Empty returns true if LpL3 has zero fields.
 func (lph LpL3) Empty() bool {
-	return len(lph.PitToken) == 0 && lph.NackReason == an.NackNone && lph.CongMark == 0
+	return len(lph.PitToken) == 0 && lph.NackReason == an.NackNone && lph.CongMark == 0 && lph.IncomingFaceID == 0
 }
 
 func (lph LpL3) encode() (fields []tlv.Field) {
@@ -49,6 +55,9 @@ func (lph LpL3) encode() (fields []tlv.Field) {
 	if lph.CongMark != 0 {
 		fields = append(fields, tlv.TLVNNI(an.TtCongestionMark, lph.CongMark))
 	}
+	if lph.IncomingFaceID != 0 {
+		fields = append(fields, tlv.TLVNNI(an.TtIncomingFaceID, lph.IncomingFaceID))
+	}
 	return fields
 }
 
@@ -57,6 +66,102 @@ func (lph *LpL3) inheritFrom(src LpL3) {
 	lph.CongMark = src.CongMark
 }
 
+// LpRelFieldSize is the encoded size of a TxSequence or Ack field.
+const LpRelFieldSize = 3 + 1 + 8
+
+// LpRel contains link reliability fields in NDNLPv2 header.
+type LpRel struct {
+	// TxSequence is the transmit sequence number.
+	// It is meaningful only if HasTxSequence is true.
+	TxSequence    uint64
+	HasTxSequence bool
+
+	// Acks contains acknowledged TxSequence numbers.
+	Acks []uint64
+}
+
+// Empty returns true if LpRel has zero fields.
+func (rel LpRel) Empty() bool {
+	return !rel.HasTxSequence && len(rel.Acks) == 0
+}
+
+func (rel LpRel) encode() (fields []tlv.Field) {
+	for _, ack := range rel.Acks {
+		fields = append(fields, tlv.TLVBytes(an.TtLpAck, binary.BigEndian.AppendUint64(nil, ack)))
+	}
+	if rel.HasTxSequence {
+		fields = append(fields, tlv.TLVBytes(an.TtLpTxSequence, binary.BigEndian.AppendUint64(nil, rel.TxSequence)))
+	}
+	return fields
+}
+
+// Apply inserts link reliability fields into an encoded frame.
+//
+// frame may be an LpPacket, a bare network layer packet, or empty.
+// An empty frame results in an IDLE packet, which has no LpPayload.
+// The fields are placed immediately before LpPayload, as required by NDNLPv2 field ordering.
+func (rel LpRel) Apply(frame []byte) (wire []byte, e error) {
+	if len(frame) == 0 {
+		return tlv.Encode(tlv.TLV(an.TtLpPacket, rel.encode()...))
+	}
+
+	d := tlv.DecodingBuffer(frame)
+	de, e := d.Element()
+	if e != nil {
+		return nil, e
+	}
+	if de.Type != an.TtLpPacket {
+		return tlv.Encode(tlv.TLV(an.TtLpPacket, append(rel.encode(), tlv.TLVBytes(an.TtLpPayload, de.Wire))...))
+	}
+
+	var header, payload []byte
+	dv := tlv.DecodingBuffer(de.Value)
+	for field := range dv.IterElements() {
+		if field.Type == an.TtLpPayload {
+			header, payload = de.Value[:len(de.Value)-len(field.WireAfter())], field.WireAfter()
+			break
+		}
+	}
+	if payload == nil {
+		header = de.Value
+	}
+	fields := append([]tlv.Field{tlv.Bytes(header)}, rel.encode()...)
+	return tlv.Encode(tlv.TLV(an.TtLpPacket, append(fields, tlv.Bytes(payload))...))
+}
+
+// ParseLpRel extracts link reliability fields from an encoded frame.
+// hasPayload indicates whether the frame carries a network layer packet or fragment;
+// it is false for an IDLE packet.
+func ParseLpRel(frame []byte) (rel LpRel, hasPayload bool, e error) {
+	d := tlv.DecodingBuffer(frame)
+	de, e := d.Element()
+	if e != nil {
+		return rel, false, e
+	}
+	if de.Type != an.TtLpPacket {
+		return rel, true, nil
+	}
+
+	dv := tlv.DecodingBuffer(de.Value)
+	for field := range dv.IterElements() {
+		switch field.Type {
+		case an.TtLpTxSequence:
+			if field.Length() != 8 {
+				return rel, false, ErrLpRel
+			}
+			rel.TxSequence, rel.HasTxSequence = binary.BigEndian.Uint64(field.Value), true
+		case an.TtLpAck:
+			if field.Length() != 8 {
+				return rel, false, ErrLpRel
+			}
+			rel.Acks = append(rel.Acks, binary.BigEndian.Uint64(field.Value))
+		case an.TtLpPayload:
+			hasPayload = true
+		}
+	}
+	return rel, hasPayload, dv.ErrUnlessEOF()
+}
+
 // LpFragment represents an NDNLPv2 fragmented frame.
 type LpFragment struct {
 	SeqNum    uint64
@@ -88,6 +193,10 @@ func (frag LpFragment) Field() tlv.Field {
 		tlv.TLVBytes(an.TtLpPayload, frag.Payload))
 }
 
+// LpMaxFragments is the maximum number of fragments of a network layer packet.
+// This must agree with ndni.LpMaxFragments, so that NDN-DPDK forwarder can reassemble the fragments.
+const LpMaxFragments = 31
+
 // LpFragmenter splits Packet into fragments.
 type LpFragmenter struct {
 	nextSeqNum uint64
@@ -108,6 +217,9 @@ func (fragmenter *LpFragmenter) Fragment(full *Packet) (frags []*Packet, e error
 	if sizeofFirstFragment <= 0 { // MTU is too small to fit this packet
 		return nil, ErrFragment
 	}
+	if 1+(len(payload)-sizeofFirstFragment+fragmenter.room-1)/fragmenter.room > LpMaxFragments { // too many fragments
+		return nil, ErrFragment
+	}
 
 	var first Packet
 	first.Lp = full.Lp
@@ -118,10 +230,7 @@ func (fragmenter *LpFragmenter) Fragment(full *Packet) (frags []*Packet, e error
 	frags = append(frags, &first)
 
 	for offset, nextOffset := sizeofFirstFragment, 0; offset < len(payload); offset = nextOffset {
-		nextOffset = offset + fragmenter.room
-		if nextOffset > len(payload) {
-			nextOffset = len(payload)
-		}
+		nextOffset = min(offset+fragmenter.room, len(payload))
 
 		var frag Packet
 		frag.Fragment = &LpFragment{
@@ -147,71 +256,184 @@ func NewLpFragmenter(mtu int) *LpFragmenter {
 	return &fragmenter
 }
 
+// Reassembler limits and defaults.
+const (
+	MinLpReassemblerCapacity     = 4
+	DefaultLpReassemblerTimeout  = 500 * time.Millisecond
+	DefaultLpReassemblerBufLimit = 1 << 20
+)
+
+// LpReassemblerConfig contains LpReassembler options.
+type LpReassemblerConfig struct {
+	// Capacity is the maximum number of partial packets.
+	// When this limit is reached, the least recently updated partial packet is discarded.
+	// Default is MinLpReassemblerCapacity.
+	Capacity int
+
+	// Timeout is how long a partial packet is kept since its last arriving fragment.
+	// Default is DefaultLpReassemblerTimeout.
+	Timeout time.Duration
+
+	// BufLimit is the maximum total size of buffered fragment payloads, in octets.
+	// When this limit is exceeded, the least recently updated partial packets are discarded.
+	// Default is DefaultLpReassemblerBufLimit.
+	BufLimit int
+}
+
+func (cfg *LpReassemblerConfig) applyDefaults() {
+	cfg.Capacity = max(cfg.Capacity, MinLpReassemblerCapacity)
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultLpReassemblerTimeout
+	}
+	if cfg.BufLimit <= 0 {
+		cfg.BufLimit = DefaultLpReassemblerBufLimit
+	}
+}
+
+// LpReassemblerCounters contains LpReassembler counters.
+type LpReassemblerCounters struct {
+	DeliverPackets   uint64 `json:"deliverPackets"`   // delivered network layer packets
+	DeliverFragments uint64 `json:"deliverFragments"` // fragments of delivered packets
+	DropFragments    uint64 `json:"dropFragments"`    // dropped fragments
+	Timeouts         uint64 `json:"timeouts"`         // partial packets discarded due to timeout
+}
+
 // LpReassembler reassembles fragments.
+//
+// Partial packets are identified by the SeqNum of their first fragment, i.e. SeqNum minus FragIndex.
+// This matches the behavior of NDN-DPDK forwarder, so that either side can fragment packets for the other.
 type LpReassembler struct {
-	pms *cache.Cache[uint64, *lpPartialPacket]
+	cfg     LpReassemblerConfig
+	table   map[uint64]*list.Element
+	list    list.List // *lpPartialPacket, least recently updated first
+	bufSize int
+
+	nDeliverPackets   atomic.Uint64
+	nDeliverFragments atomic.Uint64
+	nDropFragments    atomic.Uint64
+	nTimeouts         atomic.Uint64
+}
+
+// Counters returns counters.
+// This may be invoked concurrently with Accept.
+func (reass *LpReassembler) Counters() LpReassemblerCounters {
+	return LpReassemblerCounters{
+		DeliverPackets:   reass.nDeliverPackets.Load(),
+		DeliverFragments: reass.nDeliverFragments.Load(),
+		DropFragments:    reass.nDropFragments.Load(),
+		Timeouts:         reass.nTimeouts.Load(),
+	}
 }
 
 // Accept processes a fragment.
 // pkt.Fragment must not be nil.
 func (reass *LpReassembler) Accept(pkt *Packet) (full *Packet, e error) {
-	seq0 := pkt.Fragment.SeqNum - uint64(pkt.Fragment.FragIndex)
+	now := time.Now()
+	reass.expire(now)
+
+	frag := pkt.Fragment
+	if frag.FragCount > LpMaxFragments || frag.FragIndex >= frag.FragCount {
+		reass.nDropFragments.Add(1)
+		return nil, ErrFragment
+	}
 
-	pp, _ := reass.pms.Get(seq0)
-	if pp == nil {
-		pp = &lpPartialPacket{}
-		reass.pms.Put(seq0, pp)
+	seq0 := frag.SeqNum - uint64(frag.FragIndex)
+	elem := reass.table[seq0]
+	if elem == nil {
+		for len(reass.table) >= reass.cfg.Capacity {
+			reass.discard(reass.list.Front())
+		}
+		elem = reass.list.PushBack(&lpPartialPacket{
+			seq0:   seq0,
+			buffer: make([][]byte, frag.FragCount),
+		})
+		reass.table[seq0] = elem
+	}
+	pp := elem.Value.(*lpPartialPacket)
+
+	switch {
+	case frag.FragCount != len(pp.buffer): // FragCount changed
+		reass.discard(elem)
+		reass.nDropFragments.Add(1)
+		return nil, ErrFragment
+	case pp.buffer[frag.FragIndex] != nil: // duplicate FragIndex
+		reass.nDropFragments.Add(1)
+		return nil, ErrFragment
 	}
 
-	full, discard, e := pp.Accept(pkt)
-	if discard {
-		reass.pms.Remove(seq0)
+	pp.acceptOne(pkt, now)
+	reass.bufSize += len(frag.Payload)
+	reass.list.MoveToBack(elem)
+	for reass.bufSize > reass.cfg.BufLimit && reass.list.Len() > 0 {
+		reass.discard(reass.list.Front())
+	}
+	if reass.table[seq0] == nil || pp.accepted < len(pp.buffer) {
+		return nil, nil
 	}
 
-	return full, e
+	reass.remove(elem)
+	if full, e = pp.reassemble(); e != nil {
+		reass.nDropFragments.Add(uint64(len(pp.buffer)))
+		return nil, e
+	}
+	reass.nDeliverPackets.Add(1)
+	reass.nDeliverFragments.Add(uint64(len(pp.buffer)))
+	return full, nil
 }
 
-// NewLpReassembler creates a LpReassembler.
-func NewLpReassembler(capacity int) *LpReassembler {
-	return &LpReassembler{
-		pms: cache.New[uint64, *lpPartialPacket](capacity),
+func (reass *LpReassembler) expire(now time.Time) {
+	for elem := reass.list.Front(); elem != nil; elem = reass.list.Front() {
+		if pp := elem.Value.(*lpPartialPacket); now.Sub(pp.lastUpdate) < reass.cfg.Timeout {
+			break
+		}
+		reass.discard(elem)
+		reass.nTimeouts.Add(1)
 	}
 }
 
-type lpPartialPacket struct {
-	lpl3     LpL3
-	buffer   [][]byte
-	accepted int
+func (reass *LpReassembler) remove(elem *list.Element) {
+	pp := reass.list.Remove(elem).(*lpPartialPacket)
+	delete(reass.table, pp.seq0)
+	reass.bufSize -= pp.size
 }
 
-func (pp *lpPartialPacket) Accept(pkt *Packet) (full *Packet, discard bool, e error) {
-	if pp.accepted == 0 {
-		pp.buffer = make([][]byte, pkt.Fragment.FragCount)
-		pp.acceptOne(pkt)
-		return nil, false, nil
-	}
+func (reass *LpReassembler) discard(elem *list.Element) {
+	pp := elem.Value.(*lpPartialPacket)
+	reass.remove(elem)
+	reass.nDropFragments.Add(uint64(pp.accepted))
+}
 
-	if pkt.Fragment.FragCount != len(pp.buffer) {
-		return nil, true, ErrFragment
-	}
-	if pp.buffer[pkt.Fragment.FragIndex] != nil {
-		return nil, false, ErrFragment
-	}
+// NewLpReassembler creates a LpReassembler with specified capacity and default options.
+func NewLpReassembler(capacity int) *LpReassembler {
+	return NewLpReassemblerWithConfig(LpReassemblerConfig{Capacity: capacity})
+}
 
-	pp.acceptOne(pkt)
-	if pp.accepted == len(pp.buffer) {
-		full, e = pp.reassemble()
-		return full, true, e
+// NewLpReassemblerWithConfig creates a LpReassembler.
+func NewLpReassemblerWithConfig(cfg LpReassemblerConfig) *LpReassembler {
+	cfg.applyDefaults()
+	return &LpReassembler{
+		cfg:   cfg,
+		table: map[uint64]*list.Element{},
 	}
-	return nil, false, nil
 }
 
-func (pp *lpPartialPacket) acceptOne(pkt *Packet) {
+type lpPartialPacket struct {
+	seq0       uint64
+	lpl3       LpL3
+	buffer     [][]byte
+	accepted   int
+	size       int
+	lastUpdate time.Time
+}
+
+func (pp *lpPartialPacket) acceptOne(pkt *Packet, now time.Time) {
 	if pkt.Fragment.FragIndex == 0 {
 		pp.lpl3 = pkt.Lp
 	}
 	pp.buffer[pkt.Fragment.FragIndex] = pkt.Fragment.Payload
 	pp.accepted++
+	pp.size += len(pkt.Fragment.Payload)
+	pp.lastUpdate = now
 }
 
 func (pp *lpPartialPacket) reassemble() (full *Packet, e error) {
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/usnistgov/ndn-dpdk/ndn"
 	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
@@ -33,6 +34,10 @@ func TestLpFragmenter(t *testing.T) {
 	tooSmall := ndn.NewLpFragmenter(10)
 	_, e = tooSmall.Fragment(packet)
 	assert.Error(e)
+
+	tooMany := ndn.NewLpFragmenter(100)
+	_, e = tooMany.Fragment(packet)
+	assert.ErrorIs(e, ndn.ErrFragment)
 }
 
 func TestLpReassembler(t *testing.T) {
@@ -73,4 +78,125 @@ func TestLpReassembler(t *testing.T) {
 		}
 	}
 	assert.Equal(0, packetSet.Size())
+
+	cnt := reassembler.Counters()
+	assert.EqualValues(70, cnt.DeliverPackets)
+	assert.EqualValues(len(frames), cnt.DeliverFragments)
+	assert.Zero(cnt.DropFragments)
+}
+
+func TestLpReassemblerLimits(t *testing.T) {
+	assert, require := makeAR(t)
+
+	fragmenter := ndn.NewLpFragmenter(1000)
+	makeFragments := func(name string) (frags []*ndn.Packet) {
+		frags, e := fragmenter.Fragment(ndn.MakeData(name, make([]byte, 2500)).ToPacket())
+		require.NoError(e)
+		require.Len(frags, 3)
+		for i, frag := range frags {
+			wire, _ := tlv.EncodeFrom(frag)
+			frags[i] = &ndn.Packet{}
+			require.NoError(tlv.Decode(wire, frags[i]))
+		}
+		return frags
+	}
+
+	timeout := ndn.NewLpReassemblerWithConfig(ndn.LpReassemblerConfig{Timeout: 100 * time.Millisecond})
+	a := makeFragments("/A")
+	full, e := timeout.Accept(a[0])
+	assert.NoError(e)
+	assert.Nil(full)
+	time.Sleep(200 * time.Millisecond)
+	timeout.Accept(a[1])
+	full, _ = timeout.Accept(a[2])
+	assert.Nil(full) // a[0] was discarded
+	cnt := timeout.Counters()
+	assert.EqualValues(1, cnt.Timeouts)
+	assert.EqualValues(1, cnt.DropFragments)
+	assert.Zero(cnt.DeliverPackets)
+
+	bufLimit := ndn.NewLpReassemblerWithConfig(ndn.LpReassemblerConfig{BufLimit: 3000})
+	b, c := makeFragments("/B"), makeFragments("/C")
+	bufLimit.Accept(b[0])
+	bufLimit.Accept(b[1])
+	bufLimit.Accept(c[0])
+	bufLimit.Accept(c[1]) // exceeds BufLimit, partial packet B is discarded
+	full, _ = bufLimit.Accept(c[2])
+	require.NotNil(full)
+	nameEqual(assert, "/C", full.Data)
+	full, _ = bufLimit.Accept(b[2])
+	assert.Nil(full)
+	cnt = bufLimit.Counters()
+	assert.EqualValues(1, cnt.DeliverPackets)
+	assert.EqualValues(2, cnt.DropFragments)
+
+	dup := ndn.NewLpReassemblerWithConfig(ndn.LpReassemblerConfig{})
+	dup.Accept(b[0])
+	_, e = dup.Accept(b[0])
+	assert.ErrorIs(e, ndn.ErrFragment)
+}
+
+func TestLpRel(t *testing.T) {
+	assert, require := makeAR(t)
+
+	rel := ndn.LpRel{TxSequence: 0xA0A1A2A3A4A5A6A7, HasTxSequence: true, Acks: []uint64{0xB0, 0xB1}}
+	relFields := bytesFromHex("FD034408 00000000000000B0 FD034408 00000000000000B1 FD034808 A0A1A2A3A4A5A6A7")
+
+	interest := ndn.MakeInterest("/A")
+	interestWire, _ := tlv.EncodeFrom(interest)
+	wire, e := rel.Apply(interestWire)
+	require.NoError(e)
+	assert.Equal(append(append([]byte{0x64, byte(len(relFields) + 2 + len(interestWire))}, relFields...),
+		append([]byte{0x50, byte(len(interestWire))}, interestWire...)...), wire)
+
+	pkt := interest.ToPacket()
+	pkt.Lp.PitToken = bytesFromHex("B0B1B2B3")
+	lpWire, _ := tlv.EncodeFrom(pkt)
+	wire, e = rel.Apply(lpWire)
+	require.NoError(e)
+	assert.True(bytes.Contains(wire, append(bytesFromHex("6204B0B1B2B3"), relFields...)))
+
+	parsed, hasPayload, e := ndn.ParseLpRel(wire)
+	require.NoError(e)
+	assert.True(hasPayload)
+	assert.Equal(rel, parsed)
+
+	var decoded ndn.Packet
+	require.NoError(tlv.Decode(wire, &decoded))
+	require.NotNil(decoded.Interest)
+	assert.Equal(pkt.Lp.PitToken, decoded.Lp.PitToken)
+
+	idle, e := ndn.LpRel{Acks: []uint64{0xB0, 0xB1}}.Apply(nil)
+	require.NoError(e)
+	parsed, hasPayload, e = ndn.ParseLpRel(idle)
+	require.NoError(e)
+	assert.False(hasPayload)
+	assert.False(parsed.HasTxSequence)
+	assert.Equal([]uint64{0xB0, 0xB1}, parsed.Acks)
+
+	_, hasPayload, e = ndn.ParseLpRel(interestWire)
+	require.NoError(e)
+	assert.True(hasPayload)
+
+	_, _, e = ndn.ParseLpRel(bytesFromHex("6405 FD034401B0"))
+	assert.ErrorIs(e, ndn.ErrLpRel)
+}
+
+func TestLpIncomingFaceID(t *testing.T) {
+	assert, require := makeAR(t)
+
+	pkt := ndn.MakeInterest("/A").ToPacket()
+	pkt.Lp.IncomingFaceID = 0x1234
+	assert.False(pkt.Lp.Empty())
+	wire, e := tlv.EncodeFrom(pkt)
+	require.NoError(e)
+	assert.True(bytes.Contains(wire, bytesFromHex("FD032C021234")))
+
+	var decoded ndn.Packet
+	require.NoError(tlv.Decode(wire, &decoded))
+	require.NotNil(decoded.Interest)
+	assert.EqualValues(0x1234, decoded.Lp.IncomingFaceID)
+
+	data := ndn.MakeData(decoded.Interest)
+	assert.Zero(data.ToPacket().Lp.IncomingFaceID)
 }
@@ -10,6 +10,9 @@ import (
 	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
 )
 
+// ErrBadCommand indicates a malformed control command Interest.
+var ErrBadCommand = errors.New("bad control command")
+
 // ControlCommand represents a NFD control command.
 type ControlCommand interface {
 	Verb() []ndn.NameComponent
@@ -39,6 +42,15 @@ type ControlResponse struct {
 	Body       []byte
 }
 
+// Field implements tlv.Fielder interface.
+func (cr ControlResponse) Field() tlv.Field {
+	return tlv.TLV(TtControlResponse,
+		tlv.TLVNNI(TtStatusCode, cr.StatusCode),
+		tlv.TLVBytes(TtStatusText, []byte(cr.StatusText)),
+		tlv.Bytes(cr.Body),
+	)
+}
+
 func (cr *ControlResponse) UnmarshalTLV(typ uint32, value []byte) error {
 	if typ != TtControlResponse {
 		return tlv.ErrType
@@ -0,0 +1,68 @@
+package nfdmgmt_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
+	"github.com/usnistgov/ndn-dpdk/core/testenv"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/mgmt/nfdmgmt"
+	"github.com/usnistgov/ndn-dpdk/ndn/ndntestenv"
+	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
+)
+
+var (
+	makeAR    = testenv.MakeAR
+	nameEqual = ndntestenv.NameEqual
+)
+
+func TestParseCommandInterest(t *testing.T) {
+	assert, require := makeAR(t)
+
+	interest := nfdmgmt.MakeCommandInterest(nfdmgmt.PrefixLocalhost, nfdmgmt.RibRegisterCommand{
+		Name:    ndn.ParseName("/A"),
+		FaceID:  4097,
+		Origin:  nfdmgmt.RouteOriginClient,
+		Cost:    20,
+		Capture: true,
+		Expires: nnduration.Milliseconds(3000),
+	})
+	require.NoError(ndn.DigestSigning.Sign(&interest))
+
+	verb, cp, e := nfdmgmt.ParseCommandInterest(nfdmgmt.PrefixLocalhost, interest)
+	require.NoError(e)
+	nameEqual(assert, "/rib/register", verb)
+	nameEqual(assert, "/A", cp.Name)
+	assert.Equal(4097, cp.FaceID)
+	assert.Equal(nfdmgmt.RouteOriginClient, cp.Origin)
+	assert.Equal(20, cp.Cost)
+	assert.Equal(3, cp.Flags)
+	assert.Equal(3*time.Second, cp.ExpirationPeriod)
+
+	interest = nfdmgmt.MakeCommandInterest(nfdmgmt.PrefixLocalhost, nfdmgmt.RibUnregisterCommand{
+		Name: ndn.ParseName("/B"),
+	})
+	_, cp, e = nfdmgmt.ParseCommandInterest(nfdmgmt.PrefixLocalhost, interest)
+	require.NoError(e)
+	assert.Equal(-1, cp.FaceID)
+	assert.Equal(-1, cp.Cost)
+	assert.Equal(-1, cp.Flags)
+	assert.EqualValues(-1, cp.ExpirationPeriod)
+
+	_, _, e = nfdmgmt.ParseCommandInterest(nfdmgmt.PrefixLocalhost, ndn.MakeInterest("/localhost/nfd/rib/register"))
+	assert.ErrorIs(e, nfdmgmt.ErrBadCommand)
+
+	cr := nfdmgmt.ControlResponse{StatusCode: 200, StatusText: "OK"}
+	cr.Body, e = tlv.EncodeFrom(cp)
+	require.NoError(e)
+	wire, e := tlv.EncodeFrom(cr)
+	require.NoError(e)
+	var decoded nfdmgmt.ControlResponse
+	require.NoError(tlv.Decode(wire, &decoded))
+	assert.Equal(cr, decoded)
+
+	var cp2 nfdmgmt.ControlParameters
+	require.NoError(tlv.Decode(decoded.Body, &cp2))
+	assert.Equal(cp, cp2)
+}
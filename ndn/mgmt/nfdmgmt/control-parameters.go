@@ -0,0 +1,102 @@
+package nfdmgmt
+
+import (
+	"math"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
+	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
+)
+
+// ControlParameters represents NFD ControlParameters, as received by a management responder.
+// Optional fields that are absent are represented as -1.
+type ControlParameters struct {
+	Name             ndn.Name
+	FaceID           int
+	Origin           int
+	Cost             int
+	Flags            int
+	ExpirationPeriod time.Duration
+}
+
+// UnmarshalTLV decodes from TLV.
+func (cp *ControlParameters) UnmarshalTLV(typ uint32, value []byte) (e error) {
+	if typ != TtControlParameters {
+		return tlv.ErrType
+	}
+
+	*cp = ControlParameters{
+		FaceID:           -1,
+		Origin:           -1,
+		Cost:             -1,
+		Flags:            -1,
+		ExpirationPeriod: -1,
+	}
+	d := tlv.DecodingBuffer(value)
+	for de := range d.IterElements() {
+		switch de.Type {
+		case an.TtName:
+			e = de.UnmarshalValue(&cp.Name)
+		case TtFaceID:
+			cp.FaceID = int(de.UnmarshalNNI(math.MaxUint32, &e, tlv.ErrRange))
+		case TtOrigin:
+			cp.Origin = int(de.UnmarshalNNI(math.MaxUint16, &e, tlv.ErrRange))
+		case TtCost:
+			cp.Cost = int(de.UnmarshalNNI(math.MaxUint32, &e, tlv.ErrRange))
+		case TtFlags:
+			cp.Flags = int(de.UnmarshalNNI(math.MaxUint32, &e, tlv.ErrRange))
+		case TtExpirationPeriod:
+			cp.ExpirationPeriod = time.Duration(de.UnmarshalNNI(math.MaxInt64/uint64(time.Millisecond), &e, tlv.ErrRange)) * time.Millisecond
+		default:
+			if de.IsCriticalType() {
+				e = tlv.ErrCritical
+			}
+		}
+		if e != nil {
+			return e
+		}
+	}
+	return d.ErrUnlessEOF()
+}
+
+// Field implements tlv.Fielder interface.
+// Absent fields are omitted.
+func (cp ControlParameters) Field() tlv.Field {
+	var a []tlv.Fielder
+	if len(cp.Name) > 0 {
+		a = append(a, cp.Name)
+	}
+	if cp.FaceID >= 0 {
+		a = append(a, tlv.TLVNNI(TtFaceID, cp.FaceID))
+	}
+	if cp.Origin >= 0 {
+		a = append(a, tlv.TLVNNI(TtOrigin, cp.Origin))
+	}
+	if cp.Cost >= 0 {
+		a = append(a, tlv.TLVNNI(TtCost, cp.Cost))
+	}
+	if cp.Flags >= 0 {
+		a = append(a, tlv.TLVNNI(TtFlags, cp.Flags))
+	}
+	if cp.ExpirationPeriod >= 0 {
+		a = append(a, tlv.TLVNNI(TtExpirationPeriod, cp.ExpirationPeriod.Milliseconds()))
+	}
+	return tlv.TLVFrom(TtControlParameters, a...)
+}
+
+// ParseCommandInterest extracts verb and ControlParameters from a control command Interest.
+// The Interest name should be commandPrefix + module + command + ControlParameters, optionally followed by
+// signed Interest name components.
+func ParseCommandInterest(commandPrefix ndn.Name, interest ndn.Interest) (verb ndn.Name, cp ControlParameters, e error) {
+	if !commandPrefix.IsPrefixOf(interest.Name) || len(interest.Name) < len(commandPrefix)+3 {
+		return nil, cp, ErrBadCommand
+	}
+
+	pos := len(commandPrefix)
+	verb = interest.Name[pos : pos+2]
+	if e = tlv.Decode(interest.Name[pos+2].Value, &cp); e != nil {
+		return nil, cp, ErrBadCommand
+	}
+	return verb, cp, nil
+}
@@ -16,6 +16,9 @@ type BridgeConfig struct {
 	FwB     l3.Forwarder
 	RelayAB BridgeRelayConfig
 	RelayBA BridgeRelayConfig
+
+	MTU        int // default 9000
+	FaceConfig l3.FaceConfig
 }
 
 func (cfg *BridgeConfig) applyDefaults() {
@@ -25,6 +28,9 @@ func (cfg *BridgeConfig) applyDefaults() {
 	if cfg.FwB == nil {
 		cfg.FwB = l3.NewForwarder()
 	}
+	if cfg.MTU <= 0 {
+		cfg.MTU = 9000
+	}
 	cfg.RelayAB.applyDefaults()
 	cfg.RelayBA.applyDefaults()
 }
@@ -81,9 +87,9 @@ func NewBridge(cfg BridgeConfig) (br *Bridge) {
 		FwB: cfg.FwB,
 	}
 	connA, connB := net.Pipe()
-	br.trA, br.trB = newBridgeTransport(connA, cfg.RelayAB), newBridgeTransport(connB, cfg.RelayBA)
-	faceA, _ := l3.NewFace(br.trA, l3.FaceConfig{})
-	faceB, _ := l3.NewFace(br.trB, l3.FaceConfig{})
+	br.trA, br.trB = newBridgeTransport(connA, cfg.MTU, cfg.RelayAB), newBridgeTransport(connB, cfg.MTU, cfg.RelayBA)
+	faceA, _ := l3.NewFace(br.trA, cfg.FaceConfig)
+	faceB, _ := l3.NewFace(br.trB, cfg.FaceConfig)
 	br.FaceA, _ = br.FwA.AddFace(faceA)
 	br.FaceB, _ = br.FwB.AddFace(faceB)
 	br.FaceA.AddRoute(ndn.Name{})
@@ -110,14 +116,14 @@ func (tr *bridgeTransport) Write(buf []byte) (n int, e error) {
 	return len(buf), nil
 }
 
-func newBridgeTransport(conn net.Conn, relay BridgeRelayConfig) (tr *bridgeTransport) {
+func newBridgeTransport(conn net.Conn, mtu int, relay BridgeRelayConfig) (tr *bridgeTransport) {
 	tr = &bridgeTransport{
 		Conn:  conn,
 		loss:  relay.makeLoss(),
 		delay: relay.makeDelay(),
 	}
 	tr.TransportBase, tr.p = l3.NewTransportBase(l3.TransportBaseConfig{
-		MTU: 9000,
+		MTU: mtu,
 	})
 	return tr
 }
@@ -0,0 +1,12 @@
+package ndntestvector
+
+// HMACDemoSecret is the secret key of HMACDemo.
+var HMACDemoSecret = []byte("NDN-DPDK HMAC-SHA256 demo secret")
+
+// HMACDemo is a Data packet signed with SigHmacWithSha256.
+// Its KeyLocator is /HMAC-demo/KEY/demo, and the secret key is HMACDemoSecret.
+// The signature was cross-checked with Python hmac module.
+var HMACDemo = makeDataFromBase64(`
+	BnAHFAgJSE1BQy1kZW1vCARkYXRhCAExFRdITUFDLVNIQTI1NiB0ZXN0IHZlY3Rv
+	chYdGwEEHBgHFggJSE1BQy1kZW1vCANLRVkIBGRlbW8XIJvhKRUQo75XL/rlaFHo
+	kpwOT/OIpTG1NH/huwkpxiRz`)
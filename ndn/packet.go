@@ -171,6 +171,10 @@ func (pkt *Packet) decodeValue(value []byte) (e error) {
 			if pkt.Lp.CongMark = uint8(de.UnmarshalNNI(math.MaxUint8, &e, tlv.ErrRange)); e != nil {
 				return e
 			}
+		case an.TtIncomingFaceID:
+			if pkt.Lp.IncomingFaceID = de.UnmarshalNNI(math.MaxUint64, &e, tlv.ErrRange); e != nil {
+				return e
+			}
 		case an.TtLpPayload:
 			if e = pkt.decodePayload(de.Value); e != nil {
 				return e
@@ -28,6 +28,10 @@ type Config struct {
 	// The default is 60s.
 	// The minimum is RedialBackoffInitial.
 	RedialBackoffMaximum time.Duration
+
+	// Passive indicates the socket was accepted by a listener, rather than dialed.
+	// A passive transport cannot be redialed: it is closed upon socket error.
+	Passive bool
 }
 
 func (cfg *Config) applyDefaults() {
@@ -57,6 +61,7 @@ func (cnt Counters) String() string {
 //
 // A transport has automatic error handling: if a socket error occurs, the transport automatically
 // redials the socket. In case the socket cannot be redialed, the transport remains in "down" status.
+// A passive transport is closed upon socket error instead.
 type Transport interface {
 	l3.Transport
 
@@ -101,6 +106,7 @@ func New(conn net.Conn, cfg Config) (Transport, error) {
 	tr := &transport{
 		impl:    impl,
 		conn:    conn,
+		passive: cfg.Passive,
 		backoff: retry.WithCappedDuration(cfg.RedialBackoffMaximum, retry.NewExponential(cfg.RedialBackoffInitial)),
 	}
 	tr.TransportBase, tr.p = l3.NewTransportBase(l3.TransportBaseConfig{
@@ -114,6 +120,7 @@ type transport struct {
 	*l3.TransportBase
 	p          *l3.TransportBasePriv
 	impl       impl
+	passive    bool
 	nRedials   atomic.Int32
 	redialLock sync.Mutex
 	conn       net.Conn
@@ -165,6 +172,11 @@ func (tr *transport) doRW(f func() (n int, e error)) (n int, e error) {
 		return n, e
 	}
 
+	if tr.passive {
+		tr.Close()
+		return 0, io.ErrClosedPipe
+	}
+
 	tr.redialLock.Lock()
 	defer tr.redialLock.Unlock()
 	if !tr.nRedials.CompareAndSwap(nRedialsEnter, nRedialsEnter+1) { // another goroutine performed redial
@@ -6,6 +6,7 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/usnistgov/ndn-dpdk/ndn/l3"
 	"github.com/usnistgov/ndn-dpdk/ndn/ndntestenv"
 	"github.com/usnistgov/ndn-dpdk/ndn/sockettransport"
 )
@@ -59,6 +60,36 @@ func TestUnix(t *testing.T) {
 	checkStream(t, listener)
 }
 
+func TestPassive(t *testing.T) {
+	assert, require := makeAR(t)
+
+	listener, e := net.Listen("tcp", "127.0.0.1:7004")
+	require.NoError(e)
+	defer listener.Close()
+
+	client, e := net.Dial("tcp", listener.Addr().String())
+	require.NoError(e)
+	socket, e := listener.Accept()
+	require.NoError(e)
+
+	tr, e := sockettransport.New(socket, sockettransport.Config{Passive: true})
+	require.NoError(e)
+	closed := make(chan struct{})
+	tr.OnStateChange(func(st l3.TransportState) {
+		if st == l3.TransportClosed {
+			close(closed)
+		}
+	})
+
+	client.Close()
+	buf := make([]byte, 64)
+	_, e = tr.Read(buf)
+	assert.Error(e)
+	<-closed
+	assert.Equal(l3.TransportClosed, tr.State())
+	assert.Equal(0, tr.Counters().NRedials)
+}
+
 func checkStream(t testing.TB, listener net.Listener) {
 	_, require := makeAR(t)
 
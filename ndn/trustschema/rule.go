@@ -0,0 +1,105 @@
+package trustschema
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
+)
+
+// Rule is a name-based trust rule that restricts which keys may sign which packets.
+//
+// Names are matched in URI format, in which GenericNameComponent is written without its type number,
+// while other components are written as "TYPE=VALUE", such as "/A/35=%00%01".
+//
+// A rule applies to a packet if Packet matches the packet name URI.
+// Then, Signer is expanded with submatches of Packet, in the same syntax as regexp.Expand, into a regular expression.
+// Submatches are quoted before expansion, so that they are treated as literals.
+// The packet is allowed only if the expanded regular expression matches the KeyLocator name URI.
+//
+// Example: a rule with Packet `^(/example/[^/]+)/blog/` and Signer `^$1/KEY/` allows each user under /example to sign their own blog posts.
+// Data /example/alice/blog/1 must be signed by a key or certificate under /example/alice/KEY.
+type Rule struct {
+	Packet *regexp.Regexp
+	Signer string
+}
+
+// NewRule creates a Rule.
+func NewRule(packet, signer string) (r Rule, e error) {
+	if r.Packet, e = regexp.Compile(packet); e != nil {
+		return Rule{}, e
+	}
+	r.Signer = signer
+	return r, nil
+}
+
+// MustNewRule creates a Rule, panics on error.
+func MustNewRule(packet, signer string) Rule {
+	r, e := NewRule(packet, signer)
+	if e != nil {
+		panic(e)
+	}
+	return r
+}
+
+// Match determines whether the rule applies to a packet name.
+func (r Rule) Match(pktName ndn.Name) bool {
+	return r.Packet.MatchString(nameURI(pktName))
+}
+
+// Allow determines whether a packet name may be signed by a KeyLocator name.
+// Returns false if the rule does not apply to the packet name.
+func (r Rule) Allow(pktName, klName ndn.Name) bool {
+	pktURI := nameURI(pktName)
+	submatches := r.Packet.FindStringSubmatchIndex(pktURI)
+	if submatches == nil {
+		return false
+	}
+
+	quoted := make([]byte, 0, len(pktURI))
+	quotedIndex := make([]int, len(submatches))
+	for i := 0; i < len(submatches); i += 2 {
+		if submatches[i] < 0 {
+			quotedIndex[i], quotedIndex[i+1] = -1, -1
+			continue
+		}
+		quotedIndex[i] = len(quoted)
+		quoted = append(quoted, regexp.QuoteMeta(pktURI[submatches[i]:submatches[i+1]])...)
+		quotedIndex[i+1] = len(quoted)
+	}
+
+	signerPattern := r.Packet.Expand(nil, []byte(r.Signer), quoted, quotedIndex)
+	signer, e := regexp.Compile(string(signerPattern))
+	if e != nil {
+		return false
+	}
+	return signer.MatchString(nameURI(klName))
+}
+
+// Policy is an ordered list of rules.
+// The first rule that matches a packet name decides whether the packet is allowed.
+// A packet that does not match any rule is rejected.
+type Policy []Rule
+
+// Allow determines whether a packet name may be signed by a KeyLocator name.
+func (p Policy) Allow(pktName, klName ndn.Name) bool {
+	for _, r := range p {
+		if r.Match(pktName) {
+			return r.Allow(pktName, klName)
+		}
+	}
+	return false
+}
+
+var genericPrefix = strconv.Itoa(an.TtGenericNameComponent) + "="
+
+func nameURI(name ndn.Name) string {
+	var b strings.Builder
+	for _, comp := range name {
+		b.WriteByte('/')
+		b.WriteString(strings.TrimPrefix(comp.String(), genericPrefix))
+	}
+	return b.String()
+}
@@ -0,0 +1,33 @@
+package trustschema_test
+
+import (
+	"testing"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/trustschema"
+)
+
+func TestRule(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	policy := trustschema.Policy{
+		trustschema.MustNewRule(`^(/example/[^/]+)/blog/`, `^$1/KEY/`),
+		trustschema.MustNewRule(`^/example/[^/]+/KEY/`, `^/example/KEY/`),
+	}
+	allow := func(pktName, klName string) bool {
+		return policy.Allow(ndn.ParseName(pktName), ndn.ParseName(klName))
+	}
+
+	assert.True(allow("/example/alice/blog/1", "/example/alice/KEY/k1"))
+	assert.False(allow("/example/alice/blog/1", "/example/bob/KEY/k1"))
+	assert.True(allow("/example/alice/KEY/k1/issuer/v1", "/example/KEY/r1"))
+	assert.False(allow("/example/alice/KEY/k1/issuer/v1", "/example/alice/KEY/k1"))
+	assert.False(allow("/other/1", "/example/KEY/r1"))
+
+	// submatch containing regexp metacharacters is treated as literal
+	assert.True(allow("/example/a.c/blog/1", "/example/a.c/KEY/k1"))
+	assert.False(allow("/example/a.c/blog/1", "/example/abc/KEY/k1"))
+
+	_, e := trustschema.NewRule(`(`, `^/`)
+	assert.Error(e)
+}
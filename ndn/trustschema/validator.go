@@ -0,0 +1,222 @@
+// Package trustschema implements a validator that follows certificate chains and enforces name-based trust rules.
+package trustschema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/endpoint"
+	"github.com/usnistgov/ndn-dpdk/ndn/keychain"
+	"github.com/usnistgov/ndn-dpdk/ndn/l3"
+)
+
+// Error conditions.
+var (
+	ErrPolicy   = errors.New("packet rejected by trust policy")
+	ErrNoAnchor = errors.New("certificate chain does not reach a trust anchor")
+	ErrDepth    = errors.New("certificate chain too long")
+	ErrExpired  = errors.New("certificate is outside ValidityPeriod")
+)
+
+// Validator defaults.
+const (
+	DefaultFetchTimeout  = 4 * time.Second
+	DefaultMaxDepth      = 8
+	DefaultCacheCapacity = 256
+)
+
+// ValidatorConfig contains Validator configuration.
+type ValidatorConfig struct {
+	// Anchors are trusted certificates.
+	// Certificate chain of every accepted packet must end at one of these certificates.
+	Anchors []*keychain.Certificate
+
+	// Policy contains name-based trust rules.
+	// They are enforced on the packet and every certificate in its chain.
+	Policy Policy
+
+	// Fw specifies the L3 Forwarder for fetching certificates.
+	// Default is the default Forwarder.
+	Fw l3.Forwarder
+
+	// FetchTimeout is the timeout of fetching each certificate.
+	// Default is DefaultFetchTimeout.
+	FetchTimeout time.Duration
+
+	// MaxDepth is the maximum number of certificates fetched for a packet.
+	// Default is DefaultMaxDepth.
+	MaxDepth int
+
+	// CacheCapacity is the maximum number of verified certificates kept in cache.
+	// Default is DefaultCacheCapacity.
+	CacheCapacity int
+}
+
+func (cfg *ValidatorConfig) applyDefaults() {
+	if cfg.FetchTimeout <= 0 {
+		cfg.FetchTimeout = DefaultFetchTimeout
+	}
+	if cfg.MaxDepth <= 0 {
+		cfg.MaxDepth = DefaultMaxDepth
+	}
+	if cfg.CacheCapacity <= 0 {
+		cfg.CacheCapacity = DefaultCacheCapacity
+	}
+}
+
+// Validator verifies packets according to trust anchors and trust policy.
+// It implements ndn.Verifier, so that it can be used wherever a verifier is accepted,
+// such as endpoint.ConsumerOptions and segmented.FetchOptions.
+//
+// When the signer certificate is neither a trust anchor nor in cache, the Validator fetches it
+// via the L3 Forwarder using the KeyLocator name, and then verifies it recursively.
+// Verify blocks while certificates are being fetched.
+type Validator struct {
+	cfg ValidatorConfig
+
+	mutex sync.Mutex
+	cache map[string]*keychain.Certificate // key name URI => verified certificate
+}
+
+var _ ndn.Verifier = (*Validator)(nil)
+
+// Verify implements ndn.Verifier interface.
+// It is equivalent to VerifyContext with a background context.
+func (v *Validator) Verify(packet ndn.Verifiable) error {
+	return v.VerifyContext(context.Background(), packet)
+}
+
+// VerifyContext verifies a packet.
+// ctx can cancel or set a deadline on certificate fetching.
+func (v *Validator) VerifyContext(ctx context.Context, packet ndn.Verifiable) error {
+	return v.verify(ctx, packet, 0)
+}
+
+var errSigInfoCaptured = errors.New("SigInfo captured")
+
+func (v *Validator) verify(ctx context.Context, packet ndn.Verifiable, depth int) error {
+	var pktName, klName ndn.Name
+	e := packet.VerifyWith(func(name ndn.Name, si ndn.SigInfo) (ndn.LLVerify, error) {
+		pktName, klName = name, si.KeyLocator.Name
+		return nil, errSigInfoCaptured
+	})
+	if !errors.Is(e, errSigInfoCaptured) {
+		return e
+	}
+	if !keychain.IsKeyName(klName) && !keychain.IsCertName(klName) {
+		return ndn.ErrKeyLocator
+	}
+
+	if !v.cfg.Policy.Allow(pktName, klName) {
+		return fmt.Errorf("%w: %s signed by %s", ErrPolicy, pktName, klName)
+	}
+
+	signer, e := v.findSigner(ctx, klName, depth)
+	if e != nil {
+		return e
+	}
+	return signer.PublicKey().Verify(packet)
+}
+
+func (v *Validator) findSigner(ctx context.Context, klName ndn.Name, depth int) (*keychain.Certificate, error) {
+	now := time.Now()
+	for _, anchor := range v.cfg.Anchors {
+		if klName.IsPrefixOf(anchor.Name()) && keychain.ToKeyName(klName).Equal(keychain.ToKeyName(anchor.Name())) {
+			if !anchor.Validity().Includes(now) {
+				return nil, fmt.Errorf("%w: trust anchor %s", ErrExpired, anchor.Name())
+			}
+			return anchor, nil
+		}
+	}
+	if cert := v.cacheGet(klName, now); cert != nil {
+		return cert, nil
+	}
+
+	if depth >= v.cfg.MaxDepth {
+		return nil, ErrDepth
+	}
+	cert, e := v.fetchCert(ctx, klName)
+	if e != nil {
+		return nil, e
+	}
+	if !cert.Validity().Includes(now) {
+		return nil, fmt.Errorf("%w: %s", ErrExpired, cert.Name())
+	}
+	if cert.SelfSigned() {
+		return nil, fmt.Errorf("%w: %s", ErrNoAnchor, cert.Name())
+	}
+
+	data := cert.Data()
+	if e := v.verify(ctx, &data, depth+1); e != nil {
+		return nil, e
+	}
+	v.cachePut(cert)
+	return cert, nil
+}
+
+func (v *Validator) fetchCert(ctx context.Context, klName ndn.Name) (*keychain.Certificate, error) {
+	ctx, cancel := context.WithTimeout(ctx, v.cfg.FetchTimeout)
+	defer cancel()
+
+	interest := ndn.MakeInterest(klName, v.cfg.FetchTimeout)
+	if !keychain.IsCertName(klName) {
+		interest.CanBePrefix = true
+	}
+	data, e := endpoint.Consume(ctx, interest, endpoint.ConsumerOptions{Fw: v.cfg.Fw})
+	if e != nil {
+		return nil, fmt.Errorf("fetch certificate %s: %w", klName, e)
+	}
+	cert, e := keychain.CertFromData(*data)
+	if e != nil {
+		return nil, fmt.Errorf("parse certificate %s: %w", data.Name, e)
+	}
+	if !klName.IsPrefixOf(cert.Name()) {
+		return nil, fmt.Errorf("%w: certificate %s does not match %s", ndn.ErrKeyLocator, cert.Name(), klName)
+	}
+	return cert, nil
+}
+
+func (v *Validator) cacheGet(klName ndn.Name, now time.Time) *keychain.Certificate {
+	key := keychain.ToKeyName(klName).String()
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	cert := v.cache[key]
+	switch {
+	case cert == nil:
+		return nil
+	case !cert.Validity().Includes(now):
+		delete(v.cache, key)
+		return nil
+	case !klName.IsPrefixOf(cert.Name()):
+		return nil
+	}
+	return cert
+}
+
+func (v *Validator) cachePut(cert *keychain.Certificate) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	if len(v.cache) >= v.cfg.CacheCapacity {
+		for key := range v.cache { // evict an arbitrary entry
+			delete(v.cache, key)
+			break
+		}
+	}
+	v.cache[keychain.ToKeyName(cert.Name()).String()] = cert
+}
+
+// NewValidator creates a Validator.
+func NewValidator(cfg ValidatorConfig) (*Validator, error) {
+	cfg.applyDefaults()
+	if len(cfg.Anchors) == 0 {
+		return nil, ErrNoAnchor
+	}
+	return &Validator{
+		cfg:   cfg,
+		cache: map[string]*keychain.Certificate{},
+	}, nil
+}
@@ -0,0 +1,155 @@
+package trustschema_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/endpoint"
+	"github.com/usnistgov/ndn-dpdk/ndn/keychain"
+	"github.com/usnistgov/ndn-dpdk/ndn/l3"
+	"github.com/usnistgov/ndn-dpdk/ndn/trustschema"
+	"go4.org/must"
+)
+
+func TestValidator(t *testing.T) {
+	assert, require := makeAR(t)
+	fw := l3.NewForwarder()
+
+	rootPvt, rootPub, e := keychain.NewECDSAKeyPair(ndn.ParseName("/example"))
+	require.NoError(e)
+	rootCert, e := keychain.MakeCert(rootPub, rootPvt, keychain.MakeCertOptions{IssuerID: keychain.ComponentSelfIssuer})
+	require.NoError(e)
+	rootSigner := rootPvt.WithKeyLocator(rootCert.Name())
+
+	alicePvt, alicePub, e := keychain.NewECDSAKeyPair(ndn.ParseName("/example/alice"))
+	require.NoError(e)
+	aliceCert, e := keychain.MakeCert(alicePub, rootSigner, keychain.MakeCertOptions{})
+	require.NoError(e)
+	aliceSigner := alicePvt.WithKeyLocator(aliceCert.Name())
+
+	bobPvt, bobPub, e := keychain.NewEd25519KeyPair(ndn.ParseName("/example/bob"))
+	require.NoError(e)
+	bobCert, e := keychain.MakeCert(bobPub, rootSigner, keychain.MakeCertOptions{
+		Validity: keychain.ValidityPeriod{
+			NotBefore: time.Unix(1600000000, 0),
+			NotAfter:  time.Unix(1600086400, 0),
+		},
+	})
+	require.NoError(e)
+
+	var nCertFetches int
+	certs := []*keychain.Certificate{rootCert, aliceCert, bobCert}
+	p, e := endpoint.Produce(context.Background(), endpoint.ProducerOptions{
+		Prefix: ndn.ParseName("/example"),
+		Fw:     fw,
+		Handler: func(ctx context.Context, interest ndn.Interest) (ndn.Data, error) {
+			for _, cert := range certs {
+				if data := cert.Data(); data.CanSatisfy(interest) {
+					nCertFetches++
+					return data, nil
+				}
+			}
+			return ndn.Data{}, nil
+		},
+	})
+	require.NoError(e)
+	defer must.Close(p)
+
+	v, e := trustschema.NewValidator(trustschema.ValidatorConfig{
+		Anchors: []*keychain.Certificate{rootCert},
+		Policy: trustschema.Policy{
+			trustschema.MustNewRule(`^(/example/[^/]+)/blog/`, `^$1/KEY/`),
+			trustschema.MustNewRule(`^/example/[^/]+/KEY/`, `^/example/KEY/`),
+		},
+		Fw:           fw,
+		FetchTimeout: 500 * time.Millisecond,
+	})
+	require.NoError(e)
+
+	makeData := func(name string, signer ndn.Signer) ndn.Data {
+		data := ndn.MakeData(name)
+		require.NoError(signer.Sign(&data))
+		return data
+	}
+
+	// fetch alice certificate
+	assert.NoError(v.Verify(makeData("/example/alice/blog/1", aliceSigner)))
+	assert.Equal(1, nCertFetches)
+
+	// alice certificate is cached
+	assert.NoError(v.Verify(makeData("/example/alice/blog/2", alicePvt)))
+	assert.Equal(1, nCertFetches)
+
+	// alice cannot sign bob's blog
+	assert.ErrorIs(v.Verify(makeData("/example/bob/blog/1", aliceSigner)), trustschema.ErrPolicy)
+
+	// no rule for this name
+	assert.ErrorIs(v.Verify(makeData("/example/alice/photo/1", aliceSigner)), trustschema.ErrPolicy)
+
+	// bad signature
+	data := makeData("/example/alice/blog/3", aliceSigner)
+	data.Content = []byte{0xC0}
+	assert.Error(v.Verify(data))
+
+	// bob certificate has expired
+	assert.ErrorIs(v.Verify(makeData("/example/bob/blog/1", bobPvt.WithKeyLocator(bobCert.Name()))), trustschema.ErrExpired)
+
+	// self-signed certificate that is not a trust anchor
+	evePvt, evePub, e := keychain.NewECDSAKeyPair(ndn.ParseName("/example/eve"))
+	require.NoError(e)
+	eveCert, e := keychain.MakeCert(evePub, evePvt, keychain.MakeCertOptions{IssuerID: keychain.ComponentSelfIssuer})
+	require.NoError(e)
+	certs = append(certs, eveCert)
+	assert.Error(v.Verify(makeData("/example/eve/blog/1", evePvt.WithKeyLocator(eveCert.Name()))))
+
+	// unreachable certificate
+	carolPvt, _, e := keychain.NewECDSAKeyPair(ndn.ParseName("/example/carol"))
+	require.NoError(e)
+	assert.Error(v.Verify(makeData("/example/carol/blog/1", carolPvt)))
+
+	// Interest signing
+	interest := ndn.MakeInterest("/example/alice/blog/4")
+	require.NoError(aliceSigner.Sign(&interest))
+	assert.NoError(v.Verify(interest))
+
+	// certificate fetching is canceled by caller
+	davePvt, davePub, e := keychain.NewECDSAKeyPair(ndn.ParseName("/example/dave"))
+	require.NoError(e)
+	daveCert, e := keychain.MakeCert(davePub, rootSigner, keychain.MakeCertOptions{})
+	require.NoError(e)
+	certs = append(certs, daveCert)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(v.VerifyContext(ctx, makeData("/example/dave/blog/1", davePvt.WithKeyLocator(daveCert.Name()))), context.Canceled)
+	assert.NoError(v.VerifyContext(context.Background(), makeData("/example/dave/blog/2", davePvt.WithKeyLocator(daveCert.Name()))))
+}
+
+func TestValidatorExpiredAnchor(t *testing.T) {
+	assert, require := makeAR(t)
+
+	rootPvt, rootPub, e := keychain.NewECDSAKeyPair(ndn.ParseName("/example"))
+	require.NoError(e)
+	rootCert, e := keychain.MakeCert(rootPub, rootPvt, keychain.MakeCertOptions{
+		IssuerID: keychain.ComponentSelfIssuer,
+		Validity: keychain.ValidityPeriod{
+			NotBefore: time.Unix(1600000000, 0),
+			NotAfter:  time.Unix(1600086400, 0),
+		},
+	})
+	require.NoError(e)
+
+	v, e := trustschema.NewValidator(trustschema.ValidatorConfig{
+		Anchors: []*keychain.Certificate{rootCert},
+		Policy: trustschema.Policy{
+			trustschema.MustNewRule(`^/example/`, `^/example/KEY/`),
+		},
+		Fw: l3.NewForwarder(),
+	})
+	require.NoError(e)
+
+	data := ndn.MakeData("/example/blog/1")
+	require.NoError(rootPvt.WithKeyLocator(rootCert.Name()).Sign(&data))
+	assert.ErrorIs(v.Verify(data), trustschema.ErrExpired)
+}
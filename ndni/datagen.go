@@ -5,9 +5,14 @@ package ndni
 */
 import "C"
 import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
 	"unsafe"
 
 	"github.com/usnistgov/ndn-dpdk/core/nnduration"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
 	"github.com/usnistgov/ndn-dpdk/dpdk/pktmbuf"
 	"github.com/usnistgov/ndn-dpdk/ndn"
 	"github.com/usnistgov/ndn-dpdk/ndn/an"
@@ -80,8 +85,26 @@ func (gen *DataGen) Init(m *pktmbuf.Packet, args ...any) {
 	C.rte_pktmbuf_trim(gen.tpl, C.uint16_t(C.size_t(pktmbuf.PacketFromPtr(unsafe.Pointer(gen.tpl)).Len())-gen.contentIov[0].iov_len))
 }
 
+// setLenDist enables variable Content payload length.
+// Each Data carries a prefix of the template Content, whose length is drawn from bins.
+func (gen *DataGen) setLenDist(bins []lenBin) {
+	gen.lenBins = eal.Zmalloc[C.DataGenLenBin]("DataGenLenBin", C.sizeof_DataGenLenBin*len(bins), eal.NumaSocket{})
+	binsC := unsafe.Slice(gen.lenBins, len(bins))
+	for i, bin := range bins {
+		binsC[i] = C.DataGenLenBin{
+			cdf:  C.uint32_t(bin.cdf),
+			min:  C.uint16_t(bin.Min),
+			span: C.uint16_t(bin.Max - bin.Min),
+		}
+	}
+	gen.nLenBins = C.uint32_t(len(bins))
+}
+
 // Close discards this DataGen.
 func (gen *DataGen) Close() error {
+	if gen.lenBins != nil {
+		eal.Free(gen.lenBins)
+	}
 	tpl := pktmbuf.PacketFromPtr(unsafe.Pointer(gen.tpl))
 	*gen = DataGen{}
 	return tpl.Close()
@@ -105,11 +128,76 @@ func (gen *DataGen) Encode(prefix ndn.Name, mp *Mempools, fragmentPayloadSize in
 type DataGenConfig struct {
 	Suffix          ndn.Name                `json:"suffix,omitempty"`
 	FreshnessPeriod nnduration.Milliseconds `json:"freshnessPeriod,omitempty"`
-	PayloadLen      int                     `json:"payloadLen,omitempty"`
+
+	// PayloadLen is the Content payload length.
+	// It is ignored if PayloadLenDist is specified.
+	PayloadLen int `json:"payloadLen,omitempty"`
+
+	// PayloadLenDist, if specified, draws Content payload length of each Data from a distribution.
+	PayloadLenDist *PayloadLenDist `json:"payloadLenDist,omitempty"`
+
+	// PayloadFile, if specified, fills Content payload from this file.
+	// If the file is shorter than the maximum payload length, its content is repeated.
+	// If the file is longer, only its beginning is used.
+	// If PayloadFile is not specified, Content payload is filled with zeros.
+	PayloadFile string `json:"payloadFile,omitempty"`
+
+	content []byte // Content payload saved by Validate
+}
+
+// Validate checks the configuration.
+// It also reads PayloadFile and saves the Content payload, which is reused by every Apply.
+// Fields should not be modified afterwards.
+func (cfg *DataGenConfig) Validate() (e error) {
+	cfg.content, e = cfg.payload()
+	return e
+}
+
+func (cfg DataGenConfig) payload() (content []byte, e error) {
+	payloadLen := cfg.PayloadLen
+	if cfg.PayloadLenDist != nil {
+		if e := cfg.PayloadLenDist.Validate(); e != nil {
+			return nil, e
+		}
+		payloadLen = cfg.PayloadLenDist.MaxLen()
+	}
+	if payloadLen < 0 || payloadLen > math.MaxUint16 {
+		return nil, fmt.Errorf("invalid payload length %d", payloadLen)
+	}
+
+	content = make([]byte, payloadLen)
+	if cfg.PayloadFile == "" {
+		return content, nil
+	}
+
+	file, e := os.ReadFile(cfg.PayloadFile)
+	if e != nil {
+		return nil, fmt.Errorf("PayloadFile: %w", e)
+	}
+	if len(file) == 0 && payloadLen > 0 {
+		return nil, errors.New("PayloadFile is empty")
+	}
+	for i := 0; i < payloadLen; i += len(file) {
+		copy(content[i:], file)
+	}
+	return content, nil
 }
 
 // Apply initializes DataGen.
+// It reuses the Content payload saved by Validate, or reads PayloadFile if Validate was not called.
+// Panics on error.
 func (cfg DataGenConfig) Apply(gen *DataGen, m *pktmbuf.Packet) {
-	content := make([]byte, cfg.PayloadLen)
+	content := cfg.content
+	if content == nil {
+		var e error
+		if content, e = cfg.payload(); e != nil {
+			logger.Panic("DataGenConfig.Apply error", zap.Error(e))
+		}
+	}
 	gen.Init(m, cfg.Suffix, cfg.FreshnessPeriod.Duration(), content)
+
+	if cfg.PayloadLenDist != nil {
+		bins, _ := cfg.PayloadLenDist.normalize()
+		gen.setLenDist(bins)
+	}
 }
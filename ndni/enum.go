@@ -15,6 +15,19 @@ import (
 //go:generate go run ../mk/enumgen/ -guard=NDNDPDK_NDNI_AN_H -out=../csrc/ndni/an.h ../ndn/an
 
 const (
+	// LpMaxAcks is the maximum number of NDNLPv2 Ack fields piggybacked on an outgoing frame.
+	LpMaxAcks = 4
+
+	// LpReliabilityHeadroom is the portion of LpHeaderHeadroom reserved for NDNLPv2 link reliability fields.
+	// It does not reduce fragment payload size on a face without link reliability.
+	LpReliabilityHeadroom = 0 +
+		(3+1+8)*LpMaxAcks + // Ack
+		3 + 1 + 8 // TxSequence
+
+	// LpIncomingFaceIDHeadroom is the portion of LpHeaderHeadroom reserved for NDNLPv2 IncomingFaceId field.
+	// It does not reduce fragment payload size on a face that does not request IncomingFaceId.
+	LpIncomingFaceIDHeadroom = 3 + 1 + 2
+
 	// LpHeaderHeadroom is the required headroom to prepend NDNLPv2 header.
 	LpHeaderHeadroom = 0 +
 		1 + 5 + // LpPacket TL
@@ -24,6 +37,8 @@ const (
 		1 + 1 + 8 + // PitToken
 		3 + 1 + 3 + 1 + 1 + // Nack
 		3 + 1 + 1 + // CongestionMark
+		LpIncomingFaceIDHeadroom +
+		LpReliabilityHeadroom +
 		1 + 5 // Payload TL
 
 	// LpMaxFragments is the maximum number of NDNLPv2 fragments.
@@ -16,6 +16,7 @@ var (
 	GqlInterestTemplateInput      *graphql.InputObject
 	GqlInterestTemplateFieldTypes gqlserver.FieldTypes
 	GqlDataGenInput               *graphql.InputObject
+	GqlPayloadLenDistInput        *graphql.InputObject
 )
 
 func init() {
@@ -59,12 +60,30 @@ func init() {
 		Fields:      gqlserver.BindInputFields[InterestTemplateConfig](GqlInterestTemplateFieldTypes),
 	})
 
+	GqlPayloadLenDistInput = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "PayloadLenDistInput",
+		Description: "Data payload length distribution.",
+		Fields: gqlserver.BindInputFields[PayloadLenDist](gqlserver.FieldTypes{
+			reflect.TypeFor[PayloadLenBin](): graphql.NewInputObject(graphql.InputObjectConfig{
+				Name:        "PayloadLenBinInput",
+				Description: "Data payload length histogram bin.",
+				Fields:      gqlserver.BindInputFields[PayloadLenBin](nil),
+			}),
+			reflect.TypeFor[PayloadLenSize](): graphql.NewInputObject(graphql.InputObjectConfig{
+				Name:        "PayloadLenSizeInput",
+				Description: "Data payload length with weight.",
+				Fields:      gqlserver.BindInputFields[PayloadLenSize](nil),
+			}),
+		}),
+	})
+
 	GqlDataGenInput = graphql.NewInputObject(graphql.InputObjectConfig{
 		Name:        "DataGenInput",
 		Description: "Data generator template.",
 		Fields: gqlserver.BindInputFields[DataGenConfig](gqlserver.FieldTypes{
 			reflect.TypeFor[ndn.Name]():                gqlserver.NonNullString,
 			reflect.TypeFor[nnduration.Milliseconds](): nnduration.GqlMilliseconds,
+			reflect.TypeFor[PayloadLenDist]():          GqlPayloadLenDistInput,
 		}),
 	})
 }
@@ -2,6 +2,8 @@ package ndnitest
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -69,3 +71,127 @@ func TestDataGen(t *testing.T) {
 		}
 	})
 }
+
+func TestDataGenPayloadLenDist(t *testing.T) {
+	assert, require := makeAR(t)
+	payloadMp := ndni.PayloadMempool.Get(eal.NumaSocket{})
+
+	payloadFile := filepath.Join(t.TempDir(), "payload.bin")
+	require.NoError(os.WriteFile(payloadFile, []byte{0xC0, 0xC1, 0xC2}, 0o644))
+	fullContent := bytes.Repeat([]byte{0xC0, 0xC1, 0xC2}, 1000)
+
+	var mp ndni.Mempools
+	mp.Assign(eal.NumaSocket{}, ndni.DataMempool)
+
+	encodeLengths := func(cfg ndni.DataGenConfig, fragmentPayloadSize int) map[int]int {
+		require.NoError(cfg.Validate())
+		var gen ndni.DataGen
+		cfg.Apply(&gen, payloadMp.MustAlloc(1)[0])
+		defer gen.Close()
+
+		lengths := map[int]int{}
+		for range 500 {
+			pkt := gen.Encode(ndn.ParseName("/prefix"), &mp, fragmentPayloadSize)
+			data := pkt.ToNPacket().Data
+			require.NotNil(data)
+			assert.Equal(fullContent[:len(data.Content)], data.Content)
+			lengths[len(data.Content)]++
+			pkt.Close()
+		}
+		return lengths
+	}
+
+	t.Run("sizes", func(t *testing.T) {
+		cfg := ndni.DataGenConfig{
+			PayloadLenDist: &ndni.PayloadLenDist{
+				Sizes: []ndni.PayloadLenSize{{Len: 100}, {Len: 2900, Weight: 3}},
+			},
+			PayloadFile: payloadFile,
+		}
+		for _, fragmentPayloadSize := range []int{0, 1000} {
+			lengths := encodeLengths(cfg, fragmentPayloadSize)
+			assert.Len(lengths, 2)
+			assert.InDelta(125, lengths[100], 50)
+			assert.InDelta(375, lengths[2900], 50)
+		}
+	})
+
+	t.Run("histogram", func(t *testing.T) {
+		cfg := ndni.DataGenConfig{
+			PayloadLenDist: &ndni.PayloadLenDist{
+				Histogram: []ndni.PayloadLenBin{
+					{Min: 0, Max: 99, Weight: 0},
+					{Min: 200, Max: 299, Weight: 1},
+					{Min: 1400, Max: 1499, Weight: 1},
+				},
+			},
+			PayloadFile: payloadFile,
+		}
+		nLow := 0
+		for n, cnt := range encodeLengths(cfg, 0) {
+			switch {
+			case n >= 200 && n <= 299:
+				nLow += cnt
+			case n >= 1400 && n <= 1499:
+			default:
+				assert.Fail("unexpected length", n)
+			}
+		}
+		assert.InDelta(250, nLow, 60)
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		cachedFile := filepath.Join(t.TempDir(), "cached.bin")
+		require.NoError(os.WriteFile(cachedFile, []byte{0xC0, 0xC1, 0xC2}, 0o644))
+		cfg := ndni.DataGenConfig{
+			PayloadLen:  3000,
+			PayloadFile: cachedFile,
+		}
+		require.NoError(cfg.Validate())
+		require.NoError(os.Remove(cachedFile))
+
+		for range 2 {
+			var gen ndni.DataGen
+			cfg.Apply(&gen, payloadMp.MustAlloc(1)[0])
+			pkt := gen.Encode(ndn.ParseName("/prefix"), &mp, 0)
+			data := pkt.ToNPacket().Data
+			require.NotNil(data)
+			assert.Equal(fullContent, data.Content)
+			pkt.Close()
+			gen.Close()
+		}
+	})
+
+	t.Run("unvalidated", func(t *testing.T) {
+		cfg := ndni.DataGenConfig{
+			PayloadLen:  3000,
+			PayloadFile: payloadFile,
+		}
+
+		var gen ndni.DataGen
+		cfg.Apply(&gen, payloadMp.MustAlloc(1)[0])
+		defer gen.Close()
+		pkt := gen.Encode(ndn.ParseName("/prefix"), &mp, 0)
+		defer pkt.Close()
+		data := pkt.ToNPacket().Data
+		require.NotNil(data)
+		assert.Equal(fullContent, data.Content)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, cfg := range []ndni.DataGenConfig{
+			{PayloadLenDist: &ndni.PayloadLenDist{}},
+			{PayloadLenDist: &ndni.PayloadLenDist{
+				Min: 100, Max: 200,
+				Sizes: []ndni.PayloadLenSize{{Len: 300}},
+			}},
+			{PayloadLenDist: &ndni.PayloadLenDist{Min: 300, Max: 200}},
+			{PayloadLenDist: &ndni.PayloadLenDist{
+				Histogram: []ndni.PayloadLenBin{{Min: 0, Max: 100, Weight: 0}},
+			}},
+			{PayloadLen: 100, PayloadFile: "/nonexistent"},
+		} {
+			assert.Error(cfg.Validate())
+		}
+	})
+}
@@ -106,6 +106,7 @@ func (pkt *Packet) ToNPacket() (npkt ndn.Packet) {
 	npkt.Lp.PitToken = pkt.PitToken()
 	npkt.Lp.NackReason = uint8(lpl3.nackReason)
 	npkt.Lp.CongMark = uint8(lpl3.congMark)
+	npkt.Lp.IncomingFaceID = uint64(lpl3.inFaceID)
 	if npkt.Lp.NackReason != 0 {
 		return *ndn.MakeNack(npkt.Interest, npkt.Lp.NackReason).ToPacket()
 	}
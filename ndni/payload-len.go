@@ -0,0 +1,119 @@
+package ndni
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// PayloadLenDist describes a distribution of Content payload length.
+//
+// It should contain exactly one of these forms:
+//   - uniform range: Min and Max.
+//   - empirical histogram: Histogram.
+//   - list of sizes with weights: Sizes.
+type PayloadLenDist struct {
+	// Min and Max specify a uniform distribution between Min and Max, inclusive.
+	Min int `json:"min,omitempty"`
+	Max int `json:"max,omitempty"`
+
+	// Histogram specifies an empirical distribution.
+	// A bin is selected according to its weight, and then the length is drawn uniformly within the bin.
+	Histogram []PayloadLenBin `json:"histogram,omitempty"`
+
+	// Sizes specifies a discrete distribution.
+	Sizes []PayloadLenSize `json:"sizes,omitempty"`
+}
+
+// PayloadLenBin is a histogram bin in PayloadLenDist.
+type PayloadLenBin struct {
+	// Min and Max are the minimum and maximum lengths in this bin, inclusive.
+	Min int `json:"min"`
+	Max int `json:"max"`
+
+	// Weight is the relative frequency of this bin, such as a packet count.
+	Weight float64 `json:"weight"`
+}
+
+// PayloadLenSize is a size in PayloadLenDist.
+type PayloadLenSize struct {
+	Len int `json:"len"`
+
+	// Weight is the relative frequency of this size.
+	// Default is 1.
+	Weight float64 `json:"weight,omitempty"`
+}
+
+// lenBin is a normalized bin, where a size is a bin with Min==Max.
+type lenBin struct {
+	PayloadLenBin
+	cdf uint32
+}
+
+func (dist PayloadLenDist) normalize() (bins []lenBin, e error) {
+	nForms := 0
+	if dist.Min != 0 || dist.Max != 0 {
+		nForms++
+		bins = append(bins, lenBin{PayloadLenBin: PayloadLenBin{Min: dist.Min, Max: dist.Max, Weight: 1}})
+	}
+	if len(dist.Histogram) > 0 {
+		nForms++
+		for _, bin := range dist.Histogram {
+			bins = append(bins, lenBin{PayloadLenBin: bin})
+		}
+	}
+	if len(dist.Sizes) > 0 {
+		nForms++
+		for _, size := range dist.Sizes {
+			weight := size.Weight
+			if weight == 0 {
+				weight = 1
+			}
+			bins = append(bins, lenBin{PayloadLenBin: PayloadLenBin{Min: size.Len, Max: size.Len, Weight: weight}})
+		}
+	}
+	if nForms != 1 {
+		return nil, errors.New("payload length distribution must contain exactly one of Min+Max, Histogram, Sizes")
+	}
+
+	sum := 0.0
+	for _, bin := range bins {
+		if bin.Min < 0 || bin.Max < bin.Min || bin.Max > math.MaxUint16 {
+			return nil, fmt.Errorf("invalid payload length range [%d,%d]", bin.Min, bin.Max)
+		}
+		if bin.Weight < 0 || math.IsNaN(bin.Weight) || math.IsInf(bin.Weight, 0) {
+			return nil, errors.New("payload length weight must be non-negative")
+		}
+		sum += bin.Weight
+	}
+	if sum <= 0 {
+		return nil, errors.New("sum of payload length weights must be positive")
+	}
+
+	nonzero, cum := bins[:0], 0.0
+	for _, bin := range bins {
+		if bin.Weight == 0 {
+			continue
+		}
+		cum += bin.Weight
+		bin.cdf = uint32(min(math.MaxUint32, math.Round(cum/sum*math.MaxUint32)))
+		nonzero = append(nonzero, bin)
+	}
+	nonzero[len(nonzero)-1].cdf = math.MaxUint32
+	return nonzero, nil
+}
+
+// Validate checks the distribution.
+func (dist PayloadLenDist) Validate() error {
+	_, e := dist.normalize()
+	return e
+}
+
+// MaxLen returns the maximum payload length that can be drawn from the distribution.
+func (dist PayloadLenDist) MaxLen() (n int) {
+	bins, _ := dist.normalize()
+	for _, bin := range bins {
+		n = max(n, bin.Max)
+	}
+	return n
+}
@@ -0,0 +1,48 @@
+package ndni
+
+import (
+	"errors"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
+)
+
+// PIT suppression defaults.
+const (
+	DefaultSuppressMin        = 10 * time.Millisecond
+	DefaultSuppressMax        = 100 * time.Millisecond
+	DefaultSuppressMultiplier = 2.0
+)
+
+// SuppressConfig contains PIT suppression configuration.
+// It is used as the forwarder-wide configuration and as a per-FIB-entry override.
+//
+// After forwarding an Interest to a nexthop, retransmissions toward the same nexthop are suppressed for a duration.
+// The suppression duration starts at Min, and is multiplied by Multiplier upon each transmission, up to Max.
+type SuppressConfig struct {
+	Min        nnduration.Nanoseconds `json:"min,omitempty" gqldesc:"Initial/minimum suppression duration."`
+	Max        nnduration.Nanoseconds `json:"max,omitempty" gqldesc:"Maximum suppression duration."`
+	Multiplier float64                `json:"multiplier,omitempty" gqldesc:"Multiplier on each transmission."`
+}
+
+// ApplyDefaults applies defaults.
+func (sc *SuppressConfig) ApplyDefaults() {
+	if sc.Min == 0 {
+		sc.Min = nnduration.Nanoseconds(DefaultSuppressMin)
+	}
+	if sc.Max == 0 {
+		sc.Max = nnduration.Nanoseconds(DefaultSuppressMax)
+	}
+	if sc.Multiplier < 1.0 {
+		sc.Multiplier = DefaultSuppressMultiplier
+	}
+}
+
+// Validate checks configuration values after applying defaults.
+func (sc SuppressConfig) Validate() error {
+	sc.ApplyDefaults()
+	if sc.Min > sc.Max {
+		return errors.New("suppress.min must not exceed suppress.max")
+	}
+	return nil
+}